@@ -97,6 +97,7 @@ type GetMe struct {
 	Role     string `json:"role"`
 
 	DisplayName   string  `json:"displayName"`
+	Region        *string `json:"region"`
 }
 
 func InstallAuthHandlers(app core.App, group pyrin.Group) {
@@ -240,11 +241,17 @@ func InstallAuthHandlers(app core.App, group pyrin.Group) {
 					displayName = user.DisplayName.String
 				}
 
+				var region *string
+				if user.Region.Valid {
+					region = &user.Region.String
+				}
+
 				return GetMe{
 					Id:            user.Id,
 					Username:      user.Username,
 					Role:          user.Role,
 					DisplayName:   displayName,
+					Region:        region,
 				}, nil
 			},
 		},
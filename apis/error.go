@@ -15,6 +15,10 @@ const (
 
 	ErrTypeInvalidFilter pyrin.ErrorType = "INVALID_FILTER"
 	ErrTypeInvalidSort   pyrin.ErrorType = "INVALID_SORT"
+	ErrTypeInvalidSeason pyrin.ErrorType = "INVALID_SEASON"
+	ErrTypeInvalidRegion pyrin.ErrorType = "INVALID_REGION"
+
+	ErrTypeInvalidSearchParams pyrin.ErrorType = "INVALID_SEARCH_PARAMS"
 
 	ErrTypeMediaNotFound            pyrin.ErrorType = "MEDIA_NOT_FOUND"
 	ErrTypeMediaPartReleaseNotFound pyrin.ErrorType = "MEDIA_PART_RELEASE_NOT_FOUND"
@@ -28,6 +32,14 @@ const (
 	ErrTypeShowNotFound             pyrin.ErrorType = "SHOW_NOT_FOUND"
 	ErrTypeShowSeasonNotFound       pyrin.ErrorType = "SHOW_SEASON_NOT_FOUND"
 	ErrTypeShowSeasonItemNotFound   pyrin.ErrorType = "SHOW_SEASON_ITEM_NOT_FOUND"
+	ErrTypePersonNotFound           pyrin.ErrorType = "PERSON_NOT_FOUND"
+	ErrTypeJobNotFound              pyrin.ErrorType = "JOB_NOT_FOUND"
+
+	ErrTypeInvalidJobState pyrin.ErrorType = "INVALID_JOB_STATE"
+	ErrTypeJobsStopping    pyrin.ErrorType = "JOBS_STOPPING"
+
+	ErrTypeJobScheduleNotFound pyrin.ErrorType = "JOB_SCHEDULE_NOT_FOUND"
+	ErrTypeInvalidJobSchedule  pyrin.ErrorType = "INVALID_JOB_SCHEDULE"
 
 	ErrTypePartAlreadyExists pyrin.ErrorType = "PART_ALREADY_EXISTS"
 )
@@ -56,6 +68,30 @@ func InvalidSort(err error) *pyrin.Error {
 	}
 }
 
+func InvalidSeason(season string) *pyrin.Error {
+	return &pyrin.Error{
+		Code:    http.StatusBadRequest,
+		Type:    ErrTypeInvalidSeason,
+		Message: "Invalid season: " + season,
+	}
+}
+
+func InvalidRegion(region string) *pyrin.Error {
+	return &pyrin.Error{
+		Code:    http.StatusBadRequest,
+		Type:    ErrTypeInvalidRegion,
+		Message: "Invalid region: " + region,
+	}
+}
+
+func InvalidSearchParams(message string) *pyrin.Error {
+	return &pyrin.Error{
+		Code:    http.StatusBadRequest,
+		Type:    ErrTypeInvalidSearchParams,
+		Message: "Invalid search params: " + message,
+	}
+}
+
 func MediaNotFound() *pyrin.Error {
 	return &pyrin.Error{
 		Code:    http.StatusNotFound,
@@ -104,6 +140,54 @@ func ImageNotFound() *pyrin.Error {
 	}
 }
 
+func PersonNotFound() *pyrin.Error {
+	return &pyrin.Error{
+		Code:    http.StatusNotFound,
+		Type:    ErrTypePersonNotFound,
+		Message: "Person not found",
+	}
+}
+
+func JobNotFound() *pyrin.Error {
+	return &pyrin.Error{
+		Code:    http.StatusNotFound,
+		Type:    ErrTypeJobNotFound,
+		Message: "Job not found",
+	}
+}
+
+func InvalidJobState(message string) *pyrin.Error {
+	return &pyrin.Error{
+		Code:    http.StatusBadRequest,
+		Type:    ErrTypeInvalidJobState,
+		Message: "Invalid job state: " + message,
+	}
+}
+
+func JobsStopping() *pyrin.Error {
+	return &pyrin.Error{
+		Code:    http.StatusServiceUnavailable,
+		Type:    ErrTypeJobsStopping,
+		Message: "Server is stopping, no new jobs can be queued",
+	}
+}
+
+func JobScheduleNotFound() *pyrin.Error {
+	return &pyrin.Error{
+		Code:    http.StatusNotFound,
+		Type:    ErrTypeJobScheduleNotFound,
+		Message: "Job schedule not found",
+	}
+}
+
+func InvalidJobSchedule(message string) *pyrin.Error {
+	return &pyrin.Error{
+		Code:    http.StatusBadRequest,
+		Type:    ErrTypeInvalidJobSchedule,
+		Message: "Invalid job schedule: " + message,
+	}
+}
+
 func NotificationNotFound() *pyrin.Error {
 	return &pyrin.Error{
 		Code:    http.StatusNotFound,
@@ -0,0 +1,367 @@
+package apis
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/nanoteck137/pyrin"
+	"github.com/nanoteck137/pyrin/ember"
+	"github.com/nanoteck137/watchbook/core"
+	"github.com/nanoteck137/watchbook/database"
+	"github.com/nanoteck137/watchbook/types"
+	"github.com/nanoteck137/watchbook/utils"
+)
+
+type JobPayloadValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type Job struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+
+	Status   types.JobStatus `json:"status"`
+	Priority int             `json:"priority"`
+	RunAt    int64           `json:"runAt"`
+
+	Attempts    int `json:"attempts"`
+	MaxAttempts int `json:"maxAttempts"`
+
+	Payload []JobPayloadValue `json:"payload"`
+	Error   *string           `json:"error"`
+
+	UserId *string `json:"userId"`
+
+	WorkerId     *string `json:"workerId"`
+	Heartbeat    *int64  `json:"heartbeat"`
+	LeaseExpires *int64  `json:"leaseExpires"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+}
+
+type GetJobs struct {
+	Page types.Page `json:"page"`
+	Jobs []Job      `json:"jobs"`
+}
+
+type GetJobById struct {
+	Job
+}
+
+// NOTE(patrik): All the jobs uses a KVStore as the payload, if the payload
+// can't be parsed it's shown as the raw string instead
+func convertJobPayload(payload string) []JobPayloadValue {
+	store, err := ember.DeserializeKVStore(payload)
+	if err != nil {
+		return []JobPayloadValue{
+			{
+				Key:   "raw",
+				Value: payload,
+			},
+		}
+	}
+
+	res := make([]JobPayloadValue, 0, len(store))
+	for k, v := range store {
+		res = append(res, JobPayloadValue{
+			Key:   k,
+			Value: v,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Key < res[j].Key
+	})
+
+	return res
+}
+
+func ConvertDBJob(job database.Job) Job {
+	return Job{
+		Id:           job.Id,
+		Type:         job.Type,
+		Status:       job.Status,
+		Priority:     job.Priority,
+		RunAt:        job.RunAt,
+		Attempts:     job.Attempts,
+		MaxAttempts:  job.MaxAttempts,
+		Payload:      convertJobPayload(job.Payload),
+		Error:        utils.SqlNullToStringPtr(job.Error),
+		UserId:       utils.SqlNullToStringPtr(job.UserId),
+		WorkerId:     utils.SqlNullToStringPtr(job.WorkerId),
+		Heartbeat:    utils.SqlNullToInt64Ptr(job.Heartbeat),
+		LeaseExpires: utils.SqlNullToInt64Ptr(job.LeaseExpires),
+		Created:      job.Created,
+		Updated:      job.Updated,
+	}
+}
+
+// NOTE(patrik): Returns the job if the user is allowed to see it, admins can
+// see all the jobs and the other users can only see their own jobs
+func getJobForUser(ctx context.Context, app core.App, user *database.User, id string) (database.Job, error) {
+	job, err := app.DB().GetJobById(ctx, id)
+	if err != nil {
+		if errors.Is(err, database.ErrItemNotFound) {
+			return database.Job{}, JobNotFound()
+		}
+
+		return database.Job{}, err
+	}
+
+	if RequireAdmin(user) != nil {
+		if !job.UserId.Valid || job.UserId.String != user.Id {
+			return database.Job{}, JobNotFound()
+		}
+	}
+
+	return job, nil
+}
+
+// NOTE(patrik): Used by all the handlers that queues jobs so no new jobs is
+// queued while the server is shutting down
+func createJob(ctx context.Context, app core.App, params database.CreateJobParams) (string, error) {
+	if app.JobProcessor().IsStopping() {
+		return "", JobsStopping()
+	}
+
+	return app.DB().CreateJob(ctx, params)
+}
+
+func getPagedJobs(c pyrin.Context, app core.App, userId *string) (GetJobs, error) {
+	q := c.Request().URL.Query()
+	opts := getPageOptions(q)
+
+	filterStr := q.Get("filter")
+	sortStr := q.Get("sort")
+
+	jobs, page, err := app.DB().GetPagedJobs(c.Request().Context(), userId, filterStr, sortStr, opts)
+	if err != nil {
+		if errors.Is(err, database.ErrInvalidFilter) {
+			return GetJobs{}, InvalidFilter(err)
+		}
+
+		if errors.Is(err, database.ErrInvalidSort) {
+			return GetJobs{}, InvalidSort(err)
+		}
+
+		return GetJobs{}, err
+	}
+
+	res := GetJobs{
+		Page: page,
+		Jobs: make([]Job, len(jobs)),
+	}
+
+	for i, job := range jobs {
+		res.Jobs[i] = ConvertDBJob(job)
+	}
+
+	return res, nil
+}
+
+func InstallJobHandlers(app core.App, group pyrin.Group) {
+	group.Register(
+		pyrin.ApiHandler{
+			Name:         "GetJobs",
+			Method:       http.MethodGet,
+			Path:         "/jobs",
+			ResponseType: GetJobs{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				_, err := User(app, c, RequireAdmin)
+				if err != nil {
+					return nil, err
+				}
+
+				return getPagedJobs(c, app, nil)
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "GetMyJobs",
+			Method:       http.MethodGet,
+			Path:         "/user/jobs",
+			ResponseType: GetJobs{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				user, err := User(app, c)
+				if err != nil {
+					return nil, err
+				}
+
+				return getPagedJobs(c, app, &user.Id)
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "GetJobById",
+			Method:       http.MethodGet,
+			Path:         "/jobs/:id",
+			ResponseType: GetJobById{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				user, err := User(app, c)
+				if err != nil {
+					return nil, err
+				}
+
+				job, err := getJobForUser(c.Request().Context(), app, user, id)
+				if err != nil {
+					return nil, err
+				}
+
+				return GetJobById{
+					Job: ConvertDBJob(job),
+				}, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:   "RetryJob",
+			Method: http.MethodPost,
+			Path:   "/jobs/:id/retry",
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				user, err := User(app, c, RequireAdmin)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := c.Request().Context()
+
+				_, err = getJobForUser(ctx, app, user, id)
+				if err != nil {
+					return nil, err
+				}
+
+				if app.JobProcessor().IsStopping() {
+					return nil, JobsStopping()
+				}
+
+				// NOTE(patrik): Start over with the attempts so the job
+				// gets all the retries again
+				updated, err := app.DB().UpdateJobWithStatus(ctx, id, []types.JobStatus{
+					types.JobStatusFailed,
+					types.JobStatusCancelled,
+				}, database.JobChanges{
+					Status: database.Change[types.JobStatus]{
+						Value:   types.JobStatusQueued,
+						Changed: true,
+					},
+					RunAt: database.Change[int64]{
+						Value:   time.Now().UnixMilli(),
+						Changed: true,
+					},
+					Attempts: database.Change[int]{
+						Value:   0,
+						Changed: true,
+					},
+					Error: database.Change[sql.NullString]{
+						Value:   sql.NullString{},
+						Changed: true,
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				if !updated {
+					return nil, InvalidJobState("only failed or cancelled jobs can be retried")
+				}
+
+				return nil, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:   "CancelJob",
+			Method: http.MethodPost,
+			Path:   "/jobs/:id/cancel",
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				// NOTE(patrik): Users can cancel their own jobs
+				user, err := User(app, c)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := c.Request().Context()
+
+				_, err = getJobForUser(ctx, app, user, id)
+				if err != nil {
+					return nil, err
+				}
+
+				updated, err := app.DB().UpdateJobWithStatus(ctx, id, []types.JobStatus{
+					types.JobStatusQueued,
+					types.JobStatusRunning,
+				}, database.JobChanges{
+					Status: database.Change[types.JobStatus]{
+						Value:   types.JobStatusCancelled,
+						Changed: true,
+					},
+					Heartbeat: database.Change[sql.NullInt64]{
+						Value:   sql.NullInt64{},
+						Changed: true,
+					},
+					LeaseExpires: database.Change[sql.NullInt64]{
+						Value:   sql.NullInt64{},
+						Changed: true,
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				if !updated {
+					return nil, InvalidJobState("only queued or running jobs can be cancelled")
+				}
+
+				// NOTE(patrik): Stop the handler if the job is running, the
+				// worker sees the new status and doesn't update the job
+				app.JobProcessor().Cancel(id)
+
+				return nil, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:   "DeleteJob",
+			Method: http.MethodDelete,
+			Path:   "/jobs/:id",
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				user, err := User(app, c, RequireAdmin)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := c.Request().Context()
+
+				job, err := getJobForUser(ctx, app, user, id)
+				if err != nil {
+					return nil, err
+				}
+
+				if !types.IsFinishedJobStatus(job.Status) {
+					return nil, InvalidJobState("only finished jobs can be deleted")
+				}
+
+				err = app.DB().RemoveJob(ctx, job.Id)
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, nil
+			},
+		},
+	)
+}
@@ -0,0 +1,714 @@
+package apis
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nanoteck137/pyrin"
+	"github.com/nanoteck137/pyrin/anvil"
+	"github.com/nanoteck137/pyrin/ember"
+	"github.com/nanoteck137/validate"
+	"github.com/nanoteck137/watchbook/config"
+	"github.com/nanoteck137/watchbook/core"
+	"github.com/nanoteck137/watchbook/database"
+	"github.com/nanoteck137/watchbook/job"
+	"github.com/nanoteck137/watchbook/utils"
+)
+
+// NOTE(patrik): Name of the schedule created from the [seasonal_import]
+// section of the config
+const SeasonalImportScheduleName = "seasonal-import"
+
+type JobSchedule struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+
+	Type        string            `json:"type"`
+	Payload     []JobPayloadValue `json:"payload"`
+	MaxAttempts int               `json:"maxAttempts"`
+
+	Cron     *string `json:"cron"`
+	Interval *string `json:"interval"`
+
+	Enabled    bool `json:"enabled"`
+	FromConfig bool `json:"fromConfig"`
+
+	LastRun   *int64  `json:"lastRun"`
+	NextRun   *int64  `json:"nextRun"`
+	LastJobId *string `json:"lastJobId"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+}
+
+type GetJobSchedules struct {
+	Schedules []JobSchedule `json:"schedules"`
+}
+
+type GetJobScheduleById struct {
+	JobSchedule
+}
+
+type CreateJobSchedule struct {
+	Id string `json:"id"`
+}
+
+type RunJobSchedule struct {
+	JobId string `json:"jobId"`
+}
+
+type CreateJobScheduleBody struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	// NOTE(patrik): One of cron ("0 4 * * *") and interval ("6h") needs to
+	// be set
+	Cron     string `json:"cron,omitempty"`
+	Interval string `json:"interval,omitempty"`
+
+	Payload     []JobPayloadValue `json:"payload,omitempty"`
+	MaxAttempts int               `json:"maxAttempts,omitempty"`
+	Enabled     bool              `json:"enabled"`
+}
+
+func (b *CreateJobScheduleBody) Transform() {
+	b.Name = anvil.String(b.Name)
+	b.Type = anvil.String(b.Type)
+	b.Cron = anvil.String(b.Cron)
+	b.Interval = anvil.String(b.Interval)
+}
+
+func (b CreateJobScheduleBody) Validate() error {
+	return validate.ValidateStruct(&b,
+		validate.Field(&b.Name, validate.Required),
+		validate.Field(&b.Type, validate.Required),
+		validate.Field(&b.Cron, validate.Required.When(b.Interval == "").Error("cron or interval needs to be set")),
+		validate.Field(&b.Interval, validate.Empty.When(b.Cron != "").Error("can't be used together with cron")),
+		validate.Field(&b.MaxAttempts, validate.Min(0)),
+	)
+}
+
+type EditJobScheduleBody struct {
+	Name *string `json:"name,omitempty"`
+	Type *string `json:"type,omitempty"`
+
+	// NOTE(patrik): Setting one of cron and interval clears the other one
+	Cron     *string `json:"cron,omitempty"`
+	Interval *string `json:"interval,omitempty"`
+
+	Payload     *[]JobPayloadValue `json:"payload,omitempty"`
+	MaxAttempts *int               `json:"maxAttempts,omitempty"`
+	Enabled     *bool              `json:"enabled,omitempty"`
+}
+
+func (b *EditJobScheduleBody) Transform() {
+	b.Name = anvil.StringPtr(b.Name)
+	b.Type = anvil.StringPtr(b.Type)
+	b.Cron = anvil.StringPtr(b.Cron)
+	b.Interval = anvil.StringPtr(b.Interval)
+}
+
+func (b EditJobScheduleBody) Validate() error {
+	return validate.ValidateStruct(&b,
+		validate.Field(&b.Name, validate.Required.When(b.Name != nil)),
+		validate.Field(&b.Type, validate.Required.When(b.Type != nil)),
+		validate.Field(&b.Cron, validate.Required.When(b.Cron != nil)),
+		validate.Field(&b.Interval, validate.Required.When(b.Interval != nil), validate.Nil.When(b.Cron != nil).Error("can't be used together with cron")),
+		validate.Field(&b.MaxAttempts, validate.Min(0)),
+	)
+}
+
+func ConvertDBJobSchedule(s database.JobSchedule) JobSchedule {
+	var interval *string
+	if s.Interval.Valid {
+		i := (time.Duration(s.Interval.Int64) * time.Millisecond).String()
+		interval = &i
+	}
+
+	return JobSchedule{
+		Id:          s.Id,
+		Name:        s.Name,
+		Type:        s.Type,
+		Payload:     convertJobPayload(s.Payload),
+		MaxAttempts: s.MaxAttempts,
+		Cron:        utils.SqlNullToStringPtr(s.Cron),
+		Interval:    interval,
+		Enabled:     s.Enabled,
+		FromConfig:  s.FromConfig,
+		LastRun:     utils.SqlNullToInt64Ptr(s.LastRun),
+		NextRun:     utils.SqlNullToInt64Ptr(s.NextRun),
+		LastJobId:   utils.SqlNullToStringPtr(s.LastJobId),
+		Created:     s.Created,
+		Updated:     s.Updated,
+	}
+}
+
+func serializeJobPayload(values []JobPayloadValue) (string, error) {
+	store := ember.KVStore{}
+	for _, v := range values {
+		store[v.Key] = v.Value
+	}
+
+	return store.Serialize()
+}
+
+func scheduleCronValue(cron string) sql.NullString {
+	return sql.NullString{
+		String: cron,
+		Valid:  cron != "",
+	}
+}
+
+func scheduleIntervalValue(interval time.Duration) sql.NullInt64 {
+	return sql.NullInt64{
+		Int64: interval.Milliseconds(),
+		Valid: interval != 0,
+	}
+}
+
+// NOTE(patrik): Returns the next run of the schedule, disabled schedules
+// doesn't have a next run
+func scheduleNextRun(schedule job.Schedule, enabled bool, lastRun sql.NullInt64) sql.NullInt64 {
+	if !enabled {
+		return sql.NullInt64{}
+	}
+
+	var last time.Time
+	if lastRun.Valid {
+		last = time.UnixMilli(lastRun.Int64)
+	}
+
+	next := job.NextRun(schedule, last, time.Now())
+	if next.IsZero() {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{
+		Int64: next.UnixMilli(),
+		Valid: true,
+	}
+}
+
+func parseScheduleInterval(interval string) (time.Duration, error) {
+	if interval == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, InvalidJobSchedule("invalid interval: " + err.Error())
+	}
+
+	return d, nil
+}
+
+func getJobScheduleById(ctx context.Context, app core.App, id string) (database.JobSchedule, error) {
+	schedule, err := app.DB().GetJobScheduleById(ctx, id)
+	if err != nil {
+		if errors.Is(err, database.ErrItemNotFound) {
+			return database.JobSchedule{}, JobScheduleNotFound()
+		}
+
+		return database.JobSchedule{}, err
+	}
+
+	return schedule, nil
+}
+
+// NOTE(patrik): Creates, updates and removes the schedules from the config
+// file, the schedules created from the api is left alone. Needs to run after
+// the job handlers has been registered.
+func SyncConfigJobSchedules(ctx context.Context, app core.App) error {
+	schedules := make(map[string]config.ScheduleConfig, len(app.Config().Schedules)+1)
+	for name, c := range app.Config().Schedules {
+		schedules[name] = c
+	}
+
+	seasonalImport := app.Config().SeasonalImport
+	if seasonalImport.Enabled {
+		if _, exists := schedules[SeasonalImportScheduleName]; exists {
+			app.Logger().Error("seasonal_import is enabled but the schedule name is already used, skipping", "name", SeasonalImportScheduleName)
+		} else {
+			schedules[SeasonalImportScheduleName] = config.ScheduleConfig{
+				Type:        JobTypeImportCurrentSeason,
+				Interval:    seasonalImport.Interval,
+				Enabled:     true,
+				MaxAttempts: 1,
+				Payload:     []string{"providerName=" + seasonalImport.Provider},
+			}
+		}
+	}
+
+	names := make([]string, 0, len(schedules))
+	for name := range schedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		err := syncConfigJobSchedule(ctx, app, name, schedules[name])
+		if err != nil {
+			return err
+		}
+	}
+
+	dbSchedules, err := app.DB().GetAllJobSchedules(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range dbSchedules {
+		if _, exists := schedules[s.Name]; exists || !s.FromConfig {
+			continue
+		}
+
+		err := app.DB().RemoveJobSchedule(ctx, s.Id)
+		if err != nil {
+			return err
+		}
+
+		app.Logger().Info("removed job schedule that is no longer in the config", "name", s.Name)
+	}
+
+	return nil
+}
+
+func syncConfigJobSchedule(ctx context.Context, app core.App, name string, c config.ScheduleConfig) error {
+	if !app.JobProcessor().HasHandler(c.Type) {
+		app.Logger().Error("unknown job type for schedule, skipping", "name", name, "type", c.Type)
+		return nil
+	}
+
+	schedule, err := job.ParseSchedule(c.Cron, c.Interval)
+	if err != nil {
+		app.Logger().Error("invalid schedule, skipping", "name", name, "err", err)
+		return nil
+	}
+
+	store := ember.KVStore{}
+	for _, kv := range c.Payload {
+		k, v, _ := strings.Cut(kv, "=")
+		store[k] = v
+	}
+
+	payload, err := store.Serialize()
+	if err != nil {
+		return err
+	}
+
+	maxAttempts := c.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	cron := scheduleCronValue(strings.TrimSpace(c.Cron))
+	interval := scheduleIntervalValue(c.Interval)
+
+	existing, err := app.DB().GetJobScheduleByName(ctx, name)
+	if err != nil {
+		if !errors.Is(err, database.ErrItemNotFound) {
+			return err
+		}
+
+		_, err = app.DB().CreateJobSchedule(ctx, database.CreateJobScheduleParams{
+			Name:        name,
+			Type:        c.Type,
+			Payload:     payload,
+			MaxAttempts: maxAttempts,
+			Cron:        cron,
+			Interval:    interval,
+			Enabled:     c.Enabled,
+			FromConfig:  true,
+			NextRun:     scheduleNextRun(schedule, c.Enabled, sql.NullInt64{}),
+		})
+		if err != nil {
+			return err
+		}
+
+		app.Logger().Info("created job schedule from the config", "name", name)
+
+		return nil
+	}
+
+	if !existing.FromConfig {
+		app.Logger().Error("schedule name is already used by a schedule created from the api, skipping", "name", name)
+		return nil
+	}
+
+	scheduleChanged := cron != existing.Cron || interval != existing.Interval
+
+	changes := database.JobScheduleChanges{
+		Type: database.Change[string]{
+			Value:   c.Type,
+			Changed: c.Type != existing.Type,
+		},
+		Payload: database.Change[string]{
+			Value:   payload,
+			Changed: payload != existing.Payload,
+		},
+		MaxAttempts: database.Change[int]{
+			Value:   maxAttempts,
+			Changed: maxAttempts != existing.MaxAttempts,
+		},
+		Cron: database.Change[sql.NullString]{
+			Value:   cron,
+			Changed: scheduleChanged,
+		},
+		Interval: database.Change[sql.NullInt64]{
+			Value:   interval,
+			Changed: scheduleChanged,
+		},
+		Enabled: database.Change[bool]{
+			Value:   c.Enabled,
+			Changed: c.Enabled != existing.Enabled,
+		},
+	}
+
+	// NOTE(patrik): Keep the next run when nothing changed so restarting
+	// the server doesn't move the schedule
+	if scheduleChanged || c.Enabled != existing.Enabled || (c.Enabled && !existing.NextRun.Valid) {
+		changes.NextRun = database.Change[sql.NullInt64]{
+			Value:   scheduleNextRun(schedule, c.Enabled, existing.LastRun),
+			Changed: true,
+		}
+	}
+
+	return app.DB().UpdateJobSchedule(ctx, existing.Id, changes)
+}
+
+func InstallJobScheduleHandlers(app core.App, group pyrin.Group) {
+	group.Register(
+		pyrin.ApiHandler{
+			Name:         "GetJobSchedules",
+			Method:       http.MethodGet,
+			Path:         "/job-schedules",
+			ResponseType: GetJobSchedules{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				_, err := User(app, c, RequireAdmin)
+				if err != nil {
+					return nil, err
+				}
+
+				schedules, err := app.DB().GetAllJobSchedules(c.Request().Context())
+				if err != nil {
+					return nil, err
+				}
+
+				res := GetJobSchedules{
+					Schedules: make([]JobSchedule, len(schedules)),
+				}
+
+				for i, s := range schedules {
+					res.Schedules[i] = ConvertDBJobSchedule(s)
+				}
+
+				return res, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "GetJobScheduleById",
+			Method:       http.MethodGet,
+			Path:         "/job-schedules/:id",
+			ResponseType: GetJobScheduleById{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				_, err := User(app, c, RequireAdmin)
+				if err != nil {
+					return nil, err
+				}
+
+				schedule, err := getJobScheduleById(c.Request().Context(), app, id)
+				if err != nil {
+					return nil, err
+				}
+
+				return GetJobScheduleById{
+					JobSchedule: ConvertDBJobSchedule(schedule),
+				}, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "CreateJobSchedule",
+			Method:       http.MethodPost,
+			Path:         "/job-schedules",
+			ResponseType: CreateJobSchedule{},
+			BodyType:     CreateJobScheduleBody{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				_, err := User(app, c, RequireAdmin)
+				if err != nil {
+					return nil, err
+				}
+
+				body, err := pyrin.Body[CreateJobScheduleBody](c)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := c.Request().Context()
+
+				if !app.JobProcessor().HasHandler(body.Type) {
+					return nil, InvalidJobSchedule("unknown job type")
+				}
+
+				interval, err := parseScheduleInterval(body.Interval)
+				if err != nil {
+					return nil, err
+				}
+
+				schedule, err := job.ParseSchedule(body.Cron, interval)
+				if err != nil {
+					return nil, InvalidJobSchedule(err.Error())
+				}
+
+				_, err = app.DB().GetJobScheduleByName(ctx, body.Name)
+				if err == nil {
+					return nil, InvalidJobSchedule("name is already used")
+				}
+
+				if !errors.Is(err, database.ErrItemNotFound) {
+					return nil, err
+				}
+
+				payload, err := serializeJobPayload(body.Payload)
+				if err != nil {
+					return nil, err
+				}
+
+				id, err := app.DB().CreateJobSchedule(ctx, database.CreateJobScheduleParams{
+					Name:        body.Name,
+					Type:        body.Type,
+					Payload:     payload,
+					MaxAttempts: max(body.MaxAttempts, 1),
+					Cron:        scheduleCronValue(body.Cron),
+					Interval:    scheduleIntervalValue(interval),
+					Enabled:     body.Enabled,
+					FromConfig:  false,
+					NextRun:     scheduleNextRun(schedule, body.Enabled, sql.NullInt64{}),
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				return CreateJobSchedule{
+					Id: id,
+				}, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:     "EditJobSchedule",
+			Method:   http.MethodPatch,
+			Path:     "/job-schedules/:id",
+			BodyType: EditJobScheduleBody{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				_, err := User(app, c, RequireAdmin)
+				if err != nil {
+					return nil, err
+				}
+
+				body, err := pyrin.Body[EditJobScheduleBody](c)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := c.Request().Context()
+
+				dbSchedule, err := getJobScheduleById(ctx, app, id)
+				if err != nil {
+					return nil, err
+				}
+
+				if dbSchedule.FromConfig {
+					return nil, InvalidJobSchedule("schedule is managed by the config file")
+				}
+
+				changes := database.JobScheduleChanges{}
+
+				if body.Name != nil && *body.Name != dbSchedule.Name {
+					_, err = app.DB().GetJobScheduleByName(ctx, *body.Name)
+					if err == nil {
+						return nil, InvalidJobSchedule("name is already used")
+					}
+
+					if !errors.Is(err, database.ErrItemNotFound) {
+						return nil, err
+					}
+
+					changes.Name = database.Change[string]{
+						Value:   *body.Name,
+						Changed: true,
+					}
+				}
+
+				if body.Type != nil {
+					if !app.JobProcessor().HasHandler(*body.Type) {
+						return nil, InvalidJobSchedule("unknown job type")
+					}
+
+					changes.Type = database.Change[string]{
+						Value:   *body.Type,
+						Changed: *body.Type != dbSchedule.Type,
+					}
+				}
+
+				if body.Payload != nil {
+					payload, err := serializeJobPayload(*body.Payload)
+					if err != nil {
+						return nil, err
+					}
+
+					changes.Payload = database.Change[string]{
+						Value:   payload,
+						Changed: payload != dbSchedule.Payload,
+					}
+				}
+
+				if body.MaxAttempts != nil {
+					maxAttempts := max(*body.MaxAttempts, 1)
+
+					changes.MaxAttempts = database.Change[int]{
+						Value:   maxAttempts,
+						Changed: maxAttempts != dbSchedule.MaxAttempts,
+					}
+				}
+
+				cron := dbSchedule.Cron
+				interval := dbSchedule.Interval
+
+				if body.Cron != nil {
+					cron = scheduleCronValue(*body.Cron)
+					interval = sql.NullInt64{}
+				}
+
+				if body.Interval != nil {
+					d, err := parseScheduleInterval(*body.Interval)
+					if err != nil {
+						return nil, err
+					}
+
+					cron = sql.NullString{}
+					interval = scheduleIntervalValue(d)
+				}
+
+				schedule, err := job.ParseSchedule(cron.String, time.Duration(interval.Int64)*time.Millisecond)
+				if err != nil {
+					return nil, InvalidJobSchedule(err.Error())
+				}
+
+				scheduleChanged := cron != dbSchedule.Cron || interval != dbSchedule.Interval
+
+				changes.Cron = database.Change[sql.NullString]{
+					Value:   cron,
+					Changed: scheduleChanged,
+				}
+				changes.Interval = database.Change[sql.NullInt64]{
+					Value:   interval,
+					Changed: scheduleChanged,
+				}
+
+				enabled := dbSchedule.Enabled
+				if body.Enabled != nil {
+					enabled = *body.Enabled
+				}
+
+				changes.Enabled = database.Change[bool]{
+					Value:   enabled,
+					Changed: enabled != dbSchedule.Enabled,
+				}
+
+				if scheduleChanged || enabled != dbSchedule.Enabled {
+					changes.NextRun = database.Change[sql.NullInt64]{
+						Value:   scheduleNextRun(schedule, enabled, dbSchedule.LastRun),
+						Changed: true,
+					}
+				}
+
+				err = app.DB().UpdateJobSchedule(ctx, dbSchedule.Id, changes)
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:   "DeleteJobSchedule",
+			Method: http.MethodDelete,
+			Path:   "/job-schedules/:id",
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				_, err := User(app, c, RequireAdmin)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := c.Request().Context()
+
+				schedule, err := getJobScheduleById(ctx, app, id)
+				if err != nil {
+					return nil, err
+				}
+
+				if schedule.FromConfig {
+					return nil, InvalidJobSchedule("schedule is managed by the config file")
+				}
+
+				err = app.DB().RemoveJobSchedule(ctx, schedule.Id)
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "RunJobSchedule",
+			Method:       http.MethodPost,
+			Path:         "/job-schedules/:id/run",
+			ResponseType: RunJobSchedule{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				_, err := User(app, c, RequireAdmin)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := c.Request().Context()
+
+				schedule, err := getJobScheduleById(ctx, app, id)
+				if err != nil {
+					return nil, err
+				}
+
+				// NOTE(patrik): Runs the schedule now without changing the
+				// next run, also works for disabled schedules
+				jobId, err := app.JobProcessor().RunSchedule(ctx, schedule)
+				if err != nil {
+					if errors.Is(err, job.ErrStopping) {
+						return nil, JobsStopping()
+					}
+
+					return nil, err
+				}
+
+				return RunJobSchedule{
+					JobId: jobId,
+				}, nil
+			},
+		},
+	)
+}
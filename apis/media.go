@@ -5,19 +5,23 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/nanoteck137/pyrin"
 	"github.com/nanoteck137/pyrin/anvil"
+	"github.com/nanoteck137/pyrin/ember"
 	"github.com/nanoteck137/validate"
 	"github.com/nanoteck137/watchbook/core"
 	"github.com/nanoteck137/watchbook/database"
 	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/provider/merge"
 	"github.com/nanoteck137/watchbook/types"
 	"github.com/nanoteck137/watchbook/utils"
 )
@@ -67,20 +71,183 @@ type Media struct {
 	BannerUrl *string `json:"bannerUrl"`
 	LogoUrl   *string `json:"logoUrl"`
 
-	DefaultProvider *string         `json:"defaultProvider"`
-	Providers       []ProviderValue `json:"providers"`
+	DefaultProvider *string            `json:"defaultProvider"`
+	Providers       []ProviderValue    `json:"providers"`
+	FieldSources    []MediaFieldSource `json:"fieldSources"`
 
 	User    *MediaUser    `json:"user,omitempty"`
 	Release *MediaRelease `json:"release"`
 }
 
+type MediaFieldSource struct {
+	Field               string `json:"field"`
+	ProviderName        string `json:"providerName"`
+	ProviderDisplayName string `json:"providerDisplayName"`
+}
+
+func createFieldSources(pm *provider.ProviderManager, store ember.KVStore) []MediaFieldSource {
+	res := make([]MediaFieldSource, 0, len(store))
+
+	// NOTE(patrik): Use the order of merge.Fields
+	for _, field := range merge.Fields {
+		name, ok := store[string(field)]
+		if !ok {
+			continue
+		}
+
+		displayName := name
+		if name == merge.SourceManual {
+			displayName = "Manual"
+		} else if info, ok := pm.GetProviderInfo(name); ok {
+			displayName = info.GetDisplayName()
+		}
+
+		res = append(res, MediaFieldSource{
+			Field:               string(field),
+			ProviderName:        name,
+			ProviderDisplayName: displayName,
+		})
+	}
+
+	return res
+}
+
 type GetMedia struct {
 	Page  types.Page `json:"page"`
 	Media []Media    `json:"media"`
 }
 
+type MediaThemeSong struct {
+	Type   types.ThemeSongType `json:"type"`
+	Index  int64               `json:"index"`
+	Name   string              `json:"name"`
+	Artist string              `json:"artist"`
+}
+
+type MediaRelation struct {
+	Type                types.MediaRelationType `json:"type"`
+	ProviderName        string                  `json:"providerName"`
+	ProviderDisplayName string                  `json:"providerDisplayName"`
+	ProviderId          string                  `json:"providerId"`
+	Title               string                  `json:"title"`
+
+	// NOTE(patrik): Set when the related entry is inside the library
+	Media *Media `json:"media"`
+}
+
+type GetMediaRelations struct {
+	Relations []MediaRelation `json:"relations"`
+}
+
+type MissingMediaRelationSource struct {
+	Type       types.MediaRelationType `json:"type"`
+	MediaId    string                  `json:"mediaId"`
+	MediaTitle string                  `json:"mediaTitle"`
+}
+
+type MissingMediaRelation struct {
+	ProviderName        string `json:"providerName"`
+	ProviderDisplayName string `json:"providerDisplayName"`
+	ProviderId          string `json:"providerId"`
+	Title               string `json:"title"`
+	// NOTE(patrik): The provider supports importing the entry
+	CanImport bool `json:"canImport"`
+
+	// NOTE(patrik): The media inside the library that has the relation
+	Sources []MissingMediaRelationSource `json:"sources"`
+}
+
+type GetMissingMediaRelations struct {
+	Relations []MissingMediaRelation `json:"relations"`
+}
+
+func getProviderDisplayName(pm *provider.ProviderManager, name string) string {
+	if info, ok := pm.GetProviderInfo(name); ok {
+		return info.GetDisplayName()
+	}
+
+	if displayName, ok := provider.ExternalIds[name]; ok {
+		return displayName
+	}
+
+	return name
+}
+
+type MediaAvailability struct {
+	Region       string                 `json:"region"`
+	Type         types.AvailabilityType `json:"type"`
+	Service      string                 `json:"service"`
+	ServiceName  string                 `json:"serviceName"`
+	ProviderName string                 `json:"providerName"`
+}
+
+type GetMediaAvailability struct {
+	// NOTE(patrik): The region used for the result, empty when all the
+	// regions is returned
+	Region       string              `json:"region"`
+	Availability []MediaAvailability `json:"availability"`
+}
+
+type MediaImage struct {
+	File                string          `json:"file"`
+	Type                types.ImageType `json:"type"`
+	ProviderName        string          `json:"providerName"`
+	ProviderDisplayName string          `json:"providerDisplayName"`
+	Url                 string          `json:"url"`
+
+	IsCover  bool `json:"isCover"`
+	IsBanner bool `json:"isBanner"`
+	IsLogo   bool `json:"isLogo"`
+}
+
+type GetMediaImages struct {
+	Images []MediaImage `json:"images"`
+}
+
+type MediaImageProviderError struct {
+	ProviderName string `json:"providerName"`
+	Message      string `json:"message"`
+}
+
+type RefreshMediaImages struct {
+	Added  int                       `json:"added"`
+	Errors []MediaImageProviderError `json:"errors"`
+}
+
+type PromoteMediaImageBody struct {
+	File string `json:"file"`
+	Type string `json:"type"`
+}
+
+func (b *PromoteMediaImageBody) Transform() {
+	b.File = anvil.String(b.File)
+	b.Type = anvil.String(b.Type)
+}
+
+func (b PromoteMediaImageBody) Validate() error {
+	return validate.ValidateStruct(&b,
+		validate.Field(&b.File, validate.Required),
+		validate.Field(&b.Type, validate.Required, validate.By(types.ValidateImageType)),
+	)
+}
+
+type MediaVideo struct {
+	Site     types.VideoSite `json:"site"`
+	Key      string          `json:"key"`
+	Type     types.VideoType `json:"type"`
+	Name     string          `json:"name"`
+	Language string          `json:"language"`
+	Official bool            `json:"official"`
+
+	Url      string `json:"url"`
+	EmbedUrl string `json:"embedUrl"`
+}
+
 type GetMediaById struct {
 	Media
+
+	ThemeSongs []MediaThemeSong `json:"themeSongs"`
+	Videos     []MediaVideo     `json:"videos"`
 }
 
 // TODO(patrik): Move
@@ -219,6 +386,7 @@ func ConvertDBMedia(c pyrin.Context, pm *provider.ProviderManager, hasUser bool,
 		LogoUrl:         logoUrl,
 		DefaultProvider: utils.SqlNullToStringPtr(media.DefaultProvider),
 		Providers:       createProviderValues(pm, media.Providers),
+		FieldSources:    createFieldSources(pm, media.FieldSources),
 		User:            user,
 		Release:         release,
 	}
@@ -480,6 +648,13 @@ func InstallMediaHandlers(app core.App, group pyrin.Group) {
 
 				var userId *string
 
+				// NOTE(patrik): The region for the availability filters
+				// always comes from the logged in user
+				user, err := User(app, c)
+				if err == nil && user.Region.Valid {
+					opts.Region = user.Region.String
+				}
+
 				if q.Has("userId") {
 					id := q.Get("userId")
 
@@ -493,10 +668,8 @@ func InstallMediaHandlers(app core.App, group pyrin.Group) {
 					}
 
 					userId = &user.Id
-				} else {
-					if user, err := User(app, c); err == nil {
-						userId = &user.Id
-					}
+				} else if user != nil {
+					userId = &user.Id
 				}
 
 				filterStr := q.Get("filter")
@@ -534,7 +707,9 @@ func InstallMediaHandlers(app core.App, group pyrin.Group) {
 					userId = &user.Id
 				}
 
-				media, err := app.DB().GetMediaById(c.Request().Context(), userId, id)
+				ctx := c.Request().Context()
+
+				media, err := app.DB().GetMediaById(ctx, userId, id)
 				if err != nil {
 					if errors.Is(err, database.ErrItemNotFound) {
 						return nil, MediaNotFound()
@@ -543,9 +718,467 @@ func InstallMediaHandlers(app core.App, group pyrin.Group) {
 					return nil, err
 				}
 
-				return GetMediaById{
-					Media: ConvertDBMedia(c, pm, userId != nil, media),
-				}, nil
+				themeSongs, err := app.DB().GetMediaThemeSongsByMediaId(ctx, media.Id)
+				if err != nil {
+					return nil, err
+				}
+
+				videos, err := app.DB().GetMediaVideosByMediaId(ctx, media.Id)
+				if err != nil {
+					return nil, err
+				}
+
+				res := GetMediaById{
+					Media:      ConvertDBMedia(c, pm, userId != nil, media),
+					ThemeSongs: make([]MediaThemeSong, len(themeSongs)),
+					Videos:     make([]MediaVideo, len(videos)),
+				}
+
+				for i, song := range themeSongs {
+					res.ThemeSongs[i] = MediaThemeSong{
+						Type:   song.Type,
+						Index:  song.Index,
+						Name:   song.Name,
+						Artist: song.Artist,
+					}
+				}
+
+				for i, video := range videos {
+					url, embedUrl := types.GetVideoUrls(video.Site, video.Key)
+
+					res.Videos[i] = MediaVideo{
+						Site:     video.Site,
+						Key:      video.Key,
+						Type:     video.Type,
+						Name:     video.Name,
+						Language: video.Language,
+						Official: video.Official,
+						Url:      url,
+						EmbedUrl: embedUrl,
+					}
+				}
+
+				return res, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "GetMediaImages",
+			Method:       http.MethodGet,
+			Path:         "/media/:id/images",
+			ResponseType: GetMediaImages{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				pm := app.ProviderManager()
+
+				id := c.Param("id")
+
+				ctx := c.Request().Context()
+
+				media, err := app.DB().GetMediaById(ctx, nil, id)
+				if err != nil {
+					if errors.Is(err, database.ErrItemNotFound) {
+						return nil, MediaNotFound()
+					}
+
+					return nil, err
+				}
+
+				images, err := app.DB().GetMediaImagesByMediaId(ctx, media.Id)
+				if err != nil {
+					return nil, err
+				}
+
+				res := GetMediaImages{
+					Images: make([]MediaImage, len(images)),
+				}
+
+				for i, image := range images {
+					res.Images[i] = MediaImage{
+						File:                image.File,
+						Type:                image.Type,
+						ProviderName:        image.ProviderName,
+						ProviderDisplayName: getProviderDisplayName(pm, image.ProviderName),
+						Url:                 ConvertURL(c, fmt.Sprintf("/files/media/%s/images/%s", media.Id, image.File)),
+						IsCover:             media.CoverFile.String == image.File,
+						IsBanner:            media.BannerFile.String == image.File,
+						IsLogo:              media.LogoFile.String == image.File,
+					}
+				}
+
+				return res, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "RefreshMediaImages",
+			Method:       http.MethodPost,
+			Path:         "/media/:id/images/refresh",
+			ResponseType: RefreshMediaImages{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				_, err := User(app, c, HasEditPrivilege)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := context.Background()
+
+				dbMedia, err := app.DB().GetMediaById(ctx, nil, id)
+				if err != nil {
+					if errors.Is(err, database.ErrItemNotFound) {
+						return nil, MediaNotFound()
+					}
+
+					return nil, err
+				}
+
+				added, errs, err := FetchMediaImages(ctx, app, dbMedia)
+				if err != nil {
+					return nil, err
+				}
+
+				res := RefreshMediaImages{
+					Added:  added,
+					Errors: []MediaImageProviderError{},
+				}
+
+				for name, err := range errs {
+					res.Errors = append(res.Errors, MediaImageProviderError{
+						ProviderName: name,
+						Message:      err.Error(),
+					})
+				}
+
+				return res, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "PromoteMediaImage",
+			Method:       http.MethodPost,
+			Path:         "/media/:id/images/promote",
+			ResponseType: nil,
+			BodyType:     PromoteMediaImageBody{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				_, err := User(app, c, HasEditPrivilege)
+				if err != nil {
+					return nil, err
+				}
+
+				body, err := pyrin.Body[PromoteMediaImageBody](c)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := context.Background()
+
+				dbMedia, err := app.DB().GetMediaById(ctx, nil, id)
+				if err != nil {
+					if errors.Is(err, database.ErrItemNotFound) {
+						return nil, MediaNotFound()
+					}
+
+					return nil, err
+				}
+
+				image, err := app.DB().GetMediaImage(ctx, dbMedia.Id, body.File)
+				if err != nil {
+					if errors.Is(err, database.ErrItemNotFound) {
+						return nil, ImageNotFound()
+					}
+
+					return nil, err
+				}
+
+				file := sql.NullString{
+					String: image.File,
+					Valid:  true,
+				}
+
+				changes := database.MediaChanges{}
+
+				fieldSources := ember.KVStore{}
+				maps.Copy(fieldSources, dbMedia.FieldSources)
+
+				switch types.ImageType(body.Type) {
+				case types.ImageTypeCover:
+					changes.CoverFile = database.Change[sql.NullString]{
+						Value:   file,
+						Changed: file != dbMedia.CoverFile,
+					}
+					fieldSources[string(merge.FieldCover)] = merge.SourceManual
+				case types.ImageTypeBanner:
+					changes.BannerFile = database.Change[sql.NullString]{
+						Value:   file,
+						Changed: file != dbMedia.BannerFile,
+					}
+					fieldSources[string(merge.FieldBanner)] = merge.SourceManual
+				case types.ImageTypeLogo:
+					changes.LogoFile = database.Change[sql.NullString]{
+						Value:   file,
+						Changed: file != dbMedia.LogoFile,
+					}
+					fieldSources[string(merge.FieldLogo)] = merge.SourceManual
+				}
+
+				changes.FieldSources = database.Change[ember.KVStore]{
+					Value:   fieldSources,
+					Changed: !maps.Equal(fieldSources, dbMedia.FieldSources),
+				}
+
+				err = app.DB().UpdateMedia(ctx, dbMedia.Id, changes)
+				if err != nil {
+					return nil, err
+				}
+
+				return nil, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "RemoveMediaImage",
+			Method:       http.MethodDelete,
+			Path:         "/media/:id/images/:file",
+			ResponseType: nil,
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+				file := c.Param("file")
+
+				_, err := User(app, c, HasEditPrivilege)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := context.Background()
+
+				dbMedia, err := app.DB().GetMediaById(ctx, nil, id)
+				if err != nil {
+					if errors.Is(err, database.ErrItemNotFound) {
+						return nil, MediaNotFound()
+					}
+
+					return nil, err
+				}
+
+				image, err := app.DB().GetMediaImage(ctx, dbMedia.Id, file)
+				if err != nil {
+					if errors.Is(err, database.ErrItemNotFound) {
+						return nil, ImageNotFound()
+					}
+
+					return nil, err
+				}
+
+				err = app.DB().RemoveMediaImage(ctx, dbMedia.Id, image.File)
+				if err != nil {
+					return nil, err
+				}
+
+				// NOTE(patrik): Keep the file if the media still uses it
+				inUse := image.File == dbMedia.CoverFile.String ||
+					image.File == dbMedia.BannerFile.String ||
+					image.File == dbMedia.LogoFile.String
+
+				if !inUse {
+					mediaDir := app.WorkDir().MediaDirById(dbMedia.Id)
+
+					err = os.Remove(path.Join(mediaDir.Images(), image.File))
+					if err != nil && !errors.Is(err, os.ErrNotExist) {
+						return nil, err
+					}
+				}
+
+				return nil, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "GetMediaRelations",
+			Method:       http.MethodGet,
+			Path:         "/media/:id/relations",
+			ResponseType: GetMediaRelations{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				pm := app.ProviderManager()
+
+				id := c.Param("id")
+
+				var userId *string
+				if user, err := User(app, c); err == nil {
+					userId = &user.Id
+				}
+
+				ctx := c.Request().Context()
+
+				dbMedia, err := app.DB().GetMediaById(ctx, nil, id)
+				if err != nil {
+					if errors.Is(err, database.ErrItemNotFound) {
+						return nil, MediaNotFound()
+					}
+
+					return nil, err
+				}
+
+				relations, err := app.DB().GetMediaRelationsByMediaId(ctx, dbMedia.Id)
+				if err != nil {
+					return nil, err
+				}
+
+				res := GetMediaRelations{
+					Relations: make([]MediaRelation, 0, len(relations)),
+				}
+
+				for _, relation := range relations {
+					var media *Media
+					if relation.RelatedMediaId.Valid {
+						related, err := app.DB().GetMediaById(ctx, userId, relation.RelatedMediaId.String)
+						if err != nil && !errors.Is(err, database.ErrItemNotFound) {
+							return nil, err
+						}
+
+						if err == nil {
+							m := ConvertDBMedia(c, pm, userId != nil, related)
+							media = &m
+						}
+					}
+
+					res.Relations = append(res.Relations, MediaRelation{
+						Type:                relation.Type,
+						ProviderName:        relation.ProviderName,
+						ProviderDisplayName: getProviderDisplayName(pm, relation.ProviderName),
+						ProviderId:          relation.ProviderId,
+						Title:               relation.Title,
+						Media:               media,
+					})
+				}
+
+				return res, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "GetMediaAvailability",
+			Method:       http.MethodGet,
+			Path:         "/media/:id/availability",
+			ResponseType: GetMediaAvailability{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				q := c.Request().URL.Query()
+
+				// NOTE(patrik): Defaults to the region of the user, use
+				// ?region=all to get every region
+				region := strings.ToUpper(q.Get("region"))
+				if region == "" {
+					if user, err := User(app, c); err == nil && user.Region.Valid {
+						region = user.Region.String
+					}
+				}
+
+				if region == "ALL" {
+					region = ""
+				}
+
+				if region != "" && !types.IsValidRegion(region) {
+					return nil, InvalidRegion(region)
+				}
+
+				ctx := c.Request().Context()
+
+				dbMedia, err := app.DB().GetMediaById(ctx, nil, id)
+				if err != nil {
+					if errors.Is(err, database.ErrItemNotFound) {
+						return nil, MediaNotFound()
+					}
+
+					return nil, err
+				}
+
+				availability, err := app.DB().GetMediaAvailabilityByMediaId(ctx, dbMedia.Id, region)
+				if err != nil {
+					return nil, err
+				}
+
+				res := GetMediaAvailability{
+					Region:       region,
+					Availability: make([]MediaAvailability, len(availability)),
+				}
+
+				for i, a := range availability {
+					res.Availability[i] = MediaAvailability{
+						Region:       a.Region,
+						Type:         a.Type,
+						Service:      a.Service,
+						ServiceName:  a.ServiceName,
+						ProviderName: a.ProviderName,
+					}
+				}
+
+				return res, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "GetMissingMediaRelations",
+			Method:       http.MethodGet,
+			Path:         "/media/relations/missing",
+			ResponseType: GetMissingMediaRelations{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				pm := app.ProviderManager()
+
+				// NOTE(patrik): Optional comma separated list of relation
+				// types (sequel,prequel...)
+				var relationTypes []types.MediaRelationType
+				if s := c.Request().URL.Query().Get("types"); s != "" {
+					for _, t := range fixArr(strings.Split(s, ",")) {
+						relationTypes = append(relationTypes, types.MediaRelationType(t))
+					}
+				}
+
+				relations, err := app.DB().GetMissingMediaRelations(c.Request().Context(), relationTypes)
+				if err != nil {
+					return nil, err
+				}
+
+				res := GetMissingMediaRelations{
+					Relations: []MissingMediaRelation{},
+				}
+
+				// NOTE(patrik): Multiple media can have a relation to the
+				// same entry (the prequel and the side story of a sequel)
+				index := map[string]int{}
+				for _, relation := range relations {
+					key := relation.ProviderName + ":" + relation.ProviderId
+
+					idx, ok := index[key]
+					if !ok {
+						canImport := false
+						if info, ok := pm.GetProviderInfo(relation.ProviderName); ok {
+							canImport = info.SupportGetMedia && !info.Disabled
+						}
+
+						idx = len(res.Relations)
+						index[key] = idx
+						res.Relations = append(res.Relations, MissingMediaRelation{
+							ProviderName:        relation.ProviderName,
+							ProviderDisplayName: getProviderDisplayName(pm, relation.ProviderName),
+							ProviderId:          relation.ProviderId,
+							Title:               relation.Title,
+							CanImport:           canImport,
+							Sources:             []MissingMediaRelationSource{},
+						})
+					}
+
+					res.Relations[idx].Sources = append(res.Relations[idx].Sources, MissingMediaRelationSource{
+						Type:       relation.Type,
+						MediaId:    relation.MediaId,
+						MediaTitle: relation.MediaTitle,
+					})
+				}
+
+				return res, nil
 			},
 		},
 
@@ -939,6 +1572,34 @@ func InstallMediaHandlers(app core.App, group pyrin.Group) {
 					}
 				}
 
+				fieldSources := ember.KVStore{}
+				maps.Copy(fieldSources, dbMedia.FieldSources)
+
+				setManual := func(field merge.Field, changed bool) {
+					if changed {
+						fieldSources[string(field)] = merge.SourceManual
+					}
+				}
+
+				setManual(merge.FieldTitle, changes.Title.Changed)
+				setManual(merge.FieldDescription, changes.Description.Changed)
+				setManual(merge.FieldScore, changes.Score.Changed)
+				setManual(merge.FieldStatus, changes.Status.Changed)
+				setManual(merge.FieldRating, changes.Rating.Changed)
+				setManual(merge.FieldAiringSeason, changes.AiringSeason.Changed)
+				setManual(merge.FieldStartDate, changes.StartDate.Changed)
+				setManual(merge.FieldEndDate, changes.EndDate.Changed)
+				setManual(merge.FieldCover, changes.CoverFile.Changed)
+				setManual(merge.FieldBanner, changes.BannerFile.Changed)
+				setManual(merge.FieldLogo, changes.LogoFile.Changed)
+				setManual(merge.FieldTags, body.Tags != nil)
+				setManual(merge.FieldCreators, body.Creators != nil)
+
+				changes.FieldSources = database.Change[ember.KVStore]{
+					Value:   fieldSources,
+					Changed: !maps.Equal(fieldSources, dbMedia.FieldSources),
+				}
+
 				err = app.DB().UpdateMedia(ctx, dbMedia.Id, changes)
 				if err != nil {
 					return nil, err
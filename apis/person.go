@@ -0,0 +1,171 @@
+package apis
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/nanoteck137/pyrin"
+	"github.com/nanoteck137/watchbook/core"
+	"github.com/nanoteck137/watchbook/database"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+type Person struct {
+	Id   string           `json:"id"`
+	Type types.PersonType `json:"type"`
+	Name string           `json:"name"`
+
+	Providers []ProviderValue `json:"providers"`
+}
+
+type PersonCredit struct {
+	Role      types.PersonRole `json:"role"`
+	Character string           `json:"character"`
+}
+
+type PersonMedia struct {
+	Media   Media          `json:"media"`
+	Credits []PersonCredit `json:"credits"`
+}
+
+type GetPersonById struct {
+	Person
+
+	Media []PersonMedia `json:"media"`
+}
+
+type MediaPerson struct {
+	PersonId   string           `json:"personId"`
+	PersonType types.PersonType `json:"personType"`
+	Name       string           `json:"name"`
+
+	Role      types.PersonRole `json:"role"`
+	Character string           `json:"character"`
+}
+
+type GetMediaPeople struct {
+	People []MediaPerson `json:"people"`
+}
+
+func InstallPersonHandlers(app core.App, group pyrin.Group) {
+	group.Register(
+		pyrin.ApiHandler{
+			Name:         "GetPersonById",
+			Method:       http.MethodGet,
+			Path:         "/people/:id",
+			ResponseType: GetPersonById{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				pm := app.ProviderManager()
+
+				id := c.Param("id")
+
+				var userId *string
+				if user, err := User(app, c); err == nil {
+					userId = &user.Id
+				}
+
+				ctx := c.Request().Context()
+
+				person, err := app.DB().GetPersonById(ctx, id)
+				if err != nil {
+					if errors.Is(err, database.ErrItemNotFound) {
+						return nil, PersonNotFound()
+					}
+
+					return nil, err
+				}
+
+				credits, err := app.DB().GetMediaPeopleByPersonId(ctx, person.Id)
+				if err != nil {
+					return nil, err
+				}
+
+				res := GetPersonById{
+					Person: Person{
+						Id:        person.Id,
+						Type:      person.Type,
+						Name:      person.Name,
+						Providers: createProviderValues(pm, person.Providers),
+					},
+					Media: []PersonMedia{},
+				}
+
+				// NOTE(patrik): The same person can have multiple roles on
+				// the same media (director and writer), group the credits
+				// by the media
+				index := map[string]int{}
+				for _, credit := range credits {
+					i, ok := index[credit.MediaId]
+					if !ok {
+						media, err := app.DB().GetMediaById(ctx, userId, credit.MediaId)
+						if err != nil {
+							return nil, err
+						}
+
+						i = len(res.Media)
+						index[credit.MediaId] = i
+
+						res.Media = append(res.Media, PersonMedia{
+							Media:   ConvertDBMedia(c, pm, userId != nil, media),
+							Credits: []PersonCredit{},
+						})
+					}
+
+					res.Media[i].Credits = append(res.Media[i].Credits, PersonCredit{
+						Role:      credit.Role,
+						Character: credit.Character,
+					})
+				}
+
+				sort.SliceStable(res.Media, func(i, j int) bool {
+					return res.Media[i].Media.Title < res.Media[j].Media.Title
+				})
+
+				return res, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "GetMediaPeople",
+			Method:       http.MethodGet,
+			Path:         "/media/:id/people",
+			ResponseType: GetMediaPeople{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				ctx := c.Request().Context()
+
+				dbMedia, err := app.DB().GetMediaById(ctx, nil, id)
+				if err != nil {
+					if errors.Is(err, database.ErrItemNotFound) {
+						return nil, MediaNotFound()
+					}
+
+					return nil, err
+				}
+
+				people, err := app.DB().GetMediaPeopleByMediaId(ctx, dbMedia.Id)
+				if err != nil {
+					return nil, err
+				}
+
+				res := GetMediaPeople{
+					People: make([]MediaPerson, len(people)),
+				}
+
+				for i, person := range people {
+					res.People[i] = MediaPerson{
+						PersonId:   person.PersonId,
+						PersonType: person.PersonType,
+						Name:       person.PersonName,
+						Role:       person.Role,
+						Character:  person.Character,
+					}
+				}
+
+				return res, nil
+			},
+		},
+	)
+}
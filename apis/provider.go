@@ -6,21 +6,28 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"maps"
+	"slices"
 
 	"github.com/kr/pretty"
 	"github.com/maruel/natural"
 	"github.com/nanoteck137/pyrin"
 	"github.com/nanoteck137/pyrin/anvil"
 	"github.com/nanoteck137/pyrin/ember"
+	"github.com/nanoteck137/validate"
 	"github.com/nanoteck137/watchbook/core"
 	"github.com/nanoteck137/watchbook/database"
 	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/provider/mapping"
+	"github.com/nanoteck137/watchbook/provider/merge"
 	"github.com/nanoteck137/watchbook/types"
 	"github.com/nanoteck137/watchbook/utils"
 )
@@ -36,7 +43,15 @@ func createProviderValues(pm *provider.ProviderManager, providerStore ember.KVSt
 	for name, value := range providerStore {
 		info, ok := pm.GetProviderInfo(name)
 		if !ok {
-			continue
+			displayName, ok := provider.ExternalIds[name]
+			if !ok {
+				continue
+			}
+
+			info = provider.Info{
+				Name:        name,
+				DisplayName: displayName,
+			}
 		}
 
 		providers = append(providers, ProviderValue{
@@ -65,6 +80,9 @@ type Provider struct {
 	Name        string           `json:"name"`
 	DisplayName string           `json:"displayName"`
 	Supports    ProviderSupports `json:"supports"`
+
+	Disabled       bool    `json:"disabled"`
+	DisabledReason *string `json:"disabledReason"`
 }
 
 type GetProviders struct {
@@ -76,12 +94,176 @@ type ProviderSearchResult struct {
 	ProviderId   string `json:"providerId"`
 	Title        string `json:"title"`
 	ImageUrl     string `json:"imageUrl"`
+
+	MediaType types.MediaType `json:"mediaType"`
+	Year      int             `json:"year"`
 }
 
 type GetProviderSearch struct {
 	SearchResults []ProviderSearchResult `json:"searchResults"`
 }
 
+type ProviderSearchPage struct {
+	Page int `json:"page"`
+	// NOTE(patrik): 0 when the provider doesn't know the number of pages
+	TotalPages  int  `json:"totalPages"`
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+type GetProviderSearchMedia struct {
+	Page          ProviderSearchPage     `json:"page"`
+	SearchResults []ProviderSearchResult `json:"searchResults"`
+}
+
+type ProviderMultiSearchItem struct {
+	ProviderName        string          `json:"providerName"`
+	ProviderDisplayName string          `json:"providerDisplayName"`
+	ProviderId          string          `json:"providerId"`
+	Title               string          `json:"title"`
+	ImageUrl            string          `json:"imageUrl"`
+	MediaType           types.MediaType `json:"mediaType"`
+	Year                *int            `json:"year"`
+	// NOTE(patrik): Set when the item is already inside the library
+	MediaId *string `json:"mediaId"`
+}
+
+type ProviderMultiSearchResult struct {
+	Title     string          `json:"title"`
+	ImageUrl  string          `json:"imageUrl"`
+	MediaType types.MediaType `json:"mediaType"`
+	Year      *int            `json:"year"`
+	// NOTE(patrik): Set when one of the items is already inside the
+	// library
+	MediaId *string `json:"mediaId"`
+
+	Items []ProviderMultiSearchItem `json:"items"`
+}
+
+type ProviderSearchError struct {
+	ProviderName string `json:"providerName"`
+	Message      string `json:"message"`
+}
+
+type GetProviderMultiSearch struct {
+	Results []ProviderMultiSearchResult `json:"results"`
+	Errors  []ProviderSearchError       `json:"errors"`
+}
+
+type ProviderSeasonalEntry struct {
+	ProviderId string          `json:"providerId"`
+	Title      string          `json:"title"`
+	ImageUrl   string          `json:"imageUrl"`
+	MediaType  types.MediaType `json:"mediaType"`
+	Year       *int            `json:"year"`
+	// NOTE(patrik): Set when the entry is already inside the library
+	MediaId *string `json:"mediaId"`
+}
+
+type GetProviderSeasonal struct {
+	Season  string                  `json:"season"`
+	Year    int                     `json:"year"`
+	Entries []ProviderSeasonalEntry `json:"entries"`
+}
+
+type PostProviderImportSeasonalBody struct {
+	Ids []string `json:"ids"`
+}
+
+func (b *PostProviderImportSeasonalBody) Transform() {
+	b.Ids = fixArr(b.Ids)
+}
+
+type PostProviderImportSeasonal struct {
+	JobId string `json:"jobId"`
+}
+
+type PostProviderResolveLinksBody struct {
+	Links []string `json:"links"`
+	// NOTE(patrik): Import the entries that are not inside the library
+	Import bool `json:"import"`
+}
+
+func (b *PostProviderResolveLinksBody) Transform() {
+	b.Links = fixArr(b.Links)
+}
+
+func (b PostProviderResolveLinksBody) Validate() error {
+	return validate.ValidateStruct(&b,
+		validate.Field(&b.Links, validate.Required),
+	)
+}
+
+type ProviderResolvedLink struct {
+	Link                string `json:"link"`
+	ProviderName        string `json:"providerName"`
+	ProviderDisplayName string `json:"providerDisplayName"`
+	ProviderId          string `json:"providerId"`
+	// NOTE(patrik): media or collection
+	Type string `json:"type"`
+
+	// NOTE(patrik): Set when the entry is inside the library (or was
+	// imported by the request)
+	MediaId      *string `json:"mediaId"`
+	CollectionId *string `json:"collectionId"`
+}
+
+type ProviderLinkError struct {
+	Link    string `json:"link"`
+	Message string `json:"message"`
+}
+
+type PostProviderResolveLinks struct {
+	Results []ProviderResolvedLink `json:"results"`
+	Errors  []ProviderLinkError    `json:"errors"`
+}
+
+// NOTE(patrik): Parses the search query params (query, page, year, type
+// and adult)
+func getSearchParams(q url.Values) (provider.SearchParams, error) {
+	params := provider.SearchParams{
+		Query: q.Get("query"),
+		Page:  1,
+	}
+
+	if s := q.Get("page"); s != "" {
+		page, err := strconv.Atoi(s)
+		if err != nil || page < 1 {
+			return provider.SearchParams{}, InvalidSearchParams("page needs to be a number larger then 0")
+		}
+
+		params.Page = page
+	}
+
+	if s := q.Get("year"); s != "" {
+		year, err := strconv.Atoi(s)
+		if err != nil || year < 0 {
+			return provider.SearchParams{}, InvalidSearchParams("year needs to be a valid year")
+		}
+
+		params.Year = year
+	}
+
+	if s := q.Get("type"); s != "" {
+		t := types.MediaType(s)
+		if !types.IsValidMediaType(t) {
+			return provider.SearchParams{}, InvalidSearchParams("invalid media type: " + s)
+		}
+
+		params.MediaType = t
+	}
+
+	if s := q.Get("adult"); s != "" {
+		adult, err := strconv.ParseBool(s)
+		if err != nil {
+			return provider.SearchParams{}, InvalidSearchParams("adult needs to be true or false")
+		}
+
+		params.IncludeAdult = adult
+	}
+
+	return params, nil
+}
+
 func fixArr(arr []string) []string {
 	if arr == nil {
 		return nil
@@ -168,6 +350,13 @@ func ImportMedia(ctx context.Context, app core.App, providerName, providerId str
 	maps.Copy(providerIds, media.ExtraProviderIds)
 	providerIds[providerName] = media.ProviderId
 
+	app.IdMappings().Fill(mapping.Ids(providerIds))
+
+	err = removeUsedProviderIds(ctx, app, id, providerIds, nil)
+	if err != nil {
+		return "", err
+	}
+
 	startDate := ""
 	if media.StartDate != nil {
 		startDate = media.StartDate.Format(types.MediaDateLayout)
@@ -183,7 +372,7 @@ func ImportMedia(ctx context.Context, app core.App, providerName, providerId str
 	logoFilename := ""
 
 	if media.CoverUrl != nil {
-		p, err := utils.DownloadImageHashed(*media.CoverUrl, mediaDir.Images())
+		p, err := downloadProviderImage(*media.CoverUrl, mediaDir.Images())
 		if err == nil {
 			coverFilename = path.Base(p)
 		} else {
@@ -192,7 +381,7 @@ func ImportMedia(ctx context.Context, app core.App, providerName, providerId str
 	}
 
 	if media.BannerUrl != nil {
-		p, err := utils.DownloadImageHashed(*media.BannerUrl, mediaDir.Images())
+		p, err := downloadProviderImage(*media.BannerUrl, mediaDir.Images())
 		if err == nil {
 			bannerFilename = path.Base(p)
 		} else {
@@ -201,7 +390,7 @@ func ImportMedia(ctx context.Context, app core.App, providerName, providerId str
 	}
 
 	if media.LogoUrl != nil {
-		p, err := utils.DownloadImageHashed(*media.LogoUrl, mediaDir.Images())
+		p, err := downloadProviderImage(*media.LogoUrl, mediaDir.Images())
 		if err == nil {
 			logoFilename = path.Base(p)
 		} else {
@@ -242,7 +431,8 @@ func ImportMedia(ctx context.Context, app core.App, providerName, providerId str
 			String: providerName,
 			Valid:  providerName != "",
 		},
-		Providers: providerIds,
+		Providers:    providerIds,
+		FieldSources: convertFieldSources(merge.Single(providerName, media)),
 	})
 	if err != nil {
 		return "", err
@@ -288,6 +478,36 @@ func ImportMedia(ctx context.Context, app core.App, providerName, providerId str
 		}
 	}
 
+	err = createMediaThemeSongs(ctx, app, id, media.ThemeSongs)
+	if err != nil {
+		return "", err
+	}
+
+	err = createMediaRelations(ctx, app, id, media.Relations)
+	if err != nil {
+		return "", err
+	}
+
+	err = createMediaPeople(ctx, app, id, media.Credits)
+	if err != nil {
+		return "", err
+	}
+
+	err = createMediaVideos(ctx, app, id, media.Videos)
+	if err != nil {
+		return "", err
+	}
+
+	err = updateMediaAvailability(ctx, app, id, providerIds)
+	if err != nil {
+		return "", err
+	}
+
+	err = linkMediaRelations(ctx, app, id, providerIds)
+	if err != nil {
+		return "", err
+	}
+
 	for _, tag := range media.Tags {
 		tag = utils.Slug(tag)
 
@@ -319,140 +539,706 @@ func ImportMedia(ctx context.Context, app core.App, providerName, providerId str
 	return id, nil
 }
 
-type ProviderMediaUpdateBody struct {
-	ReplaceImages bool `json:"replaceImages,omitempty"`
-	OverrideParts bool `json:"overrideParts,omitempty"`
-	SetRelease    bool `json:"setRelease,omitempty"`
-}
-
-type ProviderCollectionUpdateBody struct {
-	ReplaceImages bool `json:"replaceImages,omitempty"`
-}
-
-func UpdateMedia(ctx context.Context, app core.App, settings ProviderMediaUpdateBody, dbMedia database.Media, provider, providerId string) error {
+// NOTE(patrik): Imports the collection and all the items of the collection
+// as media, returns the id of the existing collection if it's already
+// imported
+func ImportCollection(ctx context.Context, app core.App, providerName, providerId string) (string, error) {
 	pm := app.ProviderManager()
 
-	data, err := pm.GetMedia(ctx, provider, providerId)
-	if err != nil {
-		return err
+	dbCollection, err := app.DB().GetCollectionByProviderId(ctx, providerName, providerId)
+	if err == nil {
+		fmt.Printf("id already exists: %v\n", providerId)
+		return dbCollection.Id, nil
 	}
 
-	if data.AiringSeason != nil {
-		err := app.DB().CreateTag(ctx, *data.AiringSeason, *data.AiringSeason)
-		if err != nil && !errors.Is(err, database.ErrItemAlreadyExists) {
-			return err
-		}
+	if !errors.Is(err, database.ErrItemNotFound) {
+		return "", err
 	}
 
-	changes := database.MediaChanges{}
-
-	changes.Type = database.Change[types.MediaType]{
-		Value:   data.Type,
-		Changed: data.Type != dbMedia.Type,
+	data, err := pm.GetCollection(ctx, providerName, providerId)
+	if err != nil {
+		// TODO(patrik): Handle err
+		return "", err
 	}
 
-	changes.Title = database.Change[string]{
-		Value:   data.Title,
-		Changed: data.Title != dbMedia.Title,
-	}
+	id := utils.CreateCollectionId()
 
-	desc := utils.NullToDefault(data.Description)
-	changes.Description = database.Change[sql.NullString]{
-		Value: sql.NullString{
-			String: desc,
-			Valid:  desc != "",
-		},
-		Changed: desc != dbMedia.Description.String,
+	collectionDir := app.WorkDir().CollectionDirById(id)
+	dirs := []string{
+		collectionDir.String(),
+		collectionDir.Images(),
 	}
 
-	score := utils.NullToDefault(data.Score)
-	changes.Score = database.Change[sql.NullFloat64]{
-		Value: sql.NullFloat64{
-			Float64: score,
-			Valid:   score != 0.0,
-		},
-		Changed: score != dbMedia.Score.Float64,
+	for _, dir := range dirs {
+		err = os.Mkdir(dir, 0755)
+		if err != nil && !os.IsExist(err) {
+			return "", err
+		}
 	}
 
-	changes.Status = database.Change[types.MediaStatus]{
-		Value:   data.Status,
-		Changed: data.Status != dbMedia.Status,
+	providerIds := ember.KVStore{}
+	maps.Copy(providerIds, data.ExtraProviderIds)
+	providerIds[providerName] = data.ProviderId
+
+	coverFilename := ""
+	bannerFilename := ""
+	logoFilename := ""
+
+	if data.CoverUrl != nil {
+		p, err := downloadProviderImage(*data.CoverUrl, collectionDir.Images())
+		if err == nil {
+			coverFilename = path.Base(p)
+		} else {
+			app.Logger().Error("failed to download cover image for collection", "err", err)
+		}
 	}
 
-	changes.Rating = database.Change[types.MediaRating]{
-		Value:   data.Rating,
-		Changed: data.Rating != dbMedia.Rating,
+	if data.BannerUrl != nil {
+		p, err := downloadProviderImage(*data.BannerUrl, collectionDir.Images())
+		if err == nil {
+			bannerFilename = path.Base(p)
+		} else {
+			app.Logger().Error("failed to download banner image for collection", "err", err)
+		}
 	}
 
-	changes.Rating = database.Change[types.MediaRating]{
-		Value:   data.Rating,
-		Changed: data.Rating != dbMedia.Rating,
+	if data.LogoUrl != nil {
+		p, err := downloadProviderImage(*data.LogoUrl, collectionDir.Images())
+		if err == nil {
+			logoFilename = path.Base(p)
+		} else {
+			app.Logger().Error("failed to download logo image for collection", "err", err)
+		}
 	}
 
-	airingSeason := utils.NullToDefault(data.AiringSeason)
-	changes.AiringSeason = database.Change[sql.NullString]{
-		Value: sql.NullString{
-			String: airingSeason,
-			Valid:  airingSeason != "",
+	_, err = app.DB().CreateCollection(ctx, database.CreateCollectionParams{
+		Id:   id,
+		Type: data.Type,
+		Name: data.Name,
+		CoverFile: sql.NullString{
+			String: coverFilename,
+			Valid:  coverFilename != "",
 		},
-		Changed: airingSeason != dbMedia.AiringSeason.String,
+		BannerFile: sql.NullString{
+			String: bannerFilename,
+			Valid:  bannerFilename != "",
+		},
+		LogoFile: sql.NullString{
+			String: logoFilename,
+			Valid:  logoFilename != "",
+		},
+		DefaultProvider: sql.NullString{
+			String: providerName,
+			Valid:  providerName != "",
+		},
+		Providers: providerIds,
+	})
+	if err != nil {
+		return "", err
 	}
 
-	formatNullTime := func(t *time.Time) string {
-		if t == nil {
-			return ""
+	for i, item := range data.Items {
+		mediaId, err := ImportMedia(ctx, app, providerName, item.Id)
+		if err != nil {
+			return "", err
 		}
 
-		return t.Format(types.MediaDateLayout)
+		err = app.DB().CreateCollectionMediaItem(ctx, database.CreateCollectionMediaItemParams{
+			CollectionId: id,
+			MediaId:      mediaId,
+			Name:         item.Name,
+			SearchSlug:   utils.Slug(item.Name),
+			Position:     i,
+		})
+		if err != nil {
+			return "", err
+		}
 	}
 
-	startDate := formatNullTime(data.StartDate)
-	changes.StartDate = database.Change[sql.NullString]{
-		Value: sql.NullString{
-			String: startDate,
-			Valid:  startDate != "",
-		},
-		Changed: startDate != dbMedia.StartDate.String,
+	return id, nil
+}
+
+// NOTE(patrik): Finds the entry inside the library, if the entry doesn't
+// exist it's imported when doImport is set
+func resolveProviderLink(ctx context.Context, app core.App, link provider.ResolvedLink, doImport bool) (ProviderResolvedLink, error) {
+	displayName := link.ProviderName
+	if info, ok := app.ProviderManager().GetProviderInfo(link.ProviderName); ok {
+		displayName = info.GetDisplayName()
 	}
 
-	endDate := formatNullTime(data.EndDate)
-	changes.EndDate = database.Change[sql.NullString]{
-		Value: sql.NullString{
-			String: endDate,
-			Valid:  endDate != "",
-		},
-		Changed: endDate != dbMedia.EndDate.String,
+	res := ProviderResolvedLink{
+		ProviderName:        link.ProviderName,
+		ProviderDisplayName: displayName,
+		ProviderId:          link.ProviderId,
+		Type:                string(link.Type),
 	}
 
-	if settings.ReplaceImages {
-		mediaDir := app.WorkDir().MediaDirById(dbMedia.Id)
+	switch link.Type {
+	case provider.SearchResultTypeMedia:
+		dbMedia, err := app.DB().GetMediaByProviderId(ctx, nil, link.ProviderName, link.ProviderId)
+		if err == nil {
+			res.MediaId = &dbMedia.Id
+			return res, nil
+		}
 
-		if data.CoverUrl != nil {
-			p, err := utils.DownloadImageHashed(*data.CoverUrl, mediaDir.Images())
+		if !errors.Is(err, database.ErrItemNotFound) {
+			return ProviderResolvedLink{}, err
+		}
+
+		if doImport {
+			mediaId, err := ImportMedia(ctx, app, link.ProviderName, link.ProviderId)
 			if err != nil {
-				return fmt.Errorf("failed to download cover image for media: %w", err)
+				return ProviderResolvedLink{}, err
 			}
 
-			n := path.Base(p)
-			changes.CoverFile = database.Change[sql.NullString]{
-				Value: sql.NullString{
-					String: n,
-					Valid:  n != "",
-				},
-				Changed: true,
-			}
+			res.MediaId = &mediaId
+		}
+	case provider.SearchResultTypeCollection:
+		dbCollection, err := app.DB().GetCollectionByProviderId(ctx, link.ProviderName, link.ProviderId)
+		if err == nil {
+			res.CollectionId = &dbCollection.Id
+			return res, nil
 		}
 
-		if data.BannerUrl != nil {
-			p, err := utils.DownloadImageHashed(*data.BannerUrl, mediaDir.Images())
+		if !errors.Is(err, database.ErrItemNotFound) {
+			return ProviderResolvedLink{}, err
+		}
+
+		if doImport {
+			collectionId, err := ImportCollection(ctx, app, link.ProviderName, link.ProviderId)
 			if err != nil {
-				return fmt.Errorf("failed to download banner image for media: %w", err)
+				return ProviderResolvedLink{}, err
 			}
 
-			n := path.Base(p)
-			changes.BannerFile = database.Change[sql.NullString]{
-				Value: sql.NullString{
-					String: n,
+			res.CollectionId = &collectionId
+		}
+	}
+
+	return res, nil
+}
+
+func createMediaThemeSongs(ctx context.Context, app core.App, mediaId string, themeSongs []provider.ThemeSong) error {
+	for _, song := range themeSongs {
+		err := app.DB().CreateMediaThemeSong(ctx, database.CreateMediaThemeSongParams{
+			MediaId: mediaId,
+			Type:    song.Type,
+			Index:   int64(song.Index),
+			Name:    song.Name,
+			Artist:  song.Artist,
+		})
+		if err != nil {
+			// NOTE(patrik): Skip the duplicated indices
+			if errors.Is(err, database.ErrItemAlreadyExists) {
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NOTE(patrik): Stores the relations of the media, relations to entries
+// already inside the library is linked to that media. Relations that points
+// to the same entry on different providers (using the id mappings) is only
+// stored once.
+func createMediaRelations(ctx context.Context, app core.App, mediaId string, relations []provider.MediaRelation) error {
+	seen := map[string]bool{}
+
+	for _, relation := range relations {
+		if relation.ProviderName == "" || relation.ProviderId == "" {
+			continue
+		}
+
+		ids := app.IdMappings().Lookup(relation.ProviderName, relation.ProviderId)
+		if ids == nil {
+			ids = mapping.Ids{}
+		}
+		ids[relation.ProviderName] = relation.ProviderId
+
+		duplicate := false
+		for name, id := range ids {
+			if seen[name+":"+id] {
+				duplicate = true
+			}
+
+			seen[name+":"+id] = true
+		}
+
+		if duplicate {
+			continue
+		}
+
+		// NOTE(patrik): Try the provider of the relation first and then the
+		// ids from the id mappings
+		names := []string{relation.ProviderName}
+		for _, name := range slices.Sorted(maps.Keys(ids)) {
+			if name != relation.ProviderName {
+				names = append(names, name)
+			}
+		}
+
+		var relatedMediaId sql.NullString
+		for _, name := range names {
+			related, err := app.DB().GetMediaByProviderId(ctx, nil, name, ids[name])
+			if err != nil {
+				if errors.Is(err, database.ErrItemNotFound) {
+					continue
+				}
+
+				return err
+			}
+
+			relatedMediaId = sql.NullString{
+				String: related.Id,
+				Valid:  true,
+			}
+			break
+		}
+
+		if relatedMediaId.String == mediaId {
+			continue
+		}
+
+		err := app.DB().CreateMediaRelation(ctx, database.CreateMediaRelationParams{
+			MediaId:        mediaId,
+			ProviderName:   relation.ProviderName,
+			ProviderId:     relation.ProviderId,
+			Type:           relation.Type,
+			Title:          relation.Title,
+			RelatedMediaId: relatedMediaId,
+		})
+		if err != nil {
+			if errors.Is(err, database.ErrItemAlreadyExists) {
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func createMediaVideos(ctx context.Context, app core.App, mediaId string, videos []provider.Video) error {
+	for i, video := range videos {
+		if video.Key == "" {
+			continue
+		}
+
+		err := app.DB().CreateMediaVideo(ctx, database.CreateMediaVideoParams{
+			MediaId:  mediaId,
+			Site:     video.Site,
+			Key:      video.Key,
+			Type:     video.Type,
+			Name:     video.Name,
+			Language: video.Language,
+			Official: video.Official,
+			Position: i,
+		})
+		if err != nil {
+			// NOTE(patrik): Skip the duplicated videos
+			if errors.Is(err, database.ErrItemAlreadyExists) {
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NOTE(patrik): Finds the person for the credit, people is matched on the
+// provider id first and then on the name so the same person from different
+// providers (and the studios without ids) only gets created once
+func getOrCreatePerson(ctx context.Context, app core.App, credit provider.Credit) (database.Person, error) {
+	if credit.ProviderName != "" && credit.ProviderId != "" {
+		person, err := app.DB().GetPersonByProviderId(ctx, credit.ProviderName, credit.ProviderId)
+		if err == nil {
+			return person, nil
+		}
+
+		if !errors.Is(err, database.ErrItemNotFound) {
+			return database.Person{}, err
+		}
+	}
+
+	slug := utils.Slug(credit.Name)
+
+	person, err := app.DB().GetPersonBySlug(ctx, credit.PersonType, slug)
+	if err != nil && !errors.Is(err, database.ErrItemNotFound) {
+		return database.Person{}, err
+	}
+
+	if err == nil {
+		if credit.ProviderName == "" || credit.ProviderId == "" {
+			return person, nil
+		}
+
+		// NOTE(patrik): Only link the provider id if the person doesn't
+		// already have an id from the same provider, then it's another
+		// person with the same name
+		if _, exists := person.Providers[credit.ProviderName]; !exists {
+			providers := maps.Clone(person.Providers)
+			if providers == nil {
+				providers = ember.KVStore{}
+			}
+			providers[credit.ProviderName] = credit.ProviderId
+
+			err := app.DB().UpdatePerson(ctx, person.Id, database.PersonChanges{
+				Providers: database.Change[ember.KVStore]{
+					Value:   providers,
+					Changed: true,
+				},
+			})
+			if err != nil {
+				return database.Person{}, err
+			}
+
+			person.Providers = providers
+			return person, nil
+		}
+	}
+
+	providers := ember.KVStore{}
+	if credit.ProviderName != "" && credit.ProviderId != "" {
+		providers[credit.ProviderName] = credit.ProviderId
+	}
+
+	id, err := app.DB().CreatePerson(ctx, database.CreatePersonParams{
+		Type:      credit.PersonType,
+		Name:      credit.Name,
+		Slug:      slug,
+		Providers: providers,
+	})
+	if err != nil {
+		return database.Person{}, err
+	}
+
+	return app.DB().GetPersonById(ctx, id)
+}
+
+func createMediaPeople(ctx context.Context, app core.App, mediaId string, credits []provider.Credit) error {
+	for i, credit := range credits {
+		if credit.Name == "" || !types.IsValidPersonRole(credit.Role) {
+			continue
+		}
+
+		person, err := getOrCreatePerson(ctx, app, credit)
+		if err != nil {
+			return err
+		}
+
+		err = app.DB().CreateMediaPerson(ctx, database.CreateMediaPersonParams{
+			MediaId:    mediaId,
+			PersonId:   person.Id,
+			Role:       credit.Role,
+			Character:  credit.Character,
+			PersonSlug: person.Slug,
+			Position:   i,
+		})
+		if err != nil {
+			// NOTE(patrik): Skip the duplicated credits
+			if errors.Is(err, database.ErrItemAlreadyExists) {
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NOTE(patrik): Links the relations from other media that points to one of
+// the provider ids of the media
+func linkMediaRelations(ctx context.Context, app core.App, mediaId string, providerIds ember.KVStore) error {
+	for name, id := range providerIds {
+		err := app.DB().LinkMediaRelations(ctx, name, id, mediaId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func convertFieldSources(sources merge.Sources) ember.KVStore {
+	res := make(ember.KVStore, len(sources))
+	for field, name := range sources {
+		res[string(field)] = name
+	}
+
+	return res
+}
+
+// NOTE(patrik): Removes the new ids that already belongs to another media,
+// two media entries pointing to the same provider id breaks the imports
+func removeUsedProviderIds(ctx context.Context, app core.App, mediaId string, providerIds, existing ember.KVStore) error {
+	for name, id := range providerIds {
+		if v, ok := existing[name]; ok && v == id {
+			continue
+		}
+
+		other, err := app.DB().GetMediaByProviderId(ctx, nil, name, id)
+		if err != nil {
+			if errors.Is(err, database.ErrItemNotFound) {
+				continue
+			}
+
+			return err
+		}
+
+		if other.Id != mediaId {
+			delete(providerIds, name)
+		}
+	}
+
+	return nil
+}
+
+// NOTE(patrik): Links the media to the other providers by using the id
+// mappings and the ids reported by the providers, returns the names of the
+// providers that was added
+func ResolveMediaProviderIds(ctx context.Context, app core.App, dbMedia database.Media, fetchProviders bool) ([]string, error) {
+	resolved, err := mapping.Resolve(ctx, app.ProviderManager(), app.IdMappings(), mapping.Ids(dbMedia.Providers), mapping.ResolveOptions{
+		FetchProviders: fetchProviders,
+	})
+	if err != nil {
+		// NOTE(patrik): The result is still valid, only some of the
+		// providers failed
+		app.Logger().Warn("failed to fetch some provider ids", "mediaId", dbMedia.Id, "err", err)
+	}
+
+	providerIds := ember.KVStore(resolved)
+
+	err = removeUsedProviderIds(ctx, app, dbMedia.Id, providerIds, dbMedia.Providers)
+	if err != nil {
+		return nil, err
+	}
+
+	added := mapping.Added(mapping.Ids(dbMedia.Providers), resolved)
+	if len(added) == 0 {
+		return []string{}, nil
+	}
+
+	sort.Strings(added)
+
+	err = app.DB().UpdateMedia(ctx, dbMedia.Id, database.MediaChanges{
+		Providers: database.Change[ember.KVStore]{
+			Value:   providerIds,
+			Changed: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = linkMediaRelations(ctx, app, dbMedia.Id, providerIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+type ResolveMediaProvidersBody struct {
+	// NOTE(patrik): Also ask the providers for the ids, slower than only
+	// using the offline id mappings
+	FetchProviders bool `json:"fetchProviders,omitempty"`
+}
+
+type ResolveMediaProviders struct {
+	Added     []string        `json:"added"`
+	Providers []ProviderValue `json:"providers"`
+}
+
+type BackfillMediaProviders struct {
+	JobId string `json:"jobId"`
+}
+
+type ProviderMediaUpdateBody struct {
+	ReplaceImages bool `json:"replaceImages,omitempty"`
+	OverrideParts bool `json:"overrideParts,omitempty"`
+	SetRelease    bool `json:"setRelease,omitempty"`
+	// NOTE(patrik): Fetch from all the linked providers and merge the
+	// fields using the merge policy of the media type
+	MergeProviders bool `json:"mergeProviders,omitempty"`
+}
+
+type ProviderCollectionUpdateBody struct {
+	ReplaceImages bool `json:"replaceImages,omitempty"`
+}
+
+// NOTE(patrik): Fetches the media from the primary provider, if
+// mergeProviders is set the media is also fetched from all the other linked
+// providers and merged, providers that fails is skipped unless it's the
+// primary provider
+func fetchMediaData(ctx context.Context, app core.App, dbMedia database.Media, primary, primaryId string, mergeProviders bool) (provider.Media, merge.Sources, error) {
+	pm := app.ProviderManager()
+
+	primaryData, err := pm.GetMedia(ctx, primary, primaryId)
+	if err != nil {
+		return provider.Media{}, nil, err
+	}
+
+	if !mergeProviders {
+		return primaryData, merge.Single(primary, primaryData), nil
+	}
+
+	data := map[string]provider.Media{
+		primary: primaryData,
+	}
+
+	for name, id := range dbMedia.Providers {
+		if name == primary {
+			continue
+		}
+
+		info, ok := pm.GetProviderInfo(name)
+		if !ok || info.Disabled || !info.SupportGetMedia {
+			continue
+		}
+
+		m, err := pm.GetMedia(ctx, name, id)
+		if err != nil {
+			app.Logger().Warn("failed to get media from provider, skipping it in the merge", "mediaId", dbMedia.Id, "provider", name, "err", err)
+			continue
+		}
+
+		data[name] = m
+	}
+
+	policy := merge.GetPolicy(primaryData.Type, app.Config().MergePolicies[string(primaryData.Type)])
+	res, sources := merge.Merge(primary, policy, data)
+
+	return res, sources, nil
+}
+
+func UpdateMedia(ctx context.Context, app core.App, settings ProviderMediaUpdateBody, dbMedia database.Media, provider, providerId string) error {
+	data, sources, err := fetchMediaData(ctx, app, dbMedia, provider, providerId, settings.MergeProviders)
+	if err != nil {
+		return err
+	}
+
+	if data.AiringSeason != nil {
+		err := app.DB().CreateTag(ctx, *data.AiringSeason, *data.AiringSeason)
+		if err != nil && !errors.Is(err, database.ErrItemAlreadyExists) {
+			return err
+		}
+	}
+
+	changes := database.MediaChanges{}
+
+	changes.Type = database.Change[types.MediaType]{
+		Value:   data.Type,
+		Changed: data.Type != dbMedia.Type,
+	}
+
+	changes.Title = database.Change[string]{
+		Value:   data.Title,
+		Changed: data.Title != dbMedia.Title,
+	}
+
+	desc := utils.NullToDefault(data.Description)
+	changes.Description = database.Change[sql.NullString]{
+		Value: sql.NullString{
+			String: desc,
+			Valid:  desc != "",
+		},
+		Changed: desc != dbMedia.Description.String,
+	}
+
+	score := utils.NullToDefault(data.Score)
+	changes.Score = database.Change[sql.NullFloat64]{
+		Value: sql.NullFloat64{
+			Float64: score,
+			Valid:   score != 0.0,
+		},
+		Changed: score != dbMedia.Score.Float64,
+	}
+
+	changes.Status = database.Change[types.MediaStatus]{
+		Value:   data.Status,
+		Changed: data.Status != dbMedia.Status,
+	}
+
+	changes.Rating = database.Change[types.MediaRating]{
+		Value:   data.Rating,
+		Changed: data.Rating != dbMedia.Rating,
+	}
+
+	changes.Rating = database.Change[types.MediaRating]{
+		Value:   data.Rating,
+		Changed: data.Rating != dbMedia.Rating,
+	}
+
+	airingSeason := utils.NullToDefault(data.AiringSeason)
+	changes.AiringSeason = database.Change[sql.NullString]{
+		Value: sql.NullString{
+			String: airingSeason,
+			Valid:  airingSeason != "",
+		},
+		Changed: airingSeason != dbMedia.AiringSeason.String,
+	}
+
+	formatNullTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+
+		return t.Format(types.MediaDateLayout)
+	}
+
+	startDate := formatNullTime(data.StartDate)
+	changes.StartDate = database.Change[sql.NullString]{
+		Value: sql.NullString{
+			String: startDate,
+			Valid:  startDate != "",
+		},
+		Changed: startDate != dbMedia.StartDate.String,
+	}
+
+	endDate := formatNullTime(data.EndDate)
+	changes.EndDate = database.Change[sql.NullString]{
+		Value: sql.NullString{
+			String: endDate,
+			Valid:  endDate != "",
+		},
+		Changed: endDate != dbMedia.EndDate.String,
+	}
+
+	if settings.ReplaceImages {
+		mediaDir := app.WorkDir().MediaDirById(dbMedia.Id)
+
+		if data.CoverUrl != nil {
+			p, err := downloadProviderImage(*data.CoverUrl, mediaDir.Images())
+			if err != nil {
+				return fmt.Errorf("failed to download cover image for media: %w", err)
+			}
+
+			n := path.Base(p)
+			changes.CoverFile = database.Change[sql.NullString]{
+				Value: sql.NullString{
+					String: n,
+					Valid:  n != "",
+				},
+				Changed: true,
+			}
+		}
+
+		if data.BannerUrl != nil {
+			p, err := downloadProviderImage(*data.BannerUrl, mediaDir.Images())
+			if err != nil {
+				return fmt.Errorf("failed to download banner image for media: %w", err)
+			}
+
+			n := path.Base(p)
+			changes.BannerFile = database.Change[sql.NullString]{
+				Value: sql.NullString{
+					String: n,
 					Valid:  n != "",
 				},
 				Changed: true,
@@ -460,7 +1246,7 @@ func UpdateMedia(ctx context.Context, app core.App, settings ProviderMediaUpdate
 		}
 
 		if data.LogoUrl != nil {
-			p, err := utils.DownloadImageHashed(*data.LogoUrl, mediaDir.Images())
+			p, err := downloadProviderImage(*data.LogoUrl, mediaDir.Images())
 			if err != nil {
 				return fmt.Errorf("failed to download logo image for media: %w", err)
 			}
@@ -476,7 +1262,88 @@ func UpdateMedia(ctx context.Context, app core.App, settings ProviderMediaUpdate
 		}
 	}
 
-	// TODO(patrik): Extra Provider Ids?
+	providerIds := ember.KVStore{}
+	maps.Copy(providerIds, dbMedia.Providers)
+	for name, id := range data.ExtraProviderIds {
+		if _, exists := providerIds[name]; !exists && id != "" {
+			providerIds[name] = id
+		}
+	}
+
+	err = removeUsedProviderIds(ctx, app, dbMedia.Id, providerIds, dbMedia.Providers)
+	if err != nil {
+		return err
+	}
+
+	changes.Providers = database.Change[ember.KVStore]{
+		Value:   providerIds,
+		Changed: !maps.Equal(providerIds, dbMedia.Providers),
+	}
+
+	fieldSources := ember.KVStore{}
+	maps.Copy(fieldSources, dbMedia.FieldSources)
+
+	setSource := func(field merge.Field) {
+		if name, ok := sources[field]; ok {
+			fieldSources[string(field)] = name
+		} else {
+			delete(fieldSources, string(field))
+		}
+	}
+
+	for _, field := range []merge.Field{
+		merge.FieldTitle,
+		merge.FieldDescription,
+		merge.FieldScore,
+		merge.FieldStatus,
+		merge.FieldRating,
+		merge.FieldAiringSeason,
+		merge.FieldStartDate,
+		merge.FieldEndDate,
+		merge.FieldCreators,
+		merge.FieldTags,
+	} {
+		setSource(field)
+	}
+
+	if settings.ReplaceImages {
+		if data.CoverUrl != nil {
+			setSource(merge.FieldCover)
+		}
+
+		if data.BannerUrl != nil {
+			setSource(merge.FieldBanner)
+		}
+
+		if data.LogoUrl != nil {
+			setSource(merge.FieldLogo)
+		}
+	}
+
+	if settings.OverrideParts {
+		setSource(merge.FieldParts)
+	}
+
+	if len(data.ThemeSongs) > 0 {
+		setSource(merge.FieldThemeSongs)
+	}
+
+	if len(data.Relations) > 0 {
+		setSource(merge.FieldRelations)
+	}
+
+	if len(data.Credits) > 0 {
+		setSource(merge.FieldCredits)
+	}
+
+	if len(data.Videos) > 0 {
+		setSource(merge.FieldVideos)
+	}
+
+	changes.FieldSources = database.Change[ember.KVStore]{
+		Value:   fieldSources,
+		Changed: !maps.Equal(fieldSources, dbMedia.FieldSources),
+	}
 
 	err = app.DB().UpdateMedia(ctx, dbMedia.Id, changes)
 	if err != nil {
@@ -531,6 +1398,68 @@ func UpdateMedia(ctx context.Context, app core.App, settings ProviderMediaUpdate
 		}
 	}
 
+	// NOTE(patrik): Only replace the theme songs if the provider has them,
+	// most providers doesn't have theme songs
+	if len(data.ThemeSongs) > 0 {
+		err = app.DB().RemoveAllMediaThemeSongs(ctx, dbMedia.Id)
+		if err != nil {
+			return err
+		}
+
+		err = createMediaThemeSongs(ctx, app, dbMedia.Id, data.ThemeSongs)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(data.Relations) > 0 {
+		err = app.DB().RemoveAllMediaRelations(ctx, dbMedia.Id)
+		if err != nil {
+			return err
+		}
+
+		err = createMediaRelations(ctx, app, dbMedia.Id, data.Relations)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(data.Credits) > 0 {
+		err = app.DB().RemoveAllMediaPeople(ctx, dbMedia.Id)
+		if err != nil {
+			return err
+		}
+
+		err = createMediaPeople(ctx, app, dbMedia.Id, data.Credits)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(data.Videos) > 0 {
+		err = app.DB().RemoveAllMediaVideos(ctx, dbMedia.Id)
+		if err != nil {
+			return err
+		}
+
+		err = createMediaVideos(ctx, app, dbMedia.Id, data.Videos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if changes.Providers.Changed {
+		err = linkMediaRelations(ctx, app, dbMedia.Id, providerIds)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = updateMediaAvailability(ctx, app, dbMedia.Id, providerIds)
+	if err != nil {
+		return err
+	}
+
 	for _, tag := range data.Tags {
 		tag = utils.Slug(tag)
 
@@ -570,12 +1499,140 @@ func UpdateMedia(ctx context.Context, app core.App, settings ProviderMediaUpdate
 				DelayDays:        0,
 			})
 			if err != nil {
-				return err
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// NOTE(patrik): Replaces the availability of the media with the data from
+// the linked providers that knows where the media can be watched, the
+// stored availability is kept if none of the providers responded
+func updateMediaAvailability(ctx context.Context, app core.App, mediaId string, providerIds ember.KVStore) error {
+	pm := app.ProviderManager()
+
+	var items []database.CreateMediaAvailabilityParams
+	fetched := false
+
+	for _, name := range slices.Sorted(maps.Keys(providerIds)) {
+		if !pm.SupportsAvailability(name) {
+			continue
+		}
+
+		availability, err := pm.GetAvailability(ctx, name, providerIds[name])
+		if err != nil {
+			app.Logger().Warn("failed to get availability from provider", "mediaId", mediaId, "provider", name, "err", err)
+			continue
+		}
+
+		fetched = true
+
+		for _, a := range availability {
+			items = append(items, database.CreateMediaAvailabilityParams{
+				MediaId:      mediaId,
+				Region:       a.Region,
+				Type:         a.Type,
+				Service:      a.Service,
+				ServiceName:  a.ServiceName,
+				ProviderName: name,
+			})
+		}
+	}
+
+	if !fetched {
+		return nil
+	}
+
+	err := app.DB().RemoveAllMediaAvailability(ctx, mediaId)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		err := app.DB().CreateMediaAvailability(ctx, item)
+		if err != nil {
+			// NOTE(patrik): Skip the duplicates from the different
+			// providers
+			if errors.Is(err, database.ErrItemAlreadyExists) {
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NOTE(patrik): Downloads the images from all the linked providers that has
+// extra images into the gallery of the media, the images already inside the
+// gallery is skipped. The errors from the providers is returned per
+// provider and doesn't stop the other providers.
+func FetchMediaImages(ctx context.Context, app core.App, dbMedia database.Media) (int, map[string]error, error) {
+	pm := app.ProviderManager()
+
+	mediaDir := app.WorkDir().MediaDirById(dbMedia.Id)
+
+	err := os.MkdirAll(mediaDir.Images(), 0755)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	names := slices.Sorted(maps.Keys(dbMedia.Providers))
+
+	added := 0
+	errs := map[string]error{}
+
+	for _, name := range names {
+		if !pm.SupportsMediaImages(name) {
+			continue
+		}
+
+		images, err := pm.GetMediaImages(ctx, name, dbMedia.Providers[name])
+		if err != nil {
+			errs[name] = err
+			continue
+		}
+
+		for _, image := range images {
+			p, err := downloadProviderImage(image.Url, mediaDir.Images())
+			if err != nil {
+				app.Logger().Error("failed to download media image", "url", image.Url, "err", err)
+				continue
+			}
+
+			err = app.DB().CreateMediaImage(ctx, database.CreateMediaImageParams{
+				MediaId:      dbMedia.Id,
+				File:         path.Base(p),
+				Type:         image.Type,
+				ProviderName: name,
+			})
+			if err != nil {
+				if errors.Is(err, database.ErrItemAlreadyExists) {
+					continue
+				}
+
+				return 0, nil, err
 			}
+
+			added++
 		}
 	}
 
-	return nil
+	return added, errs, nil
+}
+
+// NOTE(patrik): Providers like the file provider returns local images
+// (file://), only use this for urls that comes from a provider and never
+// for urls from the user
+func downloadProviderImage(u, outDir string) (string, error) {
+	if p, ok := strings.CutPrefix(u, "file://"); ok {
+		return utils.CopyImageHashed(p, outDir)
+	}
+
+	return utils.DownloadImageHashed(u, outDir)
 }
 
 func InstallProviderHandlers(app core.App, group pyrin.Group) {
@@ -598,6 +1655,11 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 						displayName = p.Name
 					}
 
+					var disabledReason *string
+					if p.Disabled && p.DisabledReason != "" {
+						disabledReason = &p.DisabledReason
+					}
+
 					res.Providers[i] = Provider{
 						Name:        p.Name,
 						DisplayName: displayName,
@@ -607,6 +1669,8 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 							GetCollection:    p.SupportGetCollection,
 							SearchCollection: p.SupportSearchCollection,
 						},
+						Disabled:       p.Disabled,
+						DisabledReason: disabledReason,
 					}
 				}
 
@@ -622,21 +1686,32 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 			Name:         "ProviderSearchMedia",
 			Method:       http.MethodGet,
 			Path:         "/providers/:providerName/media",
-			ResponseType: GetProviderSearch{},
+			ResponseType: GetProviderSearchMedia{},
 			HandlerFunc: func(c pyrin.Context) (any, error) {
 				providerName := c.Param("providerName")
 				url := c.Request().URL
-				query := url.Query().Get("query")
+
+				params, err := getSearchParams(url.Query())
+				if err != nil {
+					return nil, err
+				}
 
 				pm := app.ProviderManager()
 
-				items, err := pm.SearchMedia(context.Background(), providerName, query)
+				page, err := pm.SearchMediaWithParams(context.Background(), providerName, params)
 				if err != nil {
 					// TODO(patrik): Handle some of the errors the provider gives, like the provider not found
 					return nil, err
 				}
 
-				res := GetProviderSearch{
+				items := page.Results
+
+				res := GetProviderSearchMedia{
+					Page: ProviderSearchPage{
+						Page:        page.Page,
+						TotalPages:  page.TotalPages,
+						HasNextPage: page.HasNextPage,
+					},
 					SearchResults: make([]ProviderSearchResult, len(items)),
 				}
 
@@ -646,7 +1721,231 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 						ProviderId:   item.ProviderId,
 						Title:        item.Title,
 						ImageUrl:     item.ImageUrl,
+						MediaType:    item.MediaType,
+						Year:         item.Year,
+					}
+				}
+
+				return res, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "ProviderGetSeasonal",
+			Method:       http.MethodGet,
+			Path:         "/providers/:providerName/seasonal/:season/:year",
+			ResponseType: GetProviderSeasonal{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				providerName := c.Param("providerName")
+				season := c.Param("season")
+
+				if !provider.IsValidSeason(season) {
+					return nil, InvalidSeason(season)
+				}
+
+				year, err := strconv.Atoi(c.Param("year"))
+				if err != nil {
+					return nil, InvalidSeason(season + "-" + c.Param("year"))
+				}
+
+				ctx := context.Background()
+
+				items, err := app.ProviderManager().GetSeasonal(ctx, providerName, season, year)
+				if err != nil {
+					return nil, err
+				}
+
+				res := GetProviderSeasonal{
+					Season:  season,
+					Year:    year,
+					Entries: make([]ProviderSeasonalEntry, 0, len(items)),
+				}
+
+				for _, item := range items {
+					entry := ProviderSeasonalEntry{
+						ProviderId: item.ProviderId,
+						Title:      item.Title,
+						ImageUrl:   item.ImageUrl,
+						MediaType:  item.MediaType,
+					}
+
+					if item.Year != 0 {
+						entry.Year = &item.Year
+					}
+
+					dbMedia, err := app.DB().GetMediaByProviderId(ctx, nil, providerName, item.ProviderId)
+					if err == nil {
+						entry.MediaId = &dbMedia.Id
+					} else if !errors.Is(err, database.ErrItemNotFound) {
+						return nil, err
+					}
+
+					res.Entries = append(res.Entries, entry)
+				}
+
+				return res, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "ProviderImportSeasonal",
+			Method:       http.MethodPost,
+			Path:         "/providers/:providerName/seasonal/import",
+			ResponseType: PostProviderImportSeasonal{},
+			BodyType:     PostProviderImportSeasonalBody{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				providerName := c.Param("providerName")
+
+				user, err := User(app, c, HasEditPrivilege)
+				if err != nil {
+					return nil, err
+				}
+
+				body, err := pyrin.Body[PostProviderImportSeasonalBody](c)
+				if err != nil {
+					return nil, err
+				}
+
+				if !app.ProviderManager().IsValidProvider(providerName) {
+					// TODO(patrik): Better error
+					return nil, errors.New("provider not found")
+				}
+
+				jobId, err := QueueImportProviderMedia(context.Background(), app, user.Id, providerName, body.Ids)
+				if err != nil {
+					return nil, err
+				}
+
+				return PostProviderImportSeasonal{
+					JobId: jobId,
+				}, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "ProviderMultiSearchMedia",
+			Method:       http.MethodGet,
+			Path:         "/providers/search/media",
+			ResponseType: GetProviderMultiSearch{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				q := c.Request().URL.Query()
+				query := strings.TrimSpace(q.Get("query"))
+
+				pm := app.ProviderManager()
+
+				res := GetProviderMultiSearch{
+					Results: []ProviderMultiSearchResult{},
+					Errors:  []ProviderSearchError{},
+				}
+
+				if query == "" {
+					return res, nil
+				}
+
+				// NOTE(patrik): Optional comma separated list of providers,
+				// defaults to all the providers that supports searching
+				providerNames := pm.GetMediaSearchProviders()
+				if s := q.Get("providers"); s != "" {
+					providerNames = fixArr(strings.Split(s, ","))
+				}
+
+				ctx := context.Background()
+
+				// NOTE(patrik): Slow providers should not hold up the
+				// results from the other providers forever, the timeout
+				// only applies to the provider searches and not the library
+				// lookups below
+				results := pm.SearchMediaMultiple(ctx, providerNames, query, 30*time.Second)
+
+				for i, r := range results {
+					if r.Err != nil {
+						app.Logger().Warn("provider search failed", "provider", r.ProviderName, "err", r.Err)
+
+						res.Errors = append(res.Errors, ProviderSearchError{
+							ProviderName: r.ProviderName,
+							Message:      r.Err.Error(),
+						})
+						continue
+					}
+
+					// NOTE(patrik): Add the ids from the id mappings so
+					// more of the results can be matched by id
+					for j, item := range r.Results {
+						ids := app.IdMappings().Lookup(r.ProviderName, item.ProviderId)
+						if len(ids) == 0 {
+							continue
+						}
+
+						extra := maps.Clone(item.ExtraProviderIds)
+						if extra == nil {
+							extra = map[string]string{}
+						}
+
+						for name, id := range ids {
+							if _, exists := extra[name]; !exists && name != r.ProviderName {
+								extra[name] = id
+							}
+						}
+
+						results[i].Results[j].ExtraProviderIds = extra
+					}
+				}
+
+				groups := provider.GroupSearchResults(results)
+
+				for _, group := range groups {
+					var result ProviderMultiSearchResult
+
+					for _, item := range group.Items {
+						displayName := item.ProviderName
+						if info, ok := pm.GetProviderInfo(item.ProviderName); ok {
+							displayName = info.GetDisplayName()
+						}
+
+						var year *int
+						if item.Result.Year != 0 {
+							y := item.Result.Year
+							year = &y
+						}
+
+						var mediaId *string
+						dbMedia, err := app.DB().GetMediaByProviderId(ctx, nil, item.ProviderName, item.Result.ProviderId)
+						if err == nil {
+							mediaId = &dbMedia.Id
+						} else if !errors.Is(err, database.ErrItemNotFound) {
+							return nil, err
+						}
+
+						result.Items = append(result.Items, ProviderMultiSearchItem{
+							ProviderName:        item.ProviderName,
+							ProviderDisplayName: displayName,
+							ProviderId:          item.Result.ProviderId,
+							Title:               item.Result.Title,
+							ImageUrl:            item.Result.ImageUrl,
+							MediaType:           item.Result.MediaType,
+							Year:                year,
+							MediaId:             mediaId,
+						})
+
+						if result.Title == "" {
+							result.Title = item.Result.Title
+							result.MediaType = item.Result.MediaType
+						}
+
+						if result.ImageUrl == "" {
+							result.ImageUrl = item.Result.ImageUrl
+						}
+
+						if result.Year == nil {
+							result.Year = year
+						}
+
+						if result.MediaId == nil {
+							result.MediaId = mediaId
+						}
 					}
+
+					res.Results = append(res.Results, result)
 				}
 
 				return res, nil
@@ -681,6 +1980,8 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 						ProviderId:   item.ProviderId,
 						Title:        item.Title,
 						ImageUrl:     item.ImageUrl,
+						MediaType:    item.MediaType,
+						Year:         item.Year,
 					}
 				}
 
@@ -716,6 +2017,8 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 						ProviderId:   item.ProviderId,
 						Title:        item.Title,
 						ImageUrl:     item.ImageUrl,
+						MediaType:    item.MediaType,
+						Year:         item.Year,
 					}
 				}
 
@@ -724,159 +2027,112 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 		},
 
 		pyrin.ApiHandler{
-			Name:   "ProviderImportMedia",
-			Method: http.MethodPost,
-			Path:   "/providers/:providerName/media/import",
-			// ResponseType: PostProviderImportMedia{},
-			BodyType: PostProviderImportMediaBody{},
+			Name:         "ProviderResolveLinks",
+			Method:       http.MethodPost,
+			Path:         "/providers/links",
+			ResponseType: PostProviderResolveLinks{},
+			BodyType:     PostProviderResolveLinksBody{},
 			HandlerFunc: func(c pyrin.Context) (any, error) {
-				providerName := c.Param("providerName")
-
-				body, err := pyrin.Body[PostProviderImportMediaBody](c)
+				body, err := pyrin.Body[PostProviderResolveLinksBody](c)
 				if err != nil {
 					return nil, err
 				}
 
+				if body.Import {
+					_, err := User(app, c, HasEditPrivilege)
+					if err != nil {
+						return nil, err
+					}
+				}
+
+				pm := app.ProviderManager()
+
 				ctx := context.Background()
 
-				for _, id := range body.Ids {
-					_, err := ImportMedia(ctx, app, providerName, id)
+				res := PostProviderResolveLinks{
+					Results: []ProviderResolvedLink{},
+					Errors:  []ProviderLinkError{},
+				}
+
+				for _, link := range body.Links {
+					resolved, err := pm.ResolveLink(ctx, link)
 					if err != nil {
-						return nil, err
+						// NOTE(patrik): One bad link shouldn't fail the
+						// rest of the links
+						res.Errors = append(res.Errors, ProviderLinkError{
+							Link:    link,
+							Message: err.Error(),
+						})
+						continue
+					}
+
+					for _, r := range resolved {
+						item, err := resolveProviderLink(ctx, app, r, body.Import)
+						if err != nil {
+							app.Logger().Error("failed to resolve provider link", "err", err, "link", link, "providerName", r.ProviderName, "providerId", r.ProviderId)
+							res.Errors = append(res.Errors, ProviderLinkError{
+								Link:    link,
+								Message: err.Error(),
+							})
+							continue
+						}
+
+						item.Link = link
+						res.Results = append(res.Results, item)
 					}
 				}
 
-				return nil, nil
+				return res, nil
 			},
 		},
 
 		pyrin.ApiHandler{
-			Name:   "ProviderImportCollections",
+			Name:   "ProviderImportMedia",
 			Method: http.MethodPost,
-			Path:   "/providers/:providerName/collections/import",
+			Path:   "/providers/:providerName/media/import",
 			// ResponseType: PostProviderImportMedia{},
-			BodyType: PostProviderImportCollectionsBody{},
+			BodyType: PostProviderImportMediaBody{},
 			HandlerFunc: func(c pyrin.Context) (any, error) {
 				providerName := c.Param("providerName")
 
-				body, err := pyrin.Body[PostProviderImportCollectionsBody](c)
+				body, err := pyrin.Body[PostProviderImportMediaBody](c)
 				if err != nil {
 					return nil, err
 				}
 
-				pm := app.ProviderManager()
-
 				ctx := context.Background()
 
-				for _, providerId := range body.Ids {
-					_, err := app.DB().GetCollectionByProviderId(ctx, providerName, providerId)
-					if err == nil {
-						fmt.Printf("id already exists: %v\n", providerId)
-						continue
-					}
-
-					if !errors.Is(err, database.ErrItemNotFound) {
-						return nil, err
-					}
-
-					data, err := pm.GetCollection(ctx, providerName, providerId)
-					if err != nil {
-						// TODO(patrik): Handle err
-						return nil, err
-					}
-
-					id := utils.CreateCollectionId()
-
-					collectionDir := app.WorkDir().CollectionDirById(id)
-					dirs := []string{
-						collectionDir.String(),
-						collectionDir.Images(),
-					}
-
-					for _, dir := range dirs {
-						err = os.Mkdir(dir, 0755)
-						if err != nil && !os.IsExist(err) {
-							return nil, err
-						}
-					}
-
-					providerIds := ember.KVStore{}
-					maps.Copy(providerIds, data.ExtraProviderIds)
-					providerIds[providerName] = data.ProviderId
-
-					coverFilename := ""
-					bannerFilename := ""
-					logoFilename := ""
-
-					if data.CoverUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.CoverUrl, collectionDir.Images())
-						if err == nil {
-							coverFilename = path.Base(p)
-						} else {
-							app.Logger().Error("failed to download cover image for collection", "err", err)
-						}
-					}
-
-					if data.BannerUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.BannerUrl, collectionDir.Images())
-						if err == nil {
-							bannerFilename = path.Base(p)
-						} else {
-							app.Logger().Error("failed to download banner image for collection", "err", err)
-						}
-					}
-
-					if data.LogoUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.LogoUrl, collectionDir.Images())
-						if err == nil {
-							logoFilename = path.Base(p)
-						} else {
-							app.Logger().Error("failed to download logo image for collection", "err", err)
-						}
-					}
-
-					_, err = app.DB().CreateCollection(ctx, database.CreateCollectionParams{
-						Id:   id,
-						Type: data.Type,
-						Name: data.Name,
-						CoverFile: sql.NullString{
-							String: coverFilename,
-							Valid:  coverFilename != "",
-						},
-						BannerFile: sql.NullString{
-							String: bannerFilename,
-							Valid:  bannerFilename != "",
-						},
-						LogoFile: sql.NullString{
-							String: logoFilename,
-							Valid:  logoFilename != "",
-						},
-						DefaultProvider: sql.NullString{
-							String: providerName,
-							Valid:  providerName != "",
-						},
-						Providers: providerIds,
-					})
+				for _, id := range body.Ids {
+					_, err := ImportMedia(ctx, app, providerName, id)
 					if err != nil {
 						return nil, err
 					}
+				}
 
-					for i, item := range data.Items {
-						mediaId, err := ImportMedia(ctx, app, providerName, item.Id)
-						if err != nil {
-							return nil, err
-						}
+				return nil, nil
+			},
+		},
 
-						err = app.DB().CreateCollectionMediaItem(ctx, database.CreateCollectionMediaItemParams{
-							CollectionId: id,
-							MediaId:      mediaId,
-							Name:         item.Name,
-							SearchSlug:   utils.Slug(item.Name),
-							Position:     i,
-						})
-						if err != nil {
-							return nil, err
-						}
+		pyrin.ApiHandler{
+			Name:   "ProviderImportCollections",
+			Method: http.MethodPost,
+			Path:   "/providers/:providerName/collections/import",
+			// ResponseType: PostProviderImportMedia{},
+			BodyType: PostProviderImportCollectionsBody{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				providerName := c.Param("providerName")
+
+				body, err := pyrin.Body[PostProviderImportCollectionsBody](c)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := context.Background()
+
+				for _, providerId := range body.Ids {
+					_, err := ImportCollection(ctx, app, providerName, providerId)
+					if err != nil {
+						return nil, err
 					}
 				}
 
@@ -943,7 +2199,7 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 					logoFilename := ""
 
 					if data.CoverUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.CoverUrl, showDir.Images())
+						p, err := downloadProviderImage(*data.CoverUrl, showDir.Images())
 						if err == nil {
 							coverFilename = path.Base(p)
 						} else {
@@ -952,7 +2208,7 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 					}
 
 					if data.BannerUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.BannerUrl, showDir.Images())
+						p, err := downloadProviderImage(*data.BannerUrl, showDir.Images())
 						if err == nil {
 							bannerFilename = path.Base(p)
 						} else {
@@ -961,7 +2217,7 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 					}
 
 					if data.LogoUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.LogoUrl, showDir.Images())
+						p, err := downloadProviderImage(*data.LogoUrl, showDir.Images())
 						if err == nil {
 							logoFilename = path.Base(p)
 						} else {
@@ -1073,8 +2329,6 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 					return nil, err
 				}
 
-				pm := app.ProviderManager()
-
 				ctx := context.Background()
 
 				dbMedia, err := app.DB().GetMediaById(ctx, nil, mediaId)
@@ -1095,256 +2349,6 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 				}
 
 				return nil, nil
-
-				data, err := pm.GetMedia(ctx, providerName, providerId)
-				if err != nil {
-					fmt.Printf("err: %v\n", err)
-					// TODO(patrik): Handle err
-					return "", err
-				}
-
-				if data.AiringSeason != nil {
-					err := app.DB().CreateTag(ctx, *data.AiringSeason, *data.AiringSeason)
-					if err != nil && !errors.Is(err, database.ErrItemAlreadyExists) {
-						return "", err
-					}
-				}
-
-				changes := database.MediaChanges{}
-
-				changes.Type = database.Change[types.MediaType]{
-					Value:   data.Type,
-					Changed: data.Type != dbMedia.Type,
-				}
-
-				changes.Title = database.Change[string]{
-					Value:   data.Title,
-					Changed: data.Title != dbMedia.Title,
-				}
-
-				desc := utils.NullToDefault(data.Description)
-				changes.Description = database.Change[sql.NullString]{
-					Value: sql.NullString{
-						String: desc,
-						Valid:  desc != "",
-					},
-					Changed: desc != dbMedia.Description.String,
-				}
-
-				score := utils.NullToDefault(data.Score)
-				changes.Score = database.Change[sql.NullFloat64]{
-					Value: sql.NullFloat64{
-						Float64: score,
-						Valid:   score != 0.0,
-					},
-					Changed: score != dbMedia.Score.Float64,
-				}
-
-				changes.Status = database.Change[types.MediaStatus]{
-					Value:   data.Status,
-					Changed: data.Status != dbMedia.Status,
-				}
-
-				changes.Rating = database.Change[types.MediaRating]{
-					Value:   data.Rating,
-					Changed: data.Rating != dbMedia.Rating,
-				}
-
-				changes.Rating = database.Change[types.MediaRating]{
-					Value:   data.Rating,
-					Changed: data.Rating != dbMedia.Rating,
-				}
-
-				airingSeason := utils.NullToDefault(data.AiringSeason)
-				changes.AiringSeason = database.Change[sql.NullString]{
-					Value: sql.NullString{
-						String: airingSeason,
-						Valid:  airingSeason != "",
-					},
-					Changed: airingSeason != dbMedia.AiringSeason.String,
-				}
-
-				formatNullTime := func(t *time.Time) string {
-					if t == nil {
-						return ""
-					}
-
-					return t.Format(types.MediaDateLayout)
-				}
-
-				startDate := formatNullTime(data.StartDate)
-				changes.StartDate = database.Change[sql.NullString]{
-					Value: sql.NullString{
-						String: startDate,
-						Valid:  startDate != "",
-					},
-					Changed: startDate != dbMedia.StartDate.String,
-				}
-
-				endDate := formatNullTime(data.EndDate)
-				changes.EndDate = database.Change[sql.NullString]{
-					Value: sql.NullString{
-						String: endDate,
-						Valid:  endDate != "",
-					},
-					Changed: endDate != dbMedia.EndDate.String,
-				}
-
-				if body.ReplaceImages {
-					// TODO(patrik): Cover
-					// TODO(patrik): Banner
-					// TODO(patrik): Logo
-					mediaDir := app.WorkDir().MediaDirById(dbMedia.Id)
-
-					if data.CoverUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.CoverUrl, mediaDir.Images())
-						if err != nil {
-							return "", fmt.Errorf("failed to download cover image for media: %w", err)
-						}
-
-						n := path.Base(p)
-						changes.CoverFile = database.Change[sql.NullString]{
-							Value: sql.NullString{
-								String: n,
-								Valid:  n != "",
-							},
-							Changed: true,
-						}
-					}
-
-					if data.BannerUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.BannerUrl, mediaDir.Images())
-						if err != nil {
-							return "", fmt.Errorf("failed to download banner image for media: %w", err)
-						}
-
-						n := path.Base(p)
-						changes.BannerFile = database.Change[sql.NullString]{
-							Value: sql.NullString{
-								String: n,
-								Valid:  n != "",
-							},
-							Changed: true,
-						}
-					}
-
-					if data.LogoUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.LogoUrl, mediaDir.Images())
-						if err != nil {
-							return "", fmt.Errorf("failed to download logo image for media: %w", err)
-						}
-
-						n := path.Base(p)
-						changes.LogoFile = database.Change[sql.NullString]{
-							Value: sql.NullString{
-								String: n,
-								Valid:  n != "",
-							},
-							Changed: true,
-						}
-					}
-				}
-
-				// TODO(patrik): Extra Provider Ids?
-
-				err = app.DB().UpdateMedia(ctx, dbMedia.Id, changes)
-				if err != nil {
-					return nil, err
-				}
-
-				if body.OverrideParts {
-					err = app.DB().RemoveAllMediaParts(ctx, dbMedia.Id)
-					if err != nil {
-						return nil, err
-					}
-
-					if data.Type.IsMovie() {
-						releaseDate := ""
-						if data.StartDate != nil {
-							releaseDate = data.StartDate.Format(types.MediaDateLayout)
-						}
-
-						err := app.DB().CreateMediaPart(ctx, database.CreateMediaPartParams{
-							MediaId: dbMedia.Id,
-							Name:    data.Title,
-							Index:   1,
-							ReleaseDate: sql.NullString{
-								String: releaseDate,
-								Valid:  releaseDate != "",
-							},
-						})
-						if err != nil {
-							return nil, err
-						}
-					} else {
-						for _, part := range data.Parts {
-							releaseDate := ""
-							if part.ReleaseDate != nil {
-								releaseDate = part.ReleaseDate.Format(types.MediaDateLayout)
-							}
-
-							// TODO(patrik): Check for duplicated numbers
-							err := app.DB().CreateMediaPart(ctx, database.CreateMediaPartParams{
-								MediaId: dbMedia.Id,
-								Name:    part.Name,
-								Index:   int64(part.Number),
-								ReleaseDate: sql.NullString{
-									String: releaseDate,
-									Valid:  releaseDate != "",
-								},
-							})
-							if err != nil {
-								return nil, err
-							}
-						}
-					}
-				}
-
-				for _, tag := range data.Tags {
-					tag = utils.Slug(tag)
-
-					err := app.DB().CreateTag(ctx, tag, tag)
-					if err != nil && !errors.Is(err, database.ErrItemAlreadyExists) {
-						return nil, err
-					}
-
-					err = app.DB().AddTagToMedia(ctx, dbMedia.Id, tag)
-					if err != nil && !errors.Is(err, database.ErrItemAlreadyExists) {
-						return nil, err
-					}
-				}
-
-				for _, tag := range data.Creators {
-					tag = utils.Slug(tag)
-
-					err := app.DB().CreateTag(ctx, tag, tag)
-					if err != nil && !errors.Is(err, database.ErrItemAlreadyExists) {
-						return nil, err
-					}
-
-					err = app.DB().AddCreatorToMedia(ctx, dbMedia.Id, tag)
-					if err != nil && !errors.Is(err, database.ErrItemAlreadyExists) {
-						return nil, err
-					}
-				}
-
-				if body.SetRelease {
-					if data.Release != nil {
-						err := app.DB().SetMediaPartRelease(ctx, dbMedia.Id, database.SetMediaPartRelease{
-							Type:             types.MediaPartReleaseTypeNotConfirmed,
-							StartDate:        data.Release.Format(time.RFC3339),
-							NumExpectedParts: len(data.Parts),
-							PartOffset:       0,
-							IntervalDays:     7,
-							DelayDays:        0,
-						})
-						if err != nil {
-							return nil, err
-						}
-					}
-				}
-
-				return nil, nil
 			},
 		},
 
@@ -1401,7 +2405,7 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 					collectionDir := app.WorkDir().CollectionDirById(dbCollection.Id)
 
 					if data.CoverUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.CoverUrl, collectionDir.Images())
+						p, err := downloadProviderImage(*data.CoverUrl, collectionDir.Images())
 						if err != nil {
 							// TODO(patrik): Better error
 							return "", fmt.Errorf("failed to download cover image for collection: %w", err)
@@ -1418,7 +2422,7 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 					}
 
 					if data.BannerUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.BannerUrl, collectionDir.Images())
+						p, err := downloadProviderImage(*data.BannerUrl, collectionDir.Images())
 						if err != nil {
 							// TODO(patrik): Better error
 							return "", fmt.Errorf("failed to download banner image for collection: %w", err)
@@ -1435,7 +2439,7 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 					}
 
 					if data.LogoUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.LogoUrl, collectionDir.Images())
+						p, err := downloadProviderImage(*data.LogoUrl, collectionDir.Images())
 						if err != nil {
 							// TODO(patrik): Better error
 							return "", fmt.Errorf("failed to download logo image for collection: %w", err)
@@ -1555,7 +2559,7 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 					showDir := app.WorkDir().CollectionDirById(dbShow.Id)
 
 					if data.CoverUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.CoverUrl, showDir.Images())
+						p, err := downloadProviderImage(*data.CoverUrl, showDir.Images())
 						if err != nil {
 							// TODO(patrik): Better error
 							return "", fmt.Errorf("failed to download cover image for show: %w", err)
@@ -1572,7 +2576,7 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 					}
 
 					if data.BannerUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.BannerUrl, showDir.Images())
+						p, err := downloadProviderImage(*data.BannerUrl, showDir.Images())
 						if err != nil {
 							// TODO(patrik): Better error
 							return "", fmt.Errorf("failed to download banner image for show: %w", err)
@@ -1589,7 +2593,7 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 					}
 
 					if data.LogoUrl != nil {
-						p, err := utils.DownloadImageHashed(*data.LogoUrl, showDir.Images())
+						p, err := downloadProviderImage(*data.LogoUrl, showDir.Images())
 						if err != nil {
 							// TODO(patrik): Better error
 							return "", fmt.Errorf("failed to download logo image for show: %w", err)
@@ -1669,5 +2673,179 @@ func InstallProviderHandlers(app core.App, group pyrin.Group) {
 				return nil, nil
 			},
 		},
+
+		pyrin.ApiHandler{
+			Name:         "ResolveMediaProviders",
+			Method:       http.MethodPost,
+			Path:         "/media/:id/providers/resolve",
+			ResponseType: ResolveMediaProviders{},
+			BodyType:     ResolveMediaProvidersBody{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				_, err := User(app, c, HasEditPrivilege)
+				if err != nil {
+					return nil, err
+				}
+
+				body, err := pyrin.Body[ResolveMediaProvidersBody](c)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := context.Background()
+
+				dbMedia, err := app.DB().GetMediaById(ctx, nil, id)
+				if err != nil {
+					if errors.Is(err, database.ErrItemNotFound) {
+						return nil, MediaNotFound()
+					}
+
+					return nil, err
+				}
+
+				added, err := ResolveMediaProviderIds(ctx, app, dbMedia, body.FetchProviders)
+				if err != nil {
+					return nil, err
+				}
+
+				dbMedia, err = app.DB().GetMediaById(ctx, nil, id)
+				if err != nil {
+					return nil, err
+				}
+
+				return ResolveMediaProviders{
+					Added:     added,
+					Providers: createProviderValues(app.ProviderManager(), dbMedia.Providers),
+				}, nil
+			},
+		},
+
+		pyrin.ApiHandler{
+			Name:         "BackfillMediaProviders",
+			Method:       http.MethodPost,
+			Path:         "/providers/backfill",
+			ResponseType: BackfillMediaProviders{},
+			BodyType:     ResolveMediaProvidersBody{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				user, err := User(app, c, RequireAdmin)
+				if err != nil {
+					return nil, err
+				}
+
+				body, err := pyrin.Body[ResolveMediaProvidersBody](c)
+				if err != nil {
+					return nil, err
+				}
+
+				store := ember.KVStore{
+					"fetchProviders": strconv.FormatBool(body.FetchProviders),
+				}
+
+				payload, err := store.Serialize()
+				if err != nil {
+					return nil, err
+				}
+
+				jobId, err := createJob(context.Background(), app, database.CreateJobParams{
+					Type:        JobTypeBackfillProviderIds,
+					Status:      types.JobStatusQueued,
+					Priority:    0,
+					RunAt:       0,
+					Attempts:    0,
+					MaxAttempts: 1,
+					Payload:     payload,
+					Error:       sql.NullString{},
+					UserId: sql.NullString{
+						String: user.Id,
+						Valid:  true,
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				return BackfillMediaProviders{
+					JobId: jobId,
+				}, nil
+			},
+		},
 	)
 }
+
+// NOTE(patrik): Queues a job that imports the provider ids, the ids already
+// inside the library is skipped by the job. userId is the user that queued
+// the job, empty for the server.
+func QueueImportProviderMedia(ctx context.Context, app core.App, userId, providerName string, ids []string) (string, error) {
+	store := ember.KVStore{
+		"providerName": providerName,
+		"ids":          strings.Join(ids, ","),
+	}
+
+	payload, err := store.Serialize()
+	if err != nil {
+		return "", err
+	}
+
+	return createJob(ctx, app, database.CreateJobParams{
+		Type:        JobTypeImportProviderMedia,
+		Status:      types.JobStatusQueued,
+		Priority:    0,
+		RunAt:       0,
+		Attempts:    0,
+		MaxAttempts: 1,
+		Payload:     payload,
+		Error:       sql.NullString{},
+		UserId: sql.NullString{
+			String: userId,
+			Valid:  userId != "",
+		},
+	})
+}
+
+// NOTE(patrik): Imports the provider ids one by one, one failed import
+// doesn't stop the rest. Returns the number of failed imports.
+func ImportProviderMediaList(ctx context.Context, app core.App, providerName string, ids []string) (int, error) {
+	failed := 0
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return failed, ctx.Err()
+		}
+
+		_, err := ImportMedia(ctx, app, providerName, id)
+		if err != nil {
+			app.Logger().Error("failed to import media", "provider", providerName, "providerId", id, "err", err)
+			failed++
+		}
+	}
+
+	return failed, nil
+}
+
+// NOTE(patrik): Imports all the entries from the seasonal chart of the
+// current season, entries with a unknown type (music videos) is skipped
+func ImportCurrentSeason(ctx context.Context, app core.App, providerName string) (int, int, error) {
+	season, year := provider.GetSeason(time.Now())
+
+	items, err := app.ProviderManager().GetSeasonal(ctx, providerName, season, year)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.MediaType == types.MediaTypeUnknown {
+			continue
+		}
+
+		ids = append(ids, item.ProviderId)
+	}
+
+	failed, err := ImportProviderMediaList(ctx, app, providerName, ids)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return len(ids), failed, nil
+}
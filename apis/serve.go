@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/nanoteck137/pyrin"
 	"github.com/nanoteck137/pyrin/ember"
@@ -17,6 +19,44 @@ import (
 	"github.com/nanoteck137/watchbook/types"
 )
 
+const (
+	JobTypeBackfillProviderIds = "backfill-provider-ids"
+	JobTypeBuildShowFromChain  = "build-show-from-chain"
+	JobTypeImportProviderMedia = "import-provider-media"
+	JobTypeImportCurrentSeason = "import-current-season"
+	JobTypeRefreshMetadata     = "refresh-metadata"
+	JobTypeAiringCheck         = "airing-check"
+	JobTypeProviderCachePrune  = "provider-cache-prune"
+)
+
+// NOTE(patrik): Updates the media from the default provider, the media
+// without a default provider is skipped. Returns the number of media that
+// failed to update.
+func refreshMediaList(ctx context.Context, app core.App, media []database.Media, settings ProviderMediaUpdateBody) (int, error) {
+	failed := 0
+
+	for _, m := range media {
+		if ctx.Err() != nil {
+			return failed, ctx.Err()
+		}
+
+		providerName := m.DefaultProvider.String
+
+		providerId, ok := m.Providers[providerName]
+		if !ok {
+			continue
+		}
+
+		err := UpdateMedia(ctx, app, settings, m, providerName, providerId)
+		if err != nil {
+			app.Logger().Error("failed to refresh media", "mediaId", m.Id, "provider", providerName, "err", err)
+			failed++
+		}
+	}
+
+	return failed, nil
+}
+
 func RegisterHandlers(app core.App, router pyrin.Router) {
 	g := router.Group("/api/v1")
 	InstallAuthHandlers(app, g)
@@ -28,6 +68,9 @@ func RegisterHandlers(app core.App, router pyrin.Router) {
 	InstallProviderHandlers(app, g)
 	InstallFolderHandlers(app, g)
 	InstallShowHandlers(app, g)
+	InstallPersonHandlers(app, g)
+	InstallJobHandlers(app, g)
+	InstallJobScheduleHandlers(app, g)
 
 	g = router.Group("/files")
 	g.Register(
@@ -89,7 +132,120 @@ func Server(app core.App) (*pyrin.Server, error) {
 		},
 	})
 
-	app.JobProcessor().RegisterHandler("import-mal-watchlist", func(ctx context.Context, job database.Job) error {
+	// NOTE(patrik): The timeouts are the longest time a single run of the
+	// job is allowed to take, the big imports talks to rate limited
+	// providers so they get more time
+	app.JobProcessor().RegisterHandler(JobTypeBackfillProviderIds, 2*time.Hour, func(ctx context.Context, job database.Job) error {
+		store, err := ember.DeserializeKVStore(job.Payload)
+		if err != nil {
+			return err
+		}
+
+		fetchProviders, _ := strconv.ParseBool(store["fetchProviders"])
+
+		media, err := app.DB().GetAllMedia(ctx)
+		if err != nil {
+			return err
+		}
+
+		updated := 0
+		failed := 0
+
+		for _, m := range media {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			added, err := ResolveMediaProviderIds(ctx, app, m, fetchProviders)
+			if err != nil {
+				app.Logger().Error("failed to resolve provider ids", "mediaId", m.Id, "err", err)
+				failed++
+				continue
+			}
+
+			if len(added) > 0 {
+				app.Logger().Info("added provider ids to media", "mediaId", m.Id, "providers", added)
+				updated++
+			}
+		}
+
+		app.Logger().Info("provider id backfill done", "total", len(media), "updated", updated, "failed", failed)
+
+		return nil
+	})
+
+	app.JobProcessor().RegisterHandler(JobTypeBuildShowFromChain, 30*time.Minute, func(ctx context.Context, job database.Job) error {
+		store, err := ember.DeserializeKVStore(job.Payload)
+		if err != nil {
+			return err
+		}
+
+		providerName := store["providerName"]
+		providerId := store["providerId"]
+
+		if !app.ProviderManager().IsValidProvider(providerName) {
+			// TODO(patrik): Better error
+			return errors.New("unsupported operation")
+		}
+
+		showId, err := CreateShowFromChain(ctx, app, providerName, providerId, store["name"])
+		if err != nil {
+			return err
+		}
+
+		app.Logger().Info("built show from sequel chain", "showId", showId, "provider", providerName, "providerId", providerId)
+
+		return nil
+	})
+
+	app.JobProcessor().RegisterHandler(JobTypeImportProviderMedia, 1*time.Hour, func(ctx context.Context, job database.Job) error {
+		store, err := ember.DeserializeKVStore(job.Payload)
+		if err != nil {
+			return err
+		}
+
+		providerName := store["providerName"]
+		ids := strings.Split(store["ids"], ",")
+
+		if !app.ProviderManager().IsValidProvider(providerName) {
+			// TODO(patrik): Better error
+			return errors.New("unsupported operation")
+		}
+
+		failed, err := ImportProviderMediaList(ctx, app, providerName, ids)
+		if err != nil {
+			return err
+		}
+
+		app.Logger().Info("provider media import done", "provider", providerName, "total", len(ids), "failed", failed)
+
+		return nil
+	})
+
+	app.JobProcessor().RegisterHandler(JobTypeImportCurrentSeason, 1*time.Hour, func(ctx context.Context, job database.Job) error {
+		store, err := ember.DeserializeKVStore(job.Payload)
+		if err != nil {
+			return err
+		}
+
+		providerName := store["providerName"]
+
+		if !app.ProviderManager().IsValidProvider(providerName) {
+			// TODO(patrik): Better error
+			return errors.New("unsupported operation")
+		}
+
+		total, failed, err := ImportCurrentSeason(ctx, app, providerName)
+		if err != nil {
+			return err
+		}
+
+		app.Logger().Info("current season import done", "provider", providerName, "total", total, "failed", failed)
+
+		return nil
+	})
+
+	app.JobProcessor().RegisterHandler("import-mal-watchlist", 1*time.Hour, func(ctx context.Context, job database.Job) error {
 		store, err := ember.DeserializeKVStore(job.Payload)
 		if err != nil {
 			return err
@@ -152,5 +308,102 @@ func Server(app core.App) (*pyrin.Server, error) {
 		return nil
 	})
 
+	app.JobProcessor().RegisterHandler(JobTypeRefreshMetadata, 6*time.Hour, func(ctx context.Context, job database.Job) error {
+		store, err := ember.DeserializeKVStore(job.Payload)
+		if err != nil {
+			return err
+		}
+
+		mergeProviders, _ := strconv.ParseBool(store["mergeProviders"])
+
+		media, err := app.DB().GetAllMedia(ctx)
+		if err != nil {
+			return err
+		}
+
+		failed, err := refreshMediaList(ctx, app, media, ProviderMediaUpdateBody{
+			MergeProviders: mergeProviders,
+		})
+		if err != nil {
+			return err
+		}
+
+		app.Logger().Info("metadata refresh done", "total", len(media), "failed", failed)
+
+		return nil
+	})
+
+	// NOTE(patrik): Only refreshes the media that is still airing or hasn't
+	// started yet, the parts is updated so new episodes shows up
+	app.JobProcessor().RegisterHandler(JobTypeAiringCheck, 1*time.Hour, func(ctx context.Context, job database.Job) error {
+		store, err := ember.DeserializeKVStore(job.Payload)
+		if err != nil {
+			return err
+		}
+
+		mergeProviders, _ := strconv.ParseBool(store["mergeProviders"])
+
+		media, err := app.DB().GetAllMediaByStatus(ctx, types.MediaStatusOngoing, types.MediaStatusUpcoming)
+		if err != nil {
+			return err
+		}
+
+		failed, err := refreshMediaList(ctx, app, media, ProviderMediaUpdateBody{
+			OverrideParts:  true,
+			MergeProviders: mergeProviders,
+		})
+		if err != nil {
+			return err
+		}
+
+		app.Logger().Info("airing check done", "total", len(media), "failed", failed)
+
+		return nil
+	})
+
+	app.JobProcessor().RegisterHandler(JobTypeProviderCachePrune, 5*time.Minute, func(ctx context.Context, job database.Job) error {
+		store, err := ember.DeserializeKVStore(job.Payload)
+		if err != nil {
+			return err
+		}
+
+		pm := app.ProviderManager()
+
+		// NOTE(patrik): With a provider name the whole cache of the provider
+		// is cleared, otherwise only the expired entries is removed
+		providerName := store["providerName"]
+		if providerName != "" {
+			if !pm.IsValidProvider(providerName) {
+				// TODO(patrik): Better error
+				return errors.New("unsupported operation")
+			}
+
+			err := pm.ClearCache(providerName)
+			if err != nil {
+				return err
+			}
+
+			app.Logger().Info("provider cache cleared", "provider", providerName)
+
+			return nil
+		}
+
+		removed, err := pm.ClearExpiredCache()
+		if err != nil {
+			return err
+		}
+
+		app.Logger().Info("provider cache pruned", "removed", removed)
+
+		return nil
+	})
+
+	// NOTE(patrik): The job types from the config is validated against the
+	// handlers so this needs to run after the handlers has been registered
+	err := SyncConfigJobSchedules(context.Background(), app)
+	if err != nil {
+		return nil, err
+	}
+
 	return s, nil
 }
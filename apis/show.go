@@ -9,11 +9,13 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/nanoteck137/pyrin"
 	"github.com/nanoteck137/pyrin/anvil"
+	"github.com/nanoteck137/pyrin/ember"
 	"github.com/nanoteck137/validate"
 	"github.com/nanoteck137/watchbook/core"
 	"github.com/nanoteck137/watchbook/database"
@@ -293,6 +295,221 @@ func (b CreateShowBody) Validate() error {
 	)
 }
 
+type BuildShowFromChainBody struct {
+	// NOTE(patrik): The provider to walk the relations on, defaults to the
+	// default provider of the media
+	ProviderName string `json:"providerName,omitempty"`
+	// NOTE(patrik): Defaults to the title of the first entry
+	Name string `json:"name,omitempty"`
+}
+
+func (b *BuildShowFromChainBody) Transform() {
+	b.ProviderName = anvil.String(b.ProviderName)
+	b.Name = anvil.String(b.Name)
+}
+
+type BuildShowFromChain struct {
+	JobId string `json:"jobId"`
+}
+
+// NOTE(patrik): Max number of entries to walk when building a show from a
+// sequel chain
+const maxShowChainEntries = 50
+
+// NOTE(patrik): Walks the sequel/prequel relations starting at the id and
+// returns every entry in the chain sorted by the start date
+func walkSequelChain(ctx context.Context, pm *provider.ProviderManager, providerName, providerId string) ([]provider.Media, error) {
+	visited := map[string]bool{providerId: true}
+	queue := []string{providerId}
+
+	var entries []provider.Media
+
+	for len(queue) > 0 && len(entries) < maxShowChainEntries {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		current := queue[0]
+		queue = queue[1:]
+
+		media, err := pm.GetMedia(ctx, providerName, current)
+		if err != nil {
+			// NOTE(patrik): The start needs to exist but the related
+			// entries can be missing
+			if current == providerId || !errors.Is(err, provider.NotFound) {
+				return nil, err
+			}
+
+			continue
+		}
+
+		entries = append(entries, media)
+
+		for _, relation := range media.Relations {
+			if relation.ProviderName != providerName {
+				continue
+			}
+
+			switch relation.Type {
+			case types.MediaRelationTypeSequel, types.MediaRelationTypePrequel:
+			default:
+				continue
+			}
+
+			if visited[relation.ProviderId] {
+				continue
+			}
+
+			visited[relation.ProviderId] = true
+			queue = append(queue, relation.ProviderId)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a := entries[i].StartDate
+		b := entries[j].StartDate
+
+		if a == nil {
+			return false
+		}
+
+		if b == nil {
+			return true
+		}
+
+		return a.Before(*b)
+	})
+
+	return entries, nil
+}
+
+// NOTE(patrik): Creates a show with one season per entry inside the sequel
+// chain of the provider id, the missing entries is imported. Returns the id
+// of the new show.
+func CreateShowFromChain(ctx context.Context, app core.App, providerName, providerId, name string) (string, error) {
+	pm := app.ProviderManager()
+
+	entries, err := walkSequelChain(ctx, pm, providerName, providerId)
+	if err != nil {
+		return "", err
+	}
+
+	if len(entries) == 0 {
+		return "", errors.New("no entries found in the chain")
+	}
+
+	mediaIds := make([]string, len(entries))
+	for i, entry := range entries {
+		mediaId, err := ImportMedia(ctx, app, providerName, entry.ProviderId)
+		if err != nil {
+			return "", fmt.Errorf("failed to import %s (%s): %w", providerName, entry.ProviderId, err)
+		}
+
+		mediaIds[i] = mediaId
+	}
+
+	first := entries[0]
+
+	if name == "" {
+		name = first.Title
+	}
+
+	ty := types.ShowTypeTVSeries
+	switch first.Type {
+	case types.MediaTypeAnimeSeason, types.MediaTypeAnimeMovie:
+		ty = types.ShowTypeAnime
+	}
+
+	id := utils.CreateShowId()
+
+	showDir := app.WorkDir().ShowDirById(id)
+
+	err = showDir.Create()
+	if err != nil {
+		return "", err
+	}
+
+	coverFile := ""
+	bannerFile := ""
+	logoFile := ""
+
+	for _, entry := range entries {
+		if coverFile == "" && entry.CoverUrl != nil {
+			p, err := downloadProviderImage(*entry.CoverUrl, showDir.Images())
+			if err == nil {
+				coverFile = path.Base(p)
+			} else {
+				app.Logger().Error("failed to download cover image for show", "err", err)
+			}
+		}
+
+		if bannerFile == "" && entry.BannerUrl != nil {
+			p, err := downloadProviderImage(*entry.BannerUrl, showDir.Images())
+			if err == nil {
+				bannerFile = path.Base(p)
+			} else {
+				app.Logger().Error("failed to download banner image for show", "err", err)
+			}
+		}
+
+		if logoFile == "" && entry.LogoUrl != nil {
+			p, err := downloadProviderImage(*entry.LogoUrl, showDir.Images())
+			if err == nil {
+				logoFile = path.Base(p)
+			} else {
+				app.Logger().Error("failed to download logo image for show", "err", err)
+			}
+		}
+	}
+
+	_, err = app.DB().CreateShow(ctx, database.CreateShowParams{
+		Id:   id,
+		Type: ty,
+		Name: name,
+		CoverFile: sql.NullString{
+			String: coverFile,
+			Valid:  coverFile != "",
+		},
+		BannerFile: sql.NullString{
+			String: bannerFile,
+			Valid:  bannerFile != "",
+		},
+		LogoFile: sql.NullString{
+			String: logoFile,
+			Valid:  logoFile != "",
+		},
+		Providers: ember.KVStore{},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for i, entry := range entries {
+		num := i + 1
+
+		err := app.DB().CreateShowSeason(ctx, database.CreateShowSeasonParams{
+			Num:    num,
+			ShowId: id,
+			Name:   entry.Title,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		err = app.DB().CreateShowSeasonItem(ctx, database.CreateShowSeasonItemParams{
+			ShowSeasonNum: num,
+			ShowId:        id,
+			MediaId:       mediaIds[i],
+			Position:      0,
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return id, nil
+}
+
 type EditShowBody struct {
 	Type *string `json:"type,omitempty"`
 
@@ -592,6 +809,82 @@ func InstallShowHandlers(app core.App, group pyrin.Group) {
 			},
 		},
 
+		pyrin.ApiHandler{
+			Name:         "BuildShowFromChain",
+			Method:       http.MethodPost,
+			Path:         "/media/:id/build-show",
+			ResponseType: BuildShowFromChain{},
+			BodyType:     BuildShowFromChainBody{},
+			HandlerFunc: func(c pyrin.Context) (any, error) {
+				id := c.Param("id")
+
+				user, err := User(app, c, HasEditPrivilege)
+				if err != nil {
+					return nil, err
+				}
+
+				body, err := pyrin.Body[BuildShowFromChainBody](c)
+				if err != nil {
+					return nil, err
+				}
+
+				ctx := context.Background()
+
+				dbMedia, err := app.DB().GetMediaById(ctx, nil, id)
+				if err != nil {
+					if errors.Is(err, database.ErrItemNotFound) {
+						return nil, MediaNotFound()
+					}
+
+					return nil, err
+				}
+
+				providerName := body.ProviderName
+				if providerName == "" {
+					providerName = dbMedia.DefaultProvider.String
+				}
+
+				providerId, ok := dbMedia.Providers[providerName]
+				if !ok {
+					// TODO(patrik): Better error
+					return nil, errors.New("provider not found on media")
+				}
+
+				store := ember.KVStore{
+					"providerName": providerName,
+					"providerId":   providerId,
+					"name":         body.Name,
+				}
+
+				payload, err := store.Serialize()
+				if err != nil {
+					return nil, err
+				}
+
+				jobId, err := createJob(ctx, app, database.CreateJobParams{
+					Type:        JobTypeBuildShowFromChain,
+					Status:      types.JobStatusQueued,
+					Priority:    0,
+					RunAt:       0,
+					Attempts:    0,
+					MaxAttempts: 1,
+					Payload:     payload,
+					Error:       sql.NullString{},
+					UserId: sql.NullString{
+						String: user.Id,
+						Valid:  true,
+					},
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				return BuildShowFromChain{
+					JobId: jobId,
+				}, nil
+			},
+		},
+
 		pyrin.ApiHandler{
 			Name:         "EditShow",
 			Method:       http.MethodPatch,
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/nanoteck137/pyrin"
 	"github.com/nanoteck137/pyrin/anvil"
@@ -27,10 +28,17 @@ type GetUser struct {
 
 type UpdateUserSettingsBody struct {
 	DisplayName *string `json:"displayName,omitempty"`
+	// NOTE(patrik): Empty string removes the region
+	Region *string `json:"region,omitempty"`
 }
 
 func (b *UpdateUserSettingsBody) Transform() {
 	b.DisplayName = anvil.StringPtr(b.DisplayName)
+
+	if b.Region != nil {
+		region := strings.ToUpper(anvil.String(*b.Region))
+		b.Region = &region
+	}
 }
 
 func (b UpdateUserSettingsBody) Validate() error {
@@ -38,6 +46,7 @@ func (b UpdateUserSettingsBody) Validate() error {
 		validate.Field(&b.DisplayName,
 			validate.Required.When(b.DisplayName != nil),
 		),
+		validate.Field(&b.Region, validate.By(types.ValidateRegion)),
 	)
 }
 
@@ -68,6 +77,10 @@ type GetAllApiTokens struct {
 	Tokens []ApiToken `json:"tokens"`
 }
 
+type ImportMalAnimeList struct {
+	JobId string `json:"jobId"`
+}
+
 type Stat struct {
 	Name  string `json:"name"`
 	Value int    `json:"value"`
@@ -148,6 +161,13 @@ func InstallUserHandlers(app core.App, group pyrin.Group) {
 					}
 				}
 
+				if body.Region != nil {
+					settings.Region = sql.NullString{
+						String: *body.Region,
+						Valid:  *body.Region != "",
+					}
+				}
+
 				err = app.DB().UpdateUserSettings(context.TODO(), settings)
 				if err != nil {
 					// TODO(patrik): Handle error
@@ -262,9 +282,10 @@ func InstallUserHandlers(app core.App, group pyrin.Group) {
 		},
 
 		pyrin.ApiHandler{
-			Name:   "ImportMalAnimeList",
-			Method: http.MethodPost,
-			Path:   "/users/import/mal/:username/anime",
+			Name:         "ImportMalAnimeList",
+			Method:       http.MethodPost,
+			Path:         "/users/import/mal/:username/anime",
+			ResponseType: ImportMalAnimeList{},
 			HandlerFunc: func(c pyrin.Context) (any, error) {
 				username := c.Param("username")
 
@@ -283,7 +304,7 @@ func InstallUserHandlers(app core.App, group pyrin.Group) {
 					return nil, err
 				}
 
-				_, err = app.DB().CreateJob(context.Background(), database.CreateJobParams{
+				jobId, err := createJob(context.Background(), app, database.CreateJobParams{
 					Type:        "import-mal-watchlist",
 					Status:      types.JobStatusQueued,
 					Priority:    0,
@@ -292,12 +313,18 @@ func InstallUserHandlers(app core.App, group pyrin.Group) {
 					MaxAttempts: 1,
 					Payload:     payload,
 					Error:       sql.NullString{},
+					UserId: sql.NullString{
+						String: user.Id,
+						Valid:  true,
+					},
 				})
 				if err != nil {
 					return nil, err
 				}
 
-				return nil, nil
+				return ImportMalAnimeList{
+					JobId: jobId,
+				}, nil
 			},
 		},
 
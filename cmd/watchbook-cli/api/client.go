@@ -82,6 +82,54 @@ func (c *Client) AddShowSeasonItem(id string, seasonNum string, body AddShowSeas
 	return Request[any](data, body)
 }
 
+func (c *Client) BackfillMediaProviders(body ResolveMediaProvidersBody, options Options) (*BackfillMediaProviders, error) {
+	path := "/api/v1/providers/backfill"
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "POST",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[BackfillMediaProviders](data, body)
+}
+
+func (c *Client) BuildShowFromChain(id string, body BuildShowFromChainBody, options Options) (*BuildShowFromChain, error) {
+	path := Sprintf("/api/v1/media/%v/build-show", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "POST",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[BuildShowFromChain](data, body)
+}
+
+func (c *Client) CancelJob(id string, options Options) (*any, error) {
+	path := Sprintf("/api/v1/jobs/%v/cancel", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "POST",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[any](data, nil)
+}
+
 func (c *Client) ChangeCollectionImages(id string, boundary string, body Reader, options Options) (*any, error) {
 	path := Sprintf("/api/v1/collections/%v/images", id)
 	url, err := createUrl(c.addr, path, options.Query)
@@ -178,6 +226,22 @@ func (c *Client) CreateFolder(body CreateFolderBody, options Options) (*CreateFo
 	return Request[CreateFolder](data, body)
 }
 
+func (c *Client) CreateJobSchedule(body CreateJobScheduleBody, options Options) (*CreateJobSchedule, error) {
+	path := "/api/v1/job-schedules"
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "POST",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[CreateJobSchedule](data, body)
+}
+
 func (c *Client) CreateMedia(body CreateMediaBody, options Options) (*CreateMedia, error) {
 	path := "/api/v1/media"
 	url, err := createUrl(c.addr, path, options.Query)
@@ -258,6 +322,38 @@ func (c *Client) DeleteFolder(id string, options Options) (*any, error) {
 	return Request[any](data, nil)
 }
 
+func (c *Client) DeleteJob(id string, options Options) (*any, error) {
+	path := Sprintf("/api/v1/jobs/%v", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "DELETE",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[any](data, nil)
+}
+
+func (c *Client) DeleteJobSchedule(id string, options Options) (*any, error) {
+	path := Sprintf("/api/v1/job-schedules/%v", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "DELETE",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[any](data, nil)
+}
+
 func (c *Client) DeleteMedia(id string, options Options) (*any, error) {
 	path := Sprintf("/api/v1/media/%v", id)
 	url, err := createUrl(c.addr, path, options.Query)
@@ -370,6 +466,22 @@ func (c *Client) EditFolder(id string, body EditFolderBody, options Options) (*a
 	return Request[any](data, body)
 }
 
+func (c *Client) EditJobSchedule(id string, body EditJobScheduleBody, options Options) (*any, error) {
+	path := Sprintf("/api/v1/job-schedules/%v", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "PATCH",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[any](data, body)
+}
+
 func (c *Client) EditMedia(id string, body EditMediaBody, options Options) (*any, error) {
 	path := Sprintf("/api/v1/media/%v", id)
 	url, err := createUrl(c.addr, path, options.Query)
@@ -563,6 +675,70 @@ func (c *Client) GetFolders(options Options) (*GetFolders, error) {
 	return Request[GetFolders](data, nil)
 }
 
+func (c *Client) GetJobById(id string, options Options) (*GetJobById, error) {
+	path := Sprintf("/api/v1/jobs/%v", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetJobById](data, nil)
+}
+
+func (c *Client) GetJobScheduleById(id string, options Options) (*GetJobScheduleById, error) {
+	path := Sprintf("/api/v1/job-schedules/%v", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetJobScheduleById](data, nil)
+}
+
+func (c *Client) GetJobSchedules(options Options) (*GetJobSchedules, error) {
+	path := "/api/v1/job-schedules"
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetJobSchedules](data, nil)
+}
+
+func (c *Client) GetJobs(options Options) (*GetJobs, error) {
+	path := "/api/v1/jobs"
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetJobs](data, nil)
+}
+
 func (c *Client) GetMe(options Options) (*GetMe, error) {
 	path := "/api/v1/auth/me"
 	url, err := createUrl(c.addr, path, options.Query)
@@ -595,6 +771,22 @@ func (c *Client) GetMedia(options Options) (*GetMedia, error) {
 	return Request[GetMedia](data, nil)
 }
 
+func (c *Client) GetMediaAvailability(id string, options Options) (*GetMediaAvailability, error) {
+	path := Sprintf("/api/v1/media/%v/availability", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetMediaAvailability](data, nil)
+}
+
 func (c *Client) GetMediaById(id string, options Options) (*GetMediaById, error) {
 	path := Sprintf("/api/v1/media/%v", id)
 	url, err := createUrl(c.addr, path, options.Query)
@@ -612,6 +804,22 @@ func (c *Client) GetMediaById(id string, options Options) (*GetMediaById, error)
 }
 
 
+func (c *Client) GetMediaImages(id string, options Options) (*GetMediaImages, error) {
+	path := Sprintf("/api/v1/media/%v/images", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetMediaImages](data, nil)
+}
+
 func (c *Client) GetMediaParts(id string, options Options) (*GetMediaParts, error) {
 	path := Sprintf("/api/v1/media/%v/parts", id)
 	url, err := createUrl(c.addr, path, options.Query)
@@ -628,6 +836,86 @@ func (c *Client) GetMediaParts(id string, options Options) (*GetMediaParts, erro
 	return Request[GetMediaParts](data, nil)
 }
 
+func (c *Client) GetMediaPeople(id string, options Options) (*GetMediaPeople, error) {
+	path := Sprintf("/api/v1/media/%v/people", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetMediaPeople](data, nil)
+}
+
+func (c *Client) GetMediaRelations(id string, options Options) (*GetMediaRelations, error) {
+	path := Sprintf("/api/v1/media/%v/relations", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetMediaRelations](data, nil)
+}
+
+func (c *Client) GetMissingMediaRelations(options Options) (*GetMissingMediaRelations, error) {
+	path := "/api/v1/media/relations/missing"
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetMissingMediaRelations](data, nil)
+}
+
+func (c *Client) GetMyJobs(options Options) (*GetJobs, error) {
+	path := "/api/v1/user/jobs"
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetJobs](data, nil)
+}
+
+func (c *Client) GetPersonById(id string, options Options) (*GetPersonById, error) {
+	path := Sprintf("/api/v1/people/%v", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetPersonById](data, nil)
+}
+
 func (c *Client) GetProviders(options Options) (*GetProviders, error) {
 	path := "/api/v1/providers"
 	url, err := createUrl(c.addr, path, options.Query)
@@ -773,7 +1061,7 @@ func (c *Client) GetUserStats(id string, options Options) (*GetUserStats, error)
 	return Request[GetUserStats](data, nil)
 }
 
-func (c *Client) ImportMalAnimeList(username string, options Options) (*any, error) {
+func (c *Client) ImportMalAnimeList(username string, options Options) (*ImportMalAnimeList, error) {
 	path := Sprintf("/api/v1/users/import/mal/%v/anime", username)
 	url, err := createUrl(c.addr, path, options.Query)
 	if err != nil {
@@ -786,7 +1074,7 @@ func (c *Client) ImportMalAnimeList(username string, options Options) (*any, err
 		ClientHeaders: c.Headers,
 		Headers: options.Header,
 	}
-	return Request[any](data, nil)
+	return Request[ImportMalAnimeList](data, nil)
 }
 
 func (c *Client) MoveFolderItem(id string, mediaId string, pos string, options Options) (*any, error) {
@@ -805,6 +1093,38 @@ func (c *Client) MoveFolderItem(id string, mediaId string, pos string, options O
 	return Request[any](data, nil)
 }
 
+func (c *Client) PromoteMediaImage(id string, body PromoteMediaImageBody, options Options) (*any, error) {
+	path := Sprintf("/api/v1/media/%v/images/promote", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "POST",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[any](data, body)
+}
+
+func (c *Client) ProviderGetSeasonal(providerName string, season string, year string, options Options) (*GetProviderSeasonal, error) {
+	path := Sprintf("/api/v1/providers/%v/seasonal/%v/%v", providerName, season, year)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetProviderSeasonal](data, nil)
+}
+
 func (c *Client) ProviderImportCollections(providerName string, body PostProviderImportCollectionsBody, options Options) (*any, error) {
 	path := Sprintf("/api/v1/providers/%v/collections/import", providerName)
 	url, err := createUrl(c.addr, path, options.Query)
@@ -837,6 +1157,22 @@ func (c *Client) ProviderImportMedia(providerName string, body PostProviderImpor
 	return Request[any](data, body)
 }
 
+func (c *Client) ProviderImportSeasonal(providerName string, body PostProviderImportSeasonalBody, options Options) (*PostProviderImportSeasonal, error) {
+	path := Sprintf("/api/v1/providers/%v/seasonal/import", providerName)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "POST",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[PostProviderImportSeasonal](data, body)
+}
+
 func (c *Client) ProviderImportShows(providerName string, body PostProviderImportCollectionsBody, options Options) (*any, error) {
 	path := Sprintf("/api/v1/providers/%v/shows/import", providerName)
 	url, err := createUrl(c.addr, path, options.Query)
@@ -853,6 +1189,38 @@ func (c *Client) ProviderImportShows(providerName string, body PostProviderImpor
 	return Request[any](data, body)
 }
 
+func (c *Client) ProviderMultiSearchMedia(options Options) (*GetProviderMultiSearch, error) {
+	path := "/api/v1/providers/search/media"
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "GET",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[GetProviderMultiSearch](data, nil)
+}
+
+func (c *Client) ProviderResolveLinks(body PostProviderResolveLinksBody, options Options) (*PostProviderResolveLinks, error) {
+	path := "/api/v1/providers/links"
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "POST",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[PostProviderResolveLinks](data, body)
+}
+
 func (c *Client) ProviderSearchCollections(providerName string, options Options) (*GetProviderSearch, error) {
 	path := Sprintf("/api/v1/providers/%v/collections", providerName)
 	url, err := createUrl(c.addr, path, options.Query)
@@ -869,7 +1237,7 @@ func (c *Client) ProviderSearchCollections(providerName string, options Options)
 	return Request[GetProviderSearch](data, nil)
 }
 
-func (c *Client) ProviderSearchMedia(providerName string, options Options) (*GetProviderSearch, error) {
+func (c *Client) ProviderSearchMedia(providerName string, options Options) (*GetProviderSearchMedia, error) {
 	path := Sprintf("/api/v1/providers/%v/media", providerName)
 	url, err := createUrl(c.addr, path, options.Query)
 	if err != nil {
@@ -882,7 +1250,7 @@ func (c *Client) ProviderSearchMedia(providerName string, options Options) (*Get
 		ClientHeaders: c.Headers,
 		Headers: options.Header,
 	}
-	return Request[GetProviderSearch](data, nil)
+	return Request[GetProviderSearchMedia](data, nil)
 }
 
 func (c *Client) ProviderSearchShows(providerName string, options Options) (*GetProviderSearch, error) {
@@ -933,6 +1301,22 @@ func (c *Client) ProviderUpdateMedia(providerName string, mediaId string, body P
 	return Request[any](data, body)
 }
 
+func (c *Client) ProviderUpdateShow(providerName string, showId string, body ProviderCollectionUpdateBody, options Options) (*any, error) {
+	path := Sprintf("/api/v1/providers/%v/collections/%v", providerName, showId)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "PATCH",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[any](data, body)
+}
+
 func (c *Client) ProviderUpdateUnknownMedia(options Options) (*any, error) {
 	path := "/api/v1/providers/updateUnknownMedia"
 	url, err := createUrl(c.addr, path, options.Query)
@@ -949,6 +1333,22 @@ func (c *Client) ProviderUpdateUnknownMedia(options Options) (*any, error) {
 	return Request[any](data, nil)
 }
 
+func (c *Client) RefreshMediaImages(id string, options Options) (*RefreshMediaImages, error) {
+	path := Sprintf("/api/v1/media/%v/images/refresh", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "POST",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[RefreshMediaImages](data, nil)
+}
+
 func (c *Client) RemoveCollectionItem(id string, mediaId string, options Options) (*any, error) {
 	path := Sprintf("/api/v1/collections/%v/items/%v", id, mediaId)
 	url, err := createUrl(c.addr, path, options.Query)
@@ -981,6 +1381,22 @@ func (c *Client) RemoveFolderItem(id string, mediaId string, options Options) (*
 	return Request[any](data, nil)
 }
 
+func (c *Client) RemoveMediaImage(id string, file string, options Options) (*any, error) {
+	path := Sprintf("/api/v1/media/%v/images/%v", id, file)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "DELETE",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[any](data, nil)
+}
+
 func (c *Client) RemovePart(id string, index string, options Options) (*any, error) {
 	path := Sprintf("/api/v1/media/%v/parts/%v", id, index)
 	url, err := createUrl(c.addr, path, options.Query)
@@ -1029,6 +1445,54 @@ func (c *Client) RemoveShowSeasonItem(id string, seasonNum string, mediaId strin
 	return Request[any](data, nil)
 }
 
+func (c *Client) ResolveMediaProviders(id string, body ResolveMediaProvidersBody, options Options) (*ResolveMediaProviders, error) {
+	path := Sprintf("/api/v1/media/%v/providers/resolve", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "POST",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[ResolveMediaProviders](data, body)
+}
+
+func (c *Client) RetryJob(id string, options Options) (*any, error) {
+	path := Sprintf("/api/v1/jobs/%v/retry", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "POST",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[any](data, nil)
+}
+
+func (c *Client) RunJobSchedule(id string, options Options) (*RunJobSchedule, error) {
+	path := Sprintf("/api/v1/job-schedules/%v/run", id)
+	url, err := createUrl(c.addr, path, options.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := RequestData{
+		Url: url,
+		Method: "POST",
+		ClientHeaders: c.Headers,
+		Headers: options.Header,
+	}
+	return Request[RunJobSchedule](data, nil)
+}
+
 func (c *Client) SetMediaRelease(id string, body SetMediaReleaseBody, options Options) (*any, error) {
 	path := Sprintf("/api/v1/media/%v/release", id)
 	url, err := createUrl(c.addr, path, options.Query)
@@ -1150,6 +1614,21 @@ func (c *ClientUrls) AddShowSeasonItem(id string, seasonNum string) (*URL, error
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) BackfillMediaProviders() (*URL, error) {
+	path := "/api/v1/providers/backfill"
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) BuildShowFromChain(id string) (*URL, error) {
+	path := Sprintf("/api/v1/media/%v/build-show", id)
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) CancelJob(id string) (*URL, error) {
+	path := Sprintf("/api/v1/jobs/%v/cancel", id)
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) ChangeCollectionImages(id string) (*URL, error) {
 	path := Sprintf("/api/v1/collections/%v/images", id)
 	return c.getUrl(path)
@@ -1180,6 +1659,11 @@ func (c *ClientUrls) CreateFolder() (*URL, error) {
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) CreateJobSchedule() (*URL, error) {
+	path := "/api/v1/job-schedules"
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) CreateMedia() (*URL, error) {
 	path := "/api/v1/media"
 	return c.getUrl(path)
@@ -1205,6 +1689,16 @@ func (c *ClientUrls) DeleteFolder(id string) (*URL, error) {
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) DeleteJob(id string) (*URL, error) {
+	path := Sprintf("/api/v1/jobs/%v", id)
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) DeleteJobSchedule(id string) (*URL, error) {
+	path := Sprintf("/api/v1/job-schedules/%v", id)
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) DeleteMedia(id string) (*URL, error) {
 	path := Sprintf("/api/v1/media/%v", id)
 	return c.getUrl(path)
@@ -1240,6 +1734,11 @@ func (c *ClientUrls) EditFolder(id string) (*URL, error) {
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) EditJobSchedule(id string) (*URL, error) {
+	path := Sprintf("/api/v1/job-schedules/%v", id)
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) EditMedia(id string) (*URL, error) {
 	path := Sprintf("/api/v1/media/%v", id)
 	return c.getUrl(path)
@@ -1305,6 +1804,26 @@ func (c *ClientUrls) GetFolders() (*URL, error) {
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) GetJobById(id string) (*URL, error) {
+	path := Sprintf("/api/v1/jobs/%v", id)
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) GetJobScheduleById(id string) (*URL, error) {
+	path := Sprintf("/api/v1/job-schedules/%v", id)
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) GetJobSchedules() (*URL, error) {
+	path := "/api/v1/job-schedules"
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) GetJobs() (*URL, error) {
+	path := "/api/v1/jobs"
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) GetMe() (*URL, error) {
 	path := "/api/v1/auth/me"
 	return c.getUrl(path)
@@ -1315,6 +1834,11 @@ func (c *ClientUrls) GetMedia() (*URL, error) {
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) GetMediaAvailability(id string) (*URL, error) {
+	path := Sprintf("/api/v1/media/%v/availability", id)
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) GetMediaById(id string) (*URL, error) {
 	path := Sprintf("/api/v1/media/%v", id)
 	return c.getUrl(path)
@@ -1325,11 +1849,41 @@ func (c *ClientUrls) GetMediaImage(id string, file string) (*URL, error) {
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) GetMediaImages(id string) (*URL, error) {
+	path := Sprintf("/api/v1/media/%v/images", id)
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) GetMediaParts(id string) (*URL, error) {
 	path := Sprintf("/api/v1/media/%v/parts", id)
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) GetMediaPeople(id string) (*URL, error) {
+	path := Sprintf("/api/v1/media/%v/people", id)
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) GetMediaRelations(id string) (*URL, error) {
+	path := Sprintf("/api/v1/media/%v/relations", id)
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) GetMissingMediaRelations() (*URL, error) {
+	path := "/api/v1/media/relations/missing"
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) GetMyJobs() (*URL, error) {
+	path := "/api/v1/user/jobs"
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) GetPersonById(id string) (*URL, error) {
+	path := Sprintf("/api/v1/people/%v", id)
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) GetProviders() (*URL, error) {
 	path := "/api/v1/providers"
 	return c.getUrl(path)
@@ -1390,6 +1944,16 @@ func (c *ClientUrls) MoveFolderItem(id string, mediaId string, pos string) (*URL
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) PromoteMediaImage(id string) (*URL, error) {
+	path := Sprintf("/api/v1/media/%v/images/promote", id)
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) ProviderGetSeasonal(providerName string, season string, year string) (*URL, error) {
+	path := Sprintf("/api/v1/providers/%v/seasonal/%v/%v", providerName, season, year)
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) ProviderImportCollections(providerName string) (*URL, error) {
 	path := Sprintf("/api/v1/providers/%v/collections/import", providerName)
 	return c.getUrl(path)
@@ -1400,11 +1964,26 @@ func (c *ClientUrls) ProviderImportMedia(providerName string) (*URL, error) {
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) ProviderImportSeasonal(providerName string) (*URL, error) {
+	path := Sprintf("/api/v1/providers/%v/seasonal/import", providerName)
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) ProviderImportShows(providerName string) (*URL, error) {
 	path := Sprintf("/api/v1/providers/%v/shows/import", providerName)
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) ProviderMultiSearchMedia() (*URL, error) {
+	path := "/api/v1/providers/search/media"
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) ProviderResolveLinks() (*URL, error) {
+	path := "/api/v1/providers/links"
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) ProviderSearchCollections(providerName string) (*URL, error) {
 	path := Sprintf("/api/v1/providers/%v/collections", providerName)
 	return c.getUrl(path)
@@ -1430,11 +2009,21 @@ func (c *ClientUrls) ProviderUpdateMedia(providerName string, mediaId string) (*
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) ProviderUpdateShow(providerName string, showId string) (*URL, error) {
+	path := Sprintf("/api/v1/providers/%v/collections/%v", providerName, showId)
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) ProviderUpdateUnknownMedia() (*URL, error) {
 	path := "/api/v1/providers/updateUnknownMedia"
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) RefreshMediaImages(id string) (*URL, error) {
+	path := Sprintf("/api/v1/media/%v/images/refresh", id)
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) RemoveCollectionItem(id string, mediaId string) (*URL, error) {
 	path := Sprintf("/api/v1/collections/%v/items/%v", id, mediaId)
 	return c.getUrl(path)
@@ -1445,6 +2034,11 @@ func (c *ClientUrls) RemoveFolderItem(id string, mediaId string) (*URL, error) {
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) RemoveMediaImage(id string, file string) (*URL, error) {
+	path := Sprintf("/api/v1/media/%v/images/%v", id, file)
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) RemovePart(id string, index string) (*URL, error) {
 	path := Sprintf("/api/v1/media/%v/parts/%v", id, index)
 	return c.getUrl(path)
@@ -1460,6 +2054,21 @@ func (c *ClientUrls) RemoveShowSeasonItem(id string, seasonNum string, mediaId s
 	return c.getUrl(path)
 }
 
+func (c *ClientUrls) ResolveMediaProviders(id string) (*URL, error) {
+	path := Sprintf("/api/v1/media/%v/providers/resolve", id)
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) RetryJob(id string) (*URL, error) {
+	path := Sprintf("/api/v1/jobs/%v/retry", id)
+	return c.getUrl(path)
+}
+
+func (c *ClientUrls) RunJobSchedule(id string) (*URL, error) {
+	path := Sprintf("/api/v1/job-schedules/%v/run", id)
+	return c.getUrl(path)
+}
+
 func (c *ClientUrls) SetMediaRelease(id string) (*URL, error) {
 	path := Sprintf("/api/v1/media/%v/release", id)
 	return c.getUrl(path)
@@ -55,6 +55,26 @@ type ApiToken struct {
 	Name string `json:"name"`
 }
 
+// Name: BackfillMediaProviders
+type BackfillMediaProviders struct {
+	// Name: BackfillMediaProviders.jobId
+	JobId string `json:"jobId"`
+}
+
+// Name: BuildShowFromChain
+type BuildShowFromChain struct {
+	// Name: BuildShowFromChain.jobId
+	JobId string `json:"jobId"`
+}
+
+// Name: BuildShowFromChainBody
+type BuildShowFromChainBody struct {
+	// Name: BuildShowFromChainBody.providerName
+	ProviderName string `json:"providerName"`
+	// Name: BuildShowFromChainBody.name
+	Name string `json:"name"`
+}
+
 // Name: ChangePasswordBody
 type ChangePasswordBody struct {
 	// Name: ChangePasswordBody.currentPassword
@@ -203,6 +223,38 @@ type CreateFolderBody struct {
 	CoverUrl string `json:"coverUrl"`
 }
 
+// Name: CreateJobSchedule
+type CreateJobSchedule struct {
+	// Name: CreateJobSchedule.id
+	Id string `json:"id"`
+}
+
+// Name: JobPayloadValue
+type JobPayloadValue struct {
+	// Name: JobPayloadValue.key
+	Key string `json:"key"`
+	// Name: JobPayloadValue.value
+	Value string `json:"value"`
+}
+
+// Name: CreateJobScheduleBody
+type CreateJobScheduleBody struct {
+	// Name: CreateJobScheduleBody.name
+	Name string `json:"name"`
+	// Name: CreateJobScheduleBody.type
+	Type string `json:"type"`
+	// Name: CreateJobScheduleBody.cron
+	Cron string `json:"cron"`
+	// Name: CreateJobScheduleBody.interval
+	Interval string `json:"interval"`
+	// Name: CreateJobScheduleBody.payload
+	Payload []JobPayloadValue `json:"payload"`
+	// Name: CreateJobScheduleBody.maxAttempts
+	MaxAttempts int `json:"maxAttempts"`
+	// Name: CreateJobScheduleBody.enabled
+	Enabled bool `json:"enabled"`
+}
+
 // Name: CreateMedia
 type CreateMedia struct {
 	// Name: CreateMedia.id
@@ -295,6 +347,24 @@ type EditFolderBody struct {
 	CoverUrl *string `json:"coverUrl,omitempty"`
 }
 
+// Name: EditJobScheduleBody
+type EditJobScheduleBody struct {
+	// Name: EditJobScheduleBody.name
+	Name *string `json:"name,omitempty"`
+	// Name: EditJobScheduleBody.type
+	Type *string `json:"type,omitempty"`
+	// Name: EditJobScheduleBody.cron
+	Cron *string `json:"cron,omitempty"`
+	// Name: EditJobScheduleBody.interval
+	Interval *string `json:"interval,omitempty"`
+	// Name: EditJobScheduleBody.payload
+	Payload *[]JobPayloadValue `json:"payload,omitempty"`
+	// Name: EditJobScheduleBody.maxAttempts
+	MaxAttempts *int `json:"maxAttempts,omitempty"`
+	// Name: EditJobScheduleBody.enabled
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
 // Name: EditMediaBody
 type EditMediaBody struct {
 	// Name: EditMediaBody.type
@@ -499,6 +569,152 @@ type GetFolders struct {
 	Folders []Folder `json:"folders"`
 }
 
+// Name: GetJobById
+type GetJobById struct {
+	// Name: GetJobById.id
+	Id string `json:"id"`
+	// Name: GetJobById.type
+	Type string `json:"type"`
+	// Name: GetJobById.status
+	Status string `json:"status"`
+	// Name: GetJobById.priority
+	Priority int `json:"priority"`
+	// Name: GetJobById.runAt
+	RunAt int `json:"runAt"`
+	// Name: GetJobById.attempts
+	Attempts int `json:"attempts"`
+	// Name: GetJobById.maxAttempts
+	MaxAttempts int `json:"maxAttempts"`
+	// Name: GetJobById.payload
+	Payload []JobPayloadValue `json:"payload"`
+	// Name: GetJobById.error
+	Error *string `json:"error,omitempty"`
+	// Name: GetJobById.userId
+	UserId *string `json:"userId,omitempty"`
+	// Name: GetJobById.workerId
+	WorkerId *string `json:"workerId,omitempty"`
+	// Name: GetJobById.heartbeat
+	Heartbeat *int `json:"heartbeat,omitempty"`
+	// Name: GetJobById.leaseExpires
+	LeaseExpires *int `json:"leaseExpires,omitempty"`
+	// Name: GetJobById.created
+	Created int `json:"created"`
+	// Name: GetJobById.updated
+	Updated int `json:"updated"`
+}
+
+// Name: GetJobScheduleById
+type GetJobScheduleById struct {
+	// Name: GetJobScheduleById.id
+	Id string `json:"id"`
+	// Name: GetJobScheduleById.name
+	Name string `json:"name"`
+	// Name: GetJobScheduleById.type
+	Type string `json:"type"`
+	// Name: GetJobScheduleById.payload
+	Payload []JobPayloadValue `json:"payload"`
+	// Name: GetJobScheduleById.maxAttempts
+	MaxAttempts int `json:"maxAttempts"`
+	// Name: GetJobScheduleById.cron
+	Cron *string `json:"cron,omitempty"`
+	// Name: GetJobScheduleById.interval
+	Interval *string `json:"interval,omitempty"`
+	// Name: GetJobScheduleById.enabled
+	Enabled bool `json:"enabled"`
+	// Name: GetJobScheduleById.fromConfig
+	FromConfig bool `json:"fromConfig"`
+	// Name: GetJobScheduleById.lastRun
+	LastRun *int `json:"lastRun,omitempty"`
+	// Name: GetJobScheduleById.nextRun
+	NextRun *int `json:"nextRun,omitempty"`
+	// Name: GetJobScheduleById.lastJobId
+	LastJobId *string `json:"lastJobId,omitempty"`
+	// Name: GetJobScheduleById.created
+	Created int `json:"created"`
+	// Name: GetJobScheduleById.updated
+	Updated int `json:"updated"`
+}
+
+// Name: JobSchedule
+type JobSchedule struct {
+	// Name: JobSchedule.id
+	Id string `json:"id"`
+	// Name: JobSchedule.name
+	Name string `json:"name"`
+	// Name: JobSchedule.type
+	Type string `json:"type"`
+	// Name: JobSchedule.payload
+	Payload []JobPayloadValue `json:"payload"`
+	// Name: JobSchedule.maxAttempts
+	MaxAttempts int `json:"maxAttempts"`
+	// Name: JobSchedule.cron
+	Cron *string `json:"cron,omitempty"`
+	// Name: JobSchedule.interval
+	Interval *string `json:"interval,omitempty"`
+	// Name: JobSchedule.enabled
+	Enabled bool `json:"enabled"`
+	// Name: JobSchedule.fromConfig
+	FromConfig bool `json:"fromConfig"`
+	// Name: JobSchedule.lastRun
+	LastRun *int `json:"lastRun,omitempty"`
+	// Name: JobSchedule.nextRun
+	NextRun *int `json:"nextRun,omitempty"`
+	// Name: JobSchedule.lastJobId
+	LastJobId *string `json:"lastJobId,omitempty"`
+	// Name: JobSchedule.created
+	Created int `json:"created"`
+	// Name: JobSchedule.updated
+	Updated int `json:"updated"`
+}
+
+// Name: GetJobSchedules
+type GetJobSchedules struct {
+	// Name: GetJobSchedules.schedules
+	Schedules []JobSchedule `json:"schedules"`
+}
+
+// Name: Job
+type Job struct {
+	// Name: Job.id
+	Id string `json:"id"`
+	// Name: Job.type
+	Type string `json:"type"`
+	// Name: Job.status
+	Status string `json:"status"`
+	// Name: Job.priority
+	Priority int `json:"priority"`
+	// Name: Job.runAt
+	RunAt int `json:"runAt"`
+	// Name: Job.attempts
+	Attempts int `json:"attempts"`
+	// Name: Job.maxAttempts
+	MaxAttempts int `json:"maxAttempts"`
+	// Name: Job.payload
+	Payload []JobPayloadValue `json:"payload"`
+	// Name: Job.error
+	Error *string `json:"error,omitempty"`
+	// Name: Job.userId
+	UserId *string `json:"userId,omitempty"`
+	// Name: Job.workerId
+	WorkerId *string `json:"workerId,omitempty"`
+	// Name: Job.heartbeat
+	Heartbeat *int `json:"heartbeat,omitempty"`
+	// Name: Job.leaseExpires
+	LeaseExpires *int `json:"leaseExpires,omitempty"`
+	// Name: Job.created
+	Created int `json:"created"`
+	// Name: Job.updated
+	Updated int `json:"updated"`
+}
+
+// Name: GetJobs
+type GetJobs struct {
+	// Name: GetJobs.page
+	Page Page `json:"page"`
+	// Name: GetJobs.jobs
+	Jobs []Job `json:"jobs"`
+}
+
 // Name: GetMe
 type GetMe struct {
 	// Name: GetMe.id
@@ -509,6 +725,18 @@ type GetMe struct {
 	Role string `json:"role"`
 	// Name: GetMe.displayName
 	DisplayName string `json:"displayName"`
+	// Name: GetMe.region
+	Region *string `json:"region,omitempty"`
+}
+
+// Name: MediaFieldSource
+type MediaFieldSource struct {
+	// Name: MediaFieldSource.field
+	Field string `json:"field"`
+	// Name: MediaFieldSource.providerName
+	ProviderName string `json:"providerName"`
+	// Name: MediaFieldSource.providerDisplayName
+	ProviderDisplayName string `json:"providerDisplayName"`
 }
 
 // Name: MediaRelease
@@ -571,6 +799,8 @@ type Media struct {
 	DefaultProvider *string `json:"defaultProvider,omitempty"`
 	// Name: Media.providers
 	Providers []ProviderValue `json:"providers"`
+	// Name: Media.fieldSources
+	FieldSources []MediaFieldSource `json:"fieldSources"`
 	// Name: Media.user
 	User *MediaUser `json:"user,omitempty"`
 	// Name: Media.release
@@ -585,6 +815,60 @@ type GetMedia struct {
 	Media []Media `json:"media"`
 }
 
+// Name: MediaAvailability
+type MediaAvailability struct {
+	// Name: MediaAvailability.region
+	Region string `json:"region"`
+	// Name: MediaAvailability.type
+	Type string `json:"type"`
+	// Name: MediaAvailability.service
+	Service string `json:"service"`
+	// Name: MediaAvailability.serviceName
+	ServiceName string `json:"serviceName"`
+	// Name: MediaAvailability.providerName
+	ProviderName string `json:"providerName"`
+}
+
+// Name: GetMediaAvailability
+type GetMediaAvailability struct {
+	// Name: GetMediaAvailability.region
+	Region string `json:"region"`
+	// Name: GetMediaAvailability.availability
+	Availability []MediaAvailability `json:"availability"`
+}
+
+// Name: MediaThemeSong
+type MediaThemeSong struct {
+	// Name: MediaThemeSong.type
+	Type string `json:"type"`
+	// Name: MediaThemeSong.index
+	Index int `json:"index"`
+	// Name: MediaThemeSong.name
+	Name string `json:"name"`
+	// Name: MediaThemeSong.artist
+	Artist string `json:"artist"`
+}
+
+// Name: MediaVideo
+type MediaVideo struct {
+	// Name: MediaVideo.site
+	Site string `json:"site"`
+	// Name: MediaVideo.key
+	Key string `json:"key"`
+	// Name: MediaVideo.type
+	Type string `json:"type"`
+	// Name: MediaVideo.name
+	Name string `json:"name"`
+	// Name: MediaVideo.language
+	Language string `json:"language"`
+	// Name: MediaVideo.official
+	Official bool `json:"official"`
+	// Name: MediaVideo.url
+	Url string `json:"url"`
+	// Name: MediaVideo.embedUrl
+	EmbedUrl string `json:"embedUrl"`
+}
+
 // Name: GetMediaById
 type GetMediaById struct {
 	// Name: GetMediaById.id
@@ -623,10 +907,42 @@ type GetMediaById struct {
 	DefaultProvider *string `json:"defaultProvider,omitempty"`
 	// Name: GetMediaById.providers
 	Providers []ProviderValue `json:"providers"`
+	// Name: GetMediaById.fieldSources
+	FieldSources []MediaFieldSource `json:"fieldSources"`
 	// Name: GetMediaById.user
 	User *MediaUser `json:"user,omitempty"`
 	// Name: GetMediaById.release
 	Release *MediaRelease `json:"release,omitempty"`
+	// Name: GetMediaById.themeSongs
+	ThemeSongs []MediaThemeSong `json:"themeSongs"`
+	// Name: GetMediaById.videos
+	Videos []MediaVideo `json:"videos"`
+}
+
+// Name: MediaImage
+type MediaImage struct {
+	// Name: MediaImage.file
+	File string `json:"file"`
+	// Name: MediaImage.type
+	Type string `json:"type"`
+	// Name: MediaImage.providerName
+	ProviderName string `json:"providerName"`
+	// Name: MediaImage.providerDisplayName
+	ProviderDisplayName string `json:"providerDisplayName"`
+	// Name: MediaImage.url
+	Url string `json:"url"`
+	// Name: MediaImage.isCover
+	IsCover bool `json:"isCover"`
+	// Name: MediaImage.isBanner
+	IsBanner bool `json:"isBanner"`
+	// Name: MediaImage.isLogo
+	IsLogo bool `json:"isLogo"`
+}
+
+// Name: GetMediaImages
+type GetMediaImages struct {
+	// Name: GetMediaImages.images
+	Images []MediaImage `json:"images"`
 }
 
 // Name: MediaPart
@@ -647,6 +963,162 @@ type GetMediaParts struct {
 	Parts []MediaPart `json:"parts"`
 }
 
+// Name: MediaPerson
+type MediaPerson struct {
+	// Name: MediaPerson.personId
+	PersonId string `json:"personId"`
+	// Name: MediaPerson.personType
+	PersonType string `json:"personType"`
+	// Name: MediaPerson.name
+	Name string `json:"name"`
+	// Name: MediaPerson.role
+	Role string `json:"role"`
+	// Name: MediaPerson.character
+	Character string `json:"character"`
+}
+
+// Name: GetMediaPeople
+type GetMediaPeople struct {
+	// Name: GetMediaPeople.people
+	People []MediaPerson `json:"people"`
+}
+
+// Name: MediaRelation
+type MediaRelation struct {
+	// Name: MediaRelation.type
+	Type string `json:"type"`
+	// Name: MediaRelation.providerName
+	ProviderName string `json:"providerName"`
+	// Name: MediaRelation.providerDisplayName
+	ProviderDisplayName string `json:"providerDisplayName"`
+	// Name: MediaRelation.providerId
+	ProviderId string `json:"providerId"`
+	// Name: MediaRelation.title
+	Title string `json:"title"`
+	// Name: MediaRelation.media
+	Media *Media `json:"media,omitempty"`
+}
+
+// Name: GetMediaRelations
+type GetMediaRelations struct {
+	// Name: GetMediaRelations.relations
+	Relations []MediaRelation `json:"relations"`
+}
+
+// Name: MissingMediaRelationSource
+type MissingMediaRelationSource struct {
+	// Name: MissingMediaRelationSource.type
+	Type string `json:"type"`
+	// Name: MissingMediaRelationSource.mediaId
+	MediaId string `json:"mediaId"`
+	// Name: MissingMediaRelationSource.mediaTitle
+	MediaTitle string `json:"mediaTitle"`
+}
+
+// Name: MissingMediaRelation
+type MissingMediaRelation struct {
+	// Name: MissingMediaRelation.providerName
+	ProviderName string `json:"providerName"`
+	// Name: MissingMediaRelation.providerDisplayName
+	ProviderDisplayName string `json:"providerDisplayName"`
+	// Name: MissingMediaRelation.providerId
+	ProviderId string `json:"providerId"`
+	// Name: MissingMediaRelation.title
+	Title string `json:"title"`
+	// Name: MissingMediaRelation.canImport
+	CanImport bool `json:"canImport"`
+	// Name: MissingMediaRelation.sources
+	Sources []MissingMediaRelationSource `json:"sources"`
+}
+
+// Name: GetMissingMediaRelations
+type GetMissingMediaRelations struct {
+	// Name: GetMissingMediaRelations.relations
+	Relations []MissingMediaRelation `json:"relations"`
+}
+
+// Name: PersonCredit
+type PersonCredit struct {
+	// Name: PersonCredit.role
+	Role string `json:"role"`
+	// Name: PersonCredit.character
+	Character string `json:"character"`
+}
+
+// Name: PersonMedia
+type PersonMedia struct {
+	// Name: PersonMedia.media
+	Media Media `json:"media"`
+	// Name: PersonMedia.credits
+	Credits []PersonCredit `json:"credits"`
+}
+
+// Name: GetPersonById
+type GetPersonById struct {
+	// Name: GetPersonById.id
+	Id string `json:"id"`
+	// Name: GetPersonById.type
+	Type string `json:"type"`
+	// Name: GetPersonById.name
+	Name string `json:"name"`
+	// Name: GetPersonById.providers
+	Providers []ProviderValue `json:"providers"`
+	// Name: GetPersonById.media
+	Media []PersonMedia `json:"media"`
+}
+
+// Name: ProviderMultiSearchItem
+type ProviderMultiSearchItem struct {
+	// Name: ProviderMultiSearchItem.providerName
+	ProviderName string `json:"providerName"`
+	// Name: ProviderMultiSearchItem.providerDisplayName
+	ProviderDisplayName string `json:"providerDisplayName"`
+	// Name: ProviderMultiSearchItem.providerId
+	ProviderId string `json:"providerId"`
+	// Name: ProviderMultiSearchItem.title
+	Title string `json:"title"`
+	// Name: ProviderMultiSearchItem.imageUrl
+	ImageUrl string `json:"imageUrl"`
+	// Name: ProviderMultiSearchItem.mediaType
+	MediaType string `json:"mediaType"`
+	// Name: ProviderMultiSearchItem.year
+	Year *int `json:"year,omitempty"`
+	// Name: ProviderMultiSearchItem.mediaId
+	MediaId *string `json:"mediaId,omitempty"`
+}
+
+// Name: ProviderMultiSearchResult
+type ProviderMultiSearchResult struct {
+	// Name: ProviderMultiSearchResult.title
+	Title string `json:"title"`
+	// Name: ProviderMultiSearchResult.imageUrl
+	ImageUrl string `json:"imageUrl"`
+	// Name: ProviderMultiSearchResult.mediaType
+	MediaType string `json:"mediaType"`
+	// Name: ProviderMultiSearchResult.year
+	Year *int `json:"year,omitempty"`
+	// Name: ProviderMultiSearchResult.mediaId
+	MediaId *string `json:"mediaId,omitempty"`
+	// Name: ProviderMultiSearchResult.items
+	Items []ProviderMultiSearchItem `json:"items"`
+}
+
+// Name: ProviderSearchError
+type ProviderSearchError struct {
+	// Name: ProviderSearchError.providerName
+	ProviderName string `json:"providerName"`
+	// Name: ProviderSearchError.message
+	Message string `json:"message"`
+}
+
+// Name: GetProviderMultiSearch
+type GetProviderMultiSearch struct {
+	// Name: GetProviderMultiSearch.results
+	Results []ProviderMultiSearchResult `json:"results"`
+	// Name: GetProviderMultiSearch.errors
+	Errors []ProviderSearchError `json:"errors"`
+}
+
 // Name: ProviderSearchResult
 type ProviderSearchResult struct {
 	// Name: ProviderSearchResult.providerName
@@ -657,6 +1129,10 @@ type ProviderSearchResult struct {
 	Title string `json:"title"`
 	// Name: ProviderSearchResult.imageUrl
 	ImageUrl string `json:"imageUrl"`
+	// Name: ProviderSearchResult.mediaType
+	MediaType string `json:"mediaType"`
+	// Name: ProviderSearchResult.year
+	Year int `json:"year"`
 }
 
 // Name: GetProviderSearch
@@ -665,6 +1141,50 @@ type GetProviderSearch struct {
 	SearchResults []ProviderSearchResult `json:"searchResults"`
 }
 
+// Name: ProviderSearchPage
+type ProviderSearchPage struct {
+	// Name: ProviderSearchPage.page
+	Page int `json:"page"`
+	// Name: ProviderSearchPage.totalPages
+	TotalPages int `json:"totalPages"`
+	// Name: ProviderSearchPage.hasNextPage
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// Name: GetProviderSearchMedia
+type GetProviderSearchMedia struct {
+	// Name: GetProviderSearchMedia.page
+	Page ProviderSearchPage `json:"page"`
+	// Name: GetProviderSearchMedia.searchResults
+	SearchResults []ProviderSearchResult `json:"searchResults"`
+}
+
+// Name: ProviderSeasonalEntry
+type ProviderSeasonalEntry struct {
+	// Name: ProviderSeasonalEntry.providerId
+	ProviderId string `json:"providerId"`
+	// Name: ProviderSeasonalEntry.title
+	Title string `json:"title"`
+	// Name: ProviderSeasonalEntry.imageUrl
+	ImageUrl string `json:"imageUrl"`
+	// Name: ProviderSeasonalEntry.mediaType
+	MediaType string `json:"mediaType"`
+	// Name: ProviderSeasonalEntry.year
+	Year *int `json:"year,omitempty"`
+	// Name: ProviderSeasonalEntry.mediaId
+	MediaId *string `json:"mediaId,omitempty"`
+}
+
+// Name: GetProviderSeasonal
+type GetProviderSeasonal struct {
+	// Name: GetProviderSeasonal.season
+	Season string `json:"season"`
+	// Name: GetProviderSeasonal.year
+	Year int `json:"year"`
+	// Name: GetProviderSeasonal.entries
+	Entries []ProviderSeasonalEntry `json:"entries"`
+}
+
 // Name: ProviderSupports
 type ProviderSupports struct {
 	// Name: ProviderSupports.getMedia
@@ -685,6 +1205,10 @@ type Provider struct {
 	DisplayName string `json:"displayName"`
 	// Name: Provider.supports
 	Supports ProviderSupports `json:"supports"`
+	// Name: Provider.disabled
+	Disabled bool `json:"disabled"`
+	// Name: Provider.disabledReason
+	DisabledReason *string `json:"disabledReason,omitempty"`
 }
 
 // Name: GetProviders
@@ -879,6 +1403,20 @@ type GetUserStats struct {
 	Backlog MainStat `json:"backlog"`
 }
 
+// Name: ImportMalAnimeList
+type ImportMalAnimeList struct {
+	// Name: ImportMalAnimeList.jobId
+	JobId string `json:"jobId"`
+}
+
+// Name: MediaImageProviderError
+type MediaImageProviderError struct {
+	// Name: MediaImageProviderError.providerName
+	ProviderName string `json:"providerName"`
+	// Name: MediaImageProviderError.message
+	Message string `json:"message"`
+}
+
 // Name: PartBody
 type PartBody struct {
 	// Name: PartBody.name
@@ -887,6 +1425,18 @@ type PartBody struct {
 	ReleaseDate string `json:"releaseDate"`
 }
 
+// Name: Person
+type Person struct {
+	// Name: Person.id
+	Id string `json:"id"`
+	// Name: Person.type
+	Type string `json:"type"`
+	// Name: Person.name
+	Name string `json:"name"`
+	// Name: Person.providers
+	Providers []ProviderValue `json:"providers"`
+}
+
 // Name: PostProviderImportCollectionsBody
 type PostProviderImportCollectionsBody struct {
 	// Name: PostProviderImportCollectionsBody.ids
@@ -899,6 +1449,68 @@ type PostProviderImportMediaBody struct {
 	Ids []string `json:"ids"`
 }
 
+// Name: PostProviderImportSeasonal
+type PostProviderImportSeasonal struct {
+	// Name: PostProviderImportSeasonal.jobId
+	JobId string `json:"jobId"`
+}
+
+// Name: PostProviderImportSeasonalBody
+type PostProviderImportSeasonalBody struct {
+	// Name: PostProviderImportSeasonalBody.ids
+	Ids []string `json:"ids"`
+}
+
+// Name: ProviderResolvedLink
+type ProviderResolvedLink struct {
+	// Name: ProviderResolvedLink.link
+	Link string `json:"link"`
+	// Name: ProviderResolvedLink.providerName
+	ProviderName string `json:"providerName"`
+	// Name: ProviderResolvedLink.providerDisplayName
+	ProviderDisplayName string `json:"providerDisplayName"`
+	// Name: ProviderResolvedLink.providerId
+	ProviderId string `json:"providerId"`
+	// Name: ProviderResolvedLink.type
+	Type string `json:"type"`
+	// Name: ProviderResolvedLink.mediaId
+	MediaId *string `json:"mediaId,omitempty"`
+	// Name: ProviderResolvedLink.collectionId
+	CollectionId *string `json:"collectionId,omitempty"`
+}
+
+// Name: ProviderLinkError
+type ProviderLinkError struct {
+	// Name: ProviderLinkError.link
+	Link string `json:"link"`
+	// Name: ProviderLinkError.message
+	Message string `json:"message"`
+}
+
+// Name: PostProviderResolveLinks
+type PostProviderResolveLinks struct {
+	// Name: PostProviderResolveLinks.results
+	Results []ProviderResolvedLink `json:"results"`
+	// Name: PostProviderResolveLinks.errors
+	Errors []ProviderLinkError `json:"errors"`
+}
+
+// Name: PostProviderResolveLinksBody
+type PostProviderResolveLinksBody struct {
+	// Name: PostProviderResolveLinksBody.links
+	Links []string `json:"links"`
+	// Name: PostProviderResolveLinksBody.import
+	Import bool `json:"import"`
+}
+
+// Name: PromoteMediaImageBody
+type PromoteMediaImageBody struct {
+	// Name: PromoteMediaImageBody.file
+	File string `json:"file"`
+	// Name: PromoteMediaImageBody.type
+	Type string `json:"type"`
+}
+
 // Name: ProviderCollectionUpdateBody
 type ProviderCollectionUpdateBody struct {
 	// Name: ProviderCollectionUpdateBody.replaceImages
@@ -913,6 +1525,36 @@ type ProviderMediaUpdateBody struct {
 	OverrideParts bool `json:"overrideParts"`
 	// Name: ProviderMediaUpdateBody.setRelease
 	SetRelease bool `json:"setRelease"`
+	// Name: ProviderMediaUpdateBody.mergeProviders
+	MergeProviders bool `json:"mergeProviders"`
+}
+
+// Name: RefreshMediaImages
+type RefreshMediaImages struct {
+	// Name: RefreshMediaImages.added
+	Added int `json:"added"`
+	// Name: RefreshMediaImages.errors
+	Errors []MediaImageProviderError `json:"errors"`
+}
+
+// Name: ResolveMediaProviders
+type ResolveMediaProviders struct {
+	// Name: ResolveMediaProviders.added
+	Added []string `json:"added"`
+	// Name: ResolveMediaProviders.providers
+	Providers []ProviderValue `json:"providers"`
+}
+
+// Name: ResolveMediaProvidersBody
+type ResolveMediaProvidersBody struct {
+	// Name: ResolveMediaProvidersBody.fetchProviders
+	FetchProviders bool `json:"fetchProviders"`
+}
+
+// Name: RunJobSchedule
+type RunJobSchedule struct {
+	// Name: RunJobSchedule.jobId
+	JobId string `json:"jobId"`
 }
 
 // Name: SetMediaReleaseBody
@@ -987,6 +1629,8 @@ type SignupBody struct {
 type UpdateUserSettingsBody struct {
 	// Name: UpdateUserSettingsBody.displayName
 	DisplayName *string `json:"displayName,omitempty"`
+	// Name: UpdateUserSettingsBody.region
+	Region *string `json:"region,omitempty"`
 }
 
 // Name: UserData
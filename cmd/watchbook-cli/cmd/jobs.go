@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nanoteck137/watchbook/cmd/watchbook-cli/api"
+	"github.com/spf13/cobra"
+)
+
+func newJobsClient(cmd *cobra.Command) *api.Client {
+	apiAddress, _ := cmd.Flags().GetString("api-address")
+	authToken, _ := cmd.Flags().GetString("auth-token")
+
+	client := api.New(apiAddress)
+	client.Headers.Add("X-Api-Token", authToken)
+
+	return client
+}
+
+func formatJobTime(t int) string {
+	if t == 0 {
+		return "-"
+	}
+
+	return time.UnixMilli(int64(t)).Format(time.DateTime)
+}
+
+// NOTE(patrik): Builds the filter string from the flags, the raw filter is
+// combined with the other flags
+func createJobsFilter(cmd *cobra.Command) string {
+	rawFilter, _ := cmd.Flags().GetString("filter")
+	status, _ := cmd.Flags().GetString("status")
+	typ, _ := cmd.Flags().GetString("type")
+	since, _ := cmd.Flags().GetDuration("since")
+
+	var parts []string
+
+	if rawFilter != "" {
+		parts = append(parts, "("+rawFilter+")")
+	}
+
+	if status != "" {
+		parts = append(parts, fmt.Sprintf("status==%q", status))
+	}
+
+	if typ != "" {
+		parts = append(parts, fmt.Sprintf("type==%q", typ))
+	}
+
+	if since > 0 {
+		parts = append(parts, fmt.Sprintf("created>=%d", time.Now().Add(-since).UnixMilli()))
+	}
+
+	return strings.Join(parts, "&&")
+}
+
+var jobsCmd = &cobra.Command{
+	Use: "jobs",
+}
+
+var jobsListCmd = &cobra.Command{
+	Use: "list",
+	Run: func(cmd *cobra.Command, args []string) {
+		mine, _ := cmd.Flags().GetBool("mine")
+		sort, _ := cmd.Flags().GetString("sort")
+		page, _ := cmd.Flags().GetInt("page")
+		perPage, _ := cmd.Flags().GetInt("per-page")
+
+		client := newJobsClient(cmd)
+
+		query := url.Values{
+			"filter":  {createJobsFilter(cmd)},
+			"sort":    {sort},
+			"page":    {strconv.Itoa(page)},
+			"perPage": {strconv.Itoa(perPage)},
+		}
+
+		var res *api.GetJobs
+		var err error
+		if mine {
+			res, err = client.GetMyJobs(api.Options{Query: query})
+		} else {
+			res, err = client.GetJobs(api.Options{Query: query})
+		}
+		if err != nil {
+			logger.Fatal("failed to get jobs", "err", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTYPE\tSTATUS\tATTEMPTS\tCREATED\tUPDATED")
+
+		for _, job := range res.Jobs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d/%d\t%s\t%s\n",
+				job.Id,
+				job.Type,
+				job.Status,
+				job.Attempts,
+				job.MaxAttempts,
+				formatJobTime(job.Created),
+				formatJobTime(job.Updated),
+			)
+		}
+
+		w.Flush()
+
+		fmt.Printf("Page %d of %d (%d jobs)\n", res.Page.Page+1, res.Page.TotalPages, res.Page.TotalItems)
+	},
+}
+
+var jobsGetCmd = &cobra.Command{
+	Use:  "get <ID>",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newJobsClient(cmd)
+
+		job, err := client.GetJobById(args[0], api.Options{})
+		if err != nil {
+			logger.Fatal("failed to get job", "err", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Id:\t%s\n", job.Id)
+		fmt.Fprintf(w, "Type:\t%s\n", job.Type)
+		fmt.Fprintf(w, "Status:\t%s\n", job.Status)
+		fmt.Fprintf(w, "Priority:\t%d\n", job.Priority)
+		fmt.Fprintf(w, "Attempts:\t%d/%d\n", job.Attempts, job.MaxAttempts)
+		fmt.Fprintf(w, "Run At:\t%s\n", formatJobTime(job.RunAt))
+		fmt.Fprintf(w, "Created:\t%s\n", formatJobTime(job.Created))
+		fmt.Fprintf(w, "Updated:\t%s\n", formatJobTime(job.Updated))
+
+		if job.UserId != nil {
+			fmt.Fprintf(w, "User:\t%s\n", *job.UserId)
+		}
+
+		if job.WorkerId != nil {
+			fmt.Fprintf(w, "Worker:\t%s\n", *job.WorkerId)
+		}
+
+		if job.LeaseExpires != nil {
+			fmt.Fprintf(w, "Lease Expires:\t%s\n", formatJobTime(*job.LeaseExpires))
+		}
+
+		if job.Error != nil {
+			fmt.Fprintf(w, "Error:\t%s\n", *job.Error)
+		}
+
+		fmt.Fprintln(w, "Payload:")
+		for _, v := range job.Payload {
+			fmt.Fprintf(w, "  %s:\t%s\n", v.Key, v.Value)
+		}
+
+		w.Flush()
+	},
+}
+
+var jobsRetryCmd = &cobra.Command{
+	Use:  "retry <ID>",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newJobsClient(cmd)
+
+		_, err := client.RetryJob(args[0], api.Options{})
+		if err != nil {
+			logger.Fatal("failed to retry job", "err", err)
+		}
+
+		logger.Info("job queued for retry", "id", args[0])
+	},
+}
+
+var jobsCancelCmd = &cobra.Command{
+	Use:  "cancel <ID>",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newJobsClient(cmd)
+
+		_, err := client.CancelJob(args[0], api.Options{})
+		if err != nil {
+			logger.Fatal("failed to cancel job", "err", err)
+		}
+
+		logger.Info("job cancelled", "id", args[0])
+	},
+}
+
+var jobsDeleteCmd = &cobra.Command{
+	Use:  "delete <ID>",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newJobsClient(cmd)
+
+		_, err := client.DeleteJob(args[0], api.Options{})
+		if err != nil {
+			logger.Fatal("failed to delete job", "err", err)
+		}
+
+		logger.Info("job deleted", "id", args[0])
+	},
+}
+
+func init() {
+	jobsCmd.PersistentFlags().StringP("auth-token", "t", "", "auth token")
+	jobsCmd.MarkPersistentFlagRequired("auth-token")
+
+	jobsListCmd.Flags().Bool("mine", false, "only list the jobs queued by the user")
+	jobsListCmd.Flags().String("status", "", "filter by status (queued, running, success, failed, cancelled)")
+	jobsListCmd.Flags().String("type", "", "filter by job type")
+	jobsListCmd.Flags().Duration("since", 0, "only list the jobs created within the duration (24h)")
+	jobsListCmd.Flags().String("filter", "", "raw filter expression")
+	jobsListCmd.Flags().String("sort", "", "sort expression")
+	jobsListCmd.Flags().Int("page", 0, "page")
+	jobsListCmd.Flags().Int("per-page", 50, "number of jobs per page")
+
+	jobsCmd.AddCommand(jobsListCmd, jobsGetCmd, jobsRetryCmd, jobsCancelCmd, jobsDeleteCmd)
+
+	rootCmd.AddCommand(jobsCmd)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/nanoteck137/watchbook/cmd/watchbook-cli/api"
+	"github.com/spf13/cobra"
+)
+
+var schedulesCmd = &cobra.Command{
+	Use: "schedules",
+}
+
+var schedulesListCmd = &cobra.Command{
+	Use: "list",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newJobsClient(cmd)
+
+		res, err := client.GetJobSchedules(api.Options{})
+		if err != nil {
+			logger.Fatal("failed to get job schedules", "err", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tTYPE\tSCHEDULE\tENABLED\tLAST RUN\tNEXT RUN")
+
+		for _, s := range res.Schedules {
+			schedule := "-"
+			if s.Cron != nil {
+				schedule = *s.Cron
+			} else if s.Interval != nil {
+				schedule = "every " + *s.Interval
+			}
+
+			name := s.Name
+			if s.FromConfig {
+				name += " (config)"
+			}
+
+			lastRun := "-"
+			if s.LastRun != nil {
+				lastRun = formatJobTime(*s.LastRun)
+			}
+
+			nextRun := "-"
+			if s.NextRun != nil {
+				nextRun = formatJobTime(*s.NextRun)
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%s\t%s\n",
+				s.Id,
+				name,
+				s.Type,
+				schedule,
+				s.Enabled,
+				lastRun,
+				nextRun,
+			)
+		}
+
+		w.Flush()
+	},
+}
+
+var schedulesRunCmd = &cobra.Command{
+	Use:  "run <ID>",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := newJobsClient(cmd)
+
+		res, err := client.RunJobSchedule(args[0], api.Options{})
+		if err != nil {
+			logger.Fatal("failed to run job schedule", "err", err)
+		}
+
+		logger.Info("job queued", "id", res.JobId)
+	},
+}
+
+func setScheduleEnabled(cmd *cobra.Command, id string, enabled bool) {
+	client := newJobsClient(cmd)
+
+	_, err := client.EditJobSchedule(id, api.EditJobScheduleBody{
+		Enabled: &enabled,
+	}, api.Options{})
+	if err != nil {
+		logger.Fatal("failed to update job schedule", "err", err)
+	}
+
+	logger.Info("job schedule updated", "id", id, "enabled", enabled)
+}
+
+var schedulesEnableCmd = &cobra.Command{
+	Use:  "enable <ID>",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setScheduleEnabled(cmd, args[0], true)
+	},
+}
+
+var schedulesDisableCmd = &cobra.Command{
+	Use:  "disable <ID>",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setScheduleEnabled(cmd, args[0], false)
+	},
+}
+
+func init() {
+	schedulesCmd.AddCommand(schedulesListCmd, schedulesRunCmd, schedulesEnableCmd, schedulesDisableCmd)
+
+	jobsCmd.AddCommand(schedulesCmd)
+}
@@ -1,6 +1,13 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/nanoteck137/watchbook/apis"
 	"github.com/nanoteck137/watchbook/config"
 	"github.com/nanoteck137/watchbook/core"
@@ -22,9 +29,38 @@ var serveCmd = &cobra.Command{
 			app.Logger().Fatal("Failed to create server", "err", err)
 		}
 
-		err = e.Start(app.Config().ListenAddr)
+		// NOTE(patrik): Start the workers after the job handlers has been
+		// registered by the server
+		app.JobProcessor().Start(1)
+
+		serverErr := make(chan error, 1)
+		go func() {
+			serverErr <- e.Start(app.Config().ListenAddr)
+		}()
+
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+
+		select {
+		case err := <-serverErr:
+			if !errors.Is(err, http.ErrServerClosed) {
+				app.Logger().Fatal("Failed to start server", "err", err)
+			}
+		case s := <-sig:
+			app.Logger().Info("Stopping server", "signal", s.String())
+		}
+
+		// NOTE(patrik): Give the running jobs time to finish, the jobs that
+		// doesn't finish in time are queued again for the next start. The
+		// http server keeps running until the process exits (pyrin.Server
+		// has no Shutdown) but the handlers refuses to queue new jobs after
+		// Stop has been called.
+		ctx, cancel := context.WithTimeout(context.Background(), app.Config().ShutdownTimeout)
+		defer cancel()
+
+		err = app.JobProcessor().Stop(ctx)
 		if err != nil {
-			app.Logger().Fatal("Failed to start server", "err", err)
+			app.Logger().Warn("Stopped before all the jobs finished, the unfinished jobs was queued again", "err", err)
 		}
 	},
 }
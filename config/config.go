@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strings"
+	"time"
 
 	"github.com/nanoteck137/watchbook"
 	"github.com/nanoteck137/watchbook/types"
@@ -17,6 +19,90 @@ type Config struct {
 	Username        string `mapstructure:"username"`
 	InitialPassword string `mapstructure:"initial_password"`
 	JwtSecret       string `mapstructure:"jwt_secret"`
+
+	// NOTE(patrik): How long the server waits for the running jobs to
+	// finish when stopped, the jobs still running after that are queued
+	// again
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+
+	Providers map[string]ProviderConfig `mapstructure:"providers"`
+	Plugins   map[string]PluginConfig   `mapstructure:"plugins"`
+
+	// NOTE(patrik): Offline datasets used to link the ids between
+	// providers (anime-offline-database, anime-lists)
+	IdMappingFiles []string `mapstructure:"id_mapping_files"`
+
+	// NOTE(patrik): Media type -> field -> provider names, overrides the
+	// default merge policies
+	MergePolicies map[string]map[string][]string `mapstructure:"merge_policies"`
+
+	SeasonalImport SeasonalImportConfig `mapstructure:"seasonal_import"`
+
+	// NOTE(patrik): Recurring jobs, the name of the section is the name of
+	// the schedule
+	Schedules map[string]ScheduleConfig `mapstructure:"schedules"`
+}
+
+// NOTE(patrik): Keeps the entries of the current anime season imported
+// from the seasonal chart of the provider
+type SeasonalImportConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Provider string        `mapstructure:"provider"`
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// NOTE(patrik): One of cron and interval needs to be set, the payload is a
+// list of "key=value" because viper lowercases the keys of maps
+type ScheduleConfig struct {
+	Type        string        `mapstructure:"type"`
+	Cron        string        `mapstructure:"cron"`
+	Interval    time.Duration `mapstructure:"interval"`
+	Enabled     bool          `mapstructure:"enabled"`
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	Payload     []string      `mapstructure:"payload"`
+}
+
+type ProviderConfig struct {
+	ApiKey    string  `mapstructure:"api_key"`
+	BaseUrl   string  `mapstructure:"base_url"`
+	Language  string  `mapstructure:"language"`
+	UserAgent string  `mapstructure:"user_agent"`
+	RateLimit float64 `mapstructure:"rate_limit"`
+	RateBurst int     `mapstructure:"rate_burst"`
+	Dir       string  `mapstructure:"dir"`
+}
+
+type PluginConfig struct {
+	Command string        `mapstructure:"command"`
+	Args    []string      `mapstructure:"args"`
+	Env     []string      `mapstructure:"env"`
+	Dir     string        `mapstructure:"dir"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// NOTE(patrik): Names of the [providers.<name>] sections, used to bind the
+// env variables (WATCHBOOK_PROVIDERS_<NAME>_<KEY>)
+var ProviderConfigNames = []string{
+	"myanimelist",
+	"anilist",
+	"tmdb",
+	"rawg",
+	"comicvine",
+	"file",
+}
+
+var providerConfigKeys = []string{
+	"api_key",
+	"base_url",
+	"language",
+	"user_agent",
+	"rate_limit",
+	"rate_burst",
+	"dir",
+}
+
+func (c *Config) ProviderConfig(name string) ProviderConfig {
+	return c.Providers[name]
 }
 
 func (c *Config) WorkDir() types.WorkDir {
@@ -26,10 +112,20 @@ func (c *Config) WorkDir() types.WorkDir {
 func setDefaults() {
 	viper.SetDefault("run_migrations", "true")
 	viper.SetDefault("listen_addr", ":3000")
+	viper.SetDefault("shutdown_timeout", "30s")
+	viper.SetDefault("seasonal_import.provider", "myanimelist-anime")
+	viper.SetDefault("seasonal_import.interval", "24h")
 	viper.BindEnv("data_dir")
 	viper.BindEnv("username")
 	viper.BindEnv("initial_password")
 	viper.BindEnv("jwt_secret")
+	viper.BindEnv("id_mapping_files")
+
+	for _, name := range ProviderConfigNames {
+		for _, key := range providerConfigKeys {
+			viper.BindEnv("providers." + name + "." + key)
+		}
+	}
 }
 
 func validateConfig(config *Config) {
@@ -49,6 +145,26 @@ func validateConfig(config *Config) {
 	validate(config.Username == "", "username needs to be set")
 	validate(config.InitialPassword == "", "initial_password needs to be set")
 	validate(config.JwtSecret == "", "jwt_secret needs to be set")
+	validate(config.ShutdownTimeout < 0, "shutdown_timeout can't be negative")
+
+	if config.SeasonalImport.Enabled {
+		validate(config.SeasonalImport.Provider == "", "seasonal_import.provider needs to be set")
+		validate(config.SeasonalImport.Interval <= 0, "seasonal_import.interval needs to be greater than 0")
+	}
+
+	for name, schedule := range config.Schedules {
+		validate(schedule.Type == "", "schedules."+name+".type needs to be set")
+		validate(schedule.Cron == "" && schedule.Interval <= 0, "schedules."+name+" needs a cron or an interval")
+		validate(schedule.Cron != "" && schedule.Interval != 0, "schedules."+name+" can't have both a cron and an interval")
+
+		for _, kv := range schedule.Payload {
+			validate(!strings.Contains(kv, "="), "schedules."+name+".payload entries needs to be \"key=value\"")
+		}
+	}
+
+	for name, plugin := range config.Plugins {
+		validate(plugin.Command == "", "plugins."+name+".command needs to be set")
+	}
 
 	if hasError {
 		os.Exit(1)
@@ -69,6 +185,7 @@ func InitConfig() {
 	}
 
 	viper.SetEnvPrefix(watchbook.AppName)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
 	viper.AutomaticEnv()
 
 	err := viper.ReadInConfig()
@@ -94,6 +211,12 @@ func InitConfig() {
 	configCopy.JwtSecret = hide(configCopy.JwtSecret)
 	configCopy.InitialPassword = hide(configCopy.InitialPassword)
 
+	configCopy.Providers = make(map[string]ProviderConfig, len(LoadedConfig.Providers))
+	for name, p := range LoadedConfig.Providers {
+		p.ApiKey = hide(p.ApiKey)
+		configCopy.Providers[name] = p
+	}
+
 	logger.Debug("Current Config", "config", configCopy)
 
 	validateConfig(&LoadedConfig)
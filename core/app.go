@@ -6,6 +6,7 @@ import (
 	"github.com/nanoteck137/watchbook/database"
 	"github.com/nanoteck137/watchbook/job"
 	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/provider/mapping"
 	"github.com/nanoteck137/watchbook/types"
 )
 
@@ -17,6 +18,7 @@ type App interface {
 	DB() *database.Database
 	Config() *config.Config
 	ProviderManager() *provider.ProviderManager
+	IdMappings() *mapping.Database
 	JobProcessor() *job.JobProcessor
 
 	WorkDir() types.WorkDir
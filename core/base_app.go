@@ -12,8 +12,14 @@ import (
 	"github.com/nanoteck137/watchbook/database"
 	"github.com/nanoteck137/watchbook/job"
 	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/provider/anilist"
+	"github.com/nanoteck137/watchbook/provider/comicvine"
 	"github.com/nanoteck137/watchbook/provider/dummy"
+	"github.com/nanoteck137/watchbook/provider/file"
+	"github.com/nanoteck137/watchbook/provider/mapping"
 	"github.com/nanoteck137/watchbook/provider/myanimelist"
+	"github.com/nanoteck137/watchbook/provider/plugin"
+	"github.com/nanoteck137/watchbook/provider/rawg"
 	"github.com/nanoteck137/watchbook/provider/tmdb"
 	"github.com/nanoteck137/watchbook/tools/cache"
 	"github.com/nanoteck137/watchbook/types"
@@ -26,6 +32,7 @@ type BaseApp struct {
 	db              *database.Database
 	cacheDb         *ember.Database
 	providerManager *provider.ProviderManager
+	idMappings      *mapping.Database
 	config          *config.Config
 	jobProcessor    *job.JobProcessor
 }
@@ -46,6 +53,10 @@ func (app *BaseApp) ProviderManager() *provider.ProviderManager {
 	return app.providerManager
 }
 
+func (app *BaseApp) IdMappings() *mapping.Database {
+	return app.idMappings
+}
+
 func (app *BaseApp) Config() *config.Config {
 	return app.config
 }
@@ -63,6 +74,7 @@ func (app *BaseApp) Bootstrap() error {
 		workDir.MediaDir(),
 		workDir.CollectionsDir(),
 		workDir.ShowsDir(),
+		workDir.MetadataDir(),
 	}
 
 	for _, dir := range dirs {
@@ -95,12 +107,54 @@ func (app *BaseApp) Bootstrap() error {
 	}
 
 	pm := provider.NewProviderManager(cache)
-	pm.RegisterProvider(&myanimelist.MyAnimeListAnimeProvider{})
+	pm.RegisterProvider(myanimelist.NewMyAnimeListAnimeProvider(app.providerConfig("myanimelist")))
+	pm.RegisterProvider(anilist.NewAnilistAnimeProvider(app.providerConfig("anilist")))
+	pm.RegisterProvider(anilist.NewAnilistMangaProvider(app.providerConfig("anilist")))
 	pm.RegisterProvider(&dummy.DummyProvider{})
-	pm.RegisterProvider(&tmdb.TmdbMovieProvider{})
-	pm.RegisterProvider(&tmdb.TmdbTvProvider{})
+	pm.RegisterProvider(tmdb.NewTmdbMovieProvider(app.providerConfig("tmdb")))
+	pm.RegisterProvider(tmdb.NewTmdbTvProvider(app.providerConfig("tmdb")))
+	pm.RegisterProvider(rawg.NewRawgGameProvider(app.providerConfig("rawg")))
+	pm.RegisterProvider(comicvine.NewComicVineComicProvider(app.providerConfig("comicvine")))
+
+	fileConfig := app.providerConfig("file")
+	pm.RegisterProvider(file.NewFileProvider(fileConfig.GetDir(workDir.MetadataDir())))
+
+	for name, c := range app.config.Plugins {
+		if pm.IsValidProvider(name) {
+			app.logger.Error("plugin name is already used by another provider, skipping", "name", name)
+			continue
+		}
+
+		p := plugin.NewPluginProvider(context.Background(), plugin.Config{
+			Name:    name,
+			Command: c.Command,
+			Args:    c.Args,
+			Env:     c.Env,
+			Dir:     c.Dir,
+			Timeout: c.Timeout,
+		})
+
+		info := p.Info()
+		if info.Disabled {
+			app.logger.Error("failed to load plugin", "name", name, "err", info.DisabledReason)
+		}
+
+		pm.RegisterProvider(p)
+	}
 
 	app.providerManager = pm
+
+	app.idMappings = mapping.NewDatabase()
+	for _, p := range app.config.IdMappingFiles {
+		count, err := app.idMappings.LoadFile(p)
+		if err != nil {
+			app.logger.Error("failed to load id mapping file", "path", p, "err", err)
+			continue
+		}
+
+		app.logger.Info("loaded id mapping file", "path", p, "entries", count)
+	}
+
 	app.jobProcessor = job.NewJobProcessor(app.db)
 
 	_, err = os.Stat(workDir.SetupFile())
@@ -125,11 +179,23 @@ func (app *BaseApp) Bootstrap() error {
 		f.Close()
 	}
 
-	app.jobProcessor.Start(1)
-
 	return nil
 }
 
+func (app *BaseApp) providerConfig(name string) provider.Config {
+	c := app.config.ProviderConfig(name)
+
+	return provider.Config{
+		ApiKey:    c.ApiKey,
+		BaseUrl:   c.BaseUrl,
+		Language:  c.Language,
+		UserAgent: c.UserAgent,
+		RateLimit: c.RateLimit,
+		RateBurst: c.RateBurst,
+		Dir:       c.Dir,
+	}
+}
+
 func NewBaseApp(config *config.Config) *BaseApp {
 	return &BaseApp{
 		logger: watchbook.DefaultLogger(),
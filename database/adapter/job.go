@@ -0,0 +1,86 @@
+package adapter
+
+import (
+	"go/ast"
+
+	"github.com/nanoteck137/watchbook/filter"
+)
+
+var _ filter.ResolverAdapter = (*JobResolverAdapter)(nil)
+
+type JobResolverAdapter struct{}
+
+func (a *JobResolverAdapter) DefaultSort() (string, filter.SortType) {
+	return "jobs.created", filter.SortTypeDesc
+}
+
+func (a *JobResolverAdapter) ResolveVariableName(name string) (filter.Name, bool) {
+	switch name {
+	case "id":
+		return filter.Name{
+			Kind: filter.NameKindString,
+			Name: "jobs.id",
+		}, true
+	case "type":
+		return filter.Name{
+			Kind: filter.NameKindString,
+			Name: "jobs.type",
+		}, true
+	case "status":
+		return filter.Name{
+			Kind: filter.NameKindString,
+			Name: "jobs.status",
+		}, true
+	case "userId":
+		return filter.Name{
+			Kind: filter.NameKindString,
+			Name: "jobs.user_id",
+		}, true
+	case "priority":
+		return filter.Name{
+			Kind: filter.NameKindNumber,
+			Name: "jobs.priority",
+		}, true
+	case "runAt":
+		return filter.Name{
+			Kind: filter.NameKindNumber,
+			Name: "jobs.run_at",
+		}, true
+	case "attempts":
+		return filter.Name{
+			Kind: filter.NameKindNumber,
+			Name: "jobs.attempts",
+		}, true
+	case "created":
+		return filter.Name{
+			Kind: filter.NameKindNumber,
+			Name: "jobs.created",
+		}, true
+	case "updated":
+		return filter.Name{
+			Kind: filter.NameKindNumber,
+			Name: "jobs.updated",
+		}, true
+	}
+
+	return filter.Name{}, false
+}
+
+func (a *JobResolverAdapter) ResolveNameToId(typ, name string) (string, bool) {
+	return "", false
+}
+
+func (a *JobResolverAdapter) ResolveTable(typ string) (filter.Table, bool) {
+	return filter.Table{}, false
+}
+
+func (a *JobResolverAdapter) ResolveFunctionCall(resolver *filter.Resolver, name string, args []ast.Expr) (filter.FilterExpr, error) {
+	switch name {
+	case "hasType":
+		return resolver.In(name, "type", args)
+	case "hasStatus":
+		return resolver.In(name, "status", args)
+	}
+
+	return nil, filter.UnknownFunction(name)
+}
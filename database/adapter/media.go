@@ -2,6 +2,7 @@ package adapter
 
 import (
 	"go/ast"
+	"strings"
 
 	"github.com/nanoteck137/watchbook/filter"
 	"github.com/nanoteck137/watchbook/utils"
@@ -9,7 +10,10 @@ import (
 
 var _ filter.ResolverAdapter = (*MediaResolverAdapter)(nil)
 
-type MediaResolverAdapter struct{}
+type MediaResolverAdapter struct {
+	// NOTE(patrik): Used by availableOn when the region is not specified
+	Region string
+}
 
 func (a *MediaResolverAdapter) DefaultSort() (string, filter.SortType) {
 	return "media.title", filter.SortTypeAsc
@@ -105,6 +109,12 @@ func (a *MediaResolverAdapter) ResolveNameToId(typ, name string) (string, bool)
 		return utils.Slug(name), true
 	case "creators":
 		return utils.Slug(name), true
+	case "artists":
+		return utils.Slug(name), true
+	case "people":
+		return utils.Slug(name), true
+	case "availability":
+		return utils.Slug(name), true
 	}
 
 	return "", false
@@ -124,6 +134,24 @@ func (a *MediaResolverAdapter) ResolveTable(typ string) (filter.Table, bool) {
 			SelectName: "media_id",
 			WhereName:  "tag_slug",
 		}, true
+	case "artists":
+		return filter.Table{
+			Name:       "media_theme_songs",
+			SelectName: "media_id",
+			WhereName:  "artist_slug",
+		}, true
+	case "people":
+		return filter.Table{
+			Name:       "media_people",
+			SelectName: "media_id",
+			WhereName:  "person_slug",
+		}, true
+	case "availability":
+		return filter.Table{
+			Name:       "media_availability",
+			SelectName: "media_id",
+			WhereName:  "service",
+		}, true
 	}
 
 	return filter.Table{}, false
@@ -135,6 +163,34 @@ func (a *MediaResolverAdapter) ResolveFunctionCall(resolver *filter.Resolver, na
 		return resolver.InTable(name, "tags", "media.id", args)
 	case "hasCreator":
 		return resolver.InTable(name, "creators", "media.id", args)
+	case "hasArtist":
+		return resolver.InTable(name, "artists", "media.id", args)
+	case "hasPerson":
+		return resolver.InTableWhere(name, "people", "media.id", []string{"role"}, args)
+	case "availableOn":
+		// NOTE(patrik): availableOn("netflix", "SE", "subscription"), the
+		// region and type is optional
+		expr, err := resolver.InTableWhere(name, "availability", "media.id", []string{"region", "type"}, args)
+		if err != nil {
+			return nil, err
+		}
+
+		hasRegion := false
+		for i, c := range expr.Conditions {
+			if c.Column == "region" {
+				expr.Conditions[i].Value = strings.ToUpper(c.Value)
+				hasRegion = true
+			}
+		}
+
+		if !hasRegion && a.Region != "" {
+			expr.Conditions = append(expr.Conditions, filter.TableCondition{
+				Column: "region",
+				Value:  a.Region,
+			})
+		}
+
+		return expr, nil
 	case "hasType":
 		return resolver.In(name, "type", args)
 	case "hasStatus":
@@ -84,6 +84,15 @@ func generateTableSelect(table *filter.Table, ids []string) *goqu.SelectDataset
 		Where(goqu.I(table.WhereName).In(ids))
 }
 
+func generateTableSelectWithConditions(table *filter.Table, ids []string, conditions []filter.TableCondition) *goqu.SelectDataset {
+	s := generateTableSelect(table, ids)
+	for _, c := range conditions {
+		s = s.Where(goqu.I(c.Column).Eq(c.Value))
+	}
+
+	return s
+}
+
 var opMapping = map[filter.OpKind]string{
 	filter.OpEqual:        "(? == ?)",
 	filter.OpNotEqual:     "(? != ?)",
@@ -133,7 +142,7 @@ func generateFilter(e filter.FilterExpr) (exp.Expression, error) {
 
 		return nil, fmt.Errorf("unimplemented OpKind %d", e.Kind)
 	case *filter.InTableExpr:
-		s := generateTableSelect(&e.Table, e.Ids)
+		s := generateTableSelectWithConditions(&e.Table, e.Ids, e.Conditions)
 
 		if e.Not {
 			return goqu.L("? NOT IN ?", goqu.I(e.IdSelector), s), nil
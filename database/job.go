@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/nanoteck137/pyrin/ember"
+	"github.com/nanoteck137/watchbook/database/adapter"
+	"github.com/nanoteck137/watchbook/filter"
 	"github.com/nanoteck137/watchbook/types"
 	"github.com/nanoteck137/watchbook/utils"
 )
@@ -18,7 +21,7 @@ type Job struct {
 	Type string `db:"type"`
 
 	Status   types.JobStatus `db:"status"`
-	Priority int             `db:"status"`
+	Priority int             `db:"priority"`
 	RunAt    int64           `db:"run_at"`
 
 	Attempts    int `db:"attempts"`
@@ -27,6 +30,16 @@ type Job struct {
 	Payload string         `db:"payload"`
 	Error   sql.NullString `db:"error"`
 
+	// NOTE(patrik): The user that queued the job, null for the jobs queued
+	// by the server
+	UserId sql.NullString `db:"user_id"`
+
+	// NOTE(patrik): Set while the job is running, the worker needs to
+	// extend the lease before it expires or the job is seen as abandoned
+	WorkerId     sql.NullString `db:"worker_id"`
+	Heartbeat    sql.NullInt64  `db:"heartbeat"`
+	LeaseExpires sql.NullInt64  `db:"lease_expires"`
+
 	Created int64 `db:"created"`
 	Updated int64 `db:"updated"`
 }
@@ -41,7 +54,7 @@ func JobQuery() *goqu.SelectDataset {
 			"jobs.type",
 
 			"jobs.status",
-			"jobs.status",
+			"jobs.priority",
 			"jobs.run_at",
 
 			"jobs.attempts",
@@ -50,6 +63,12 @@ func JobQuery() *goqu.SelectDataset {
 			"jobs.payload",
 			"jobs.error",
 
+			"jobs.user_id",
+
+			"jobs.worker_id",
+			"jobs.heartbeat",
+			"jobs.lease_expires",
+
 			"jobs.created",
 			"jobs.updated",
 		)
@@ -57,6 +76,60 @@ func JobQuery() *goqu.SelectDataset {
 	return query
 }
 
+// NOTE(patrik): userId is optional, when set only the jobs queued by the
+// user is returned
+func (db DB) GetPagedJobs(ctx context.Context, userId *string, filterStr, sortStr string, opts FetchOptions) ([]Job, types.Page, error) {
+	query := JobQuery()
+
+	if userId != nil {
+		query = query.Where(goqu.I("jobs.user_id").Eq(*userId))
+	}
+
+	var err error
+
+	a := adapter.JobResolverAdapter{}
+	resolver := filter.New(&a)
+
+	query, err = applyFilter(query, resolver, filterStr)
+	if err != nil {
+		return nil, types.Page{}, err
+	}
+
+	query, err = applySort(query, resolver, sortStr)
+	if err != nil {
+		return nil, types.Page{}, err
+	}
+
+	countQuery := query.
+		Select(goqu.COUNT("jobs.id"))
+
+	if opts.PerPage > 0 {
+		query = query.
+			Limit(uint(opts.PerPage)).
+			Offset(uint(opts.Page * opts.PerPage))
+	}
+
+	totalItems, err := ember.Single[int](db.db, ctx, countQuery)
+	if err != nil {
+		return nil, types.Page{}, err
+	}
+
+	totalPages := utils.TotalPages(opts.PerPage, totalItems)
+	page := types.Page{
+		Page:       opts.Page,
+		PerPage:    opts.PerPage,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}
+
+	items, err := ember.Multiple[Job](db.db, ctx, query)
+	if err != nil {
+		return nil, types.Page{}, err
+	}
+
+	return items, page, nil
+}
+
 func (db DB) GetAllJobs(ctx context.Context) ([]Job, error) {
 	query := JobQuery()
 	return ember.Multiple[Job](db.db, ctx, query)
@@ -91,6 +164,27 @@ func (db DB) GetNextJob(ctx context.Context) (Job, error) {
 	return ember.Single[Job](db.db, ctx, query)
 }
 
+// NOTE(patrik): Returns true if there is a queued or running job with the
+// type, used to not queue the same job twice
+func (db DB) HasActiveJob(ctx context.Context, jobType string) (bool, error) {
+	query := dialect.From("jobs").
+		Select(goqu.COUNT("jobs.id")).
+		Where(
+			goqu.I("jobs.type").Eq(jobType),
+			goqu.I("jobs.status").In(
+				string(types.JobStatusQueued),
+				string(types.JobStatusRunning),
+			),
+		)
+
+	count, err := ember.Single[int](db.db, ctx, query)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
 type CreateJobParams struct {
 	Id   string
 	Type string
@@ -105,6 +199,8 @@ type CreateJobParams struct {
 	Payload string
 	Error   sql.NullString
 
+	UserId sql.NullString
+
 	Created int64
 	Updated int64
 }
@@ -142,6 +238,8 @@ func (db DB) CreateJob(ctx context.Context, params CreateJobParams) (string, err
 		"payload": params.Payload,
 		"error":   params.Error,
 
+		"user_id": params.UserId,
+
 		"created": params.Created,
 		"updated": params.Updated,
 	}).
@@ -163,10 +261,14 @@ type JobChanges struct {
 	Payload Change[string]
 	Error   Change[sql.NullString]
 
+	WorkerId     Change[sql.NullString]
+	Heartbeat    Change[sql.NullInt64]
+	LeaseExpires Change[sql.NullInt64]
+
 	Created Change[int64]
 }
 
-func (db DB) UpdateJob(ctx context.Context, id string, changes JobChanges) error {
+func createJobRecord(changes JobChanges) goqu.Record {
 	record := goqu.Record{}
 
 	addToRecord(record, "type", changes.Type)
@@ -181,8 +283,17 @@ func (db DB) UpdateJob(ctx context.Context, id string, changes JobChanges) error
 	addToRecord(record, "payload", changes.Payload)
 	addToRecord(record, "error", changes.Error)
 
+	addToRecord(record, "worker_id", changes.WorkerId)
+	addToRecord(record, "heartbeat", changes.Heartbeat)
+	addToRecord(record, "lease_expires", changes.LeaseExpires)
+
 	addToRecord(record, "created", changes.Created)
 
+	return record
+}
+
+func (db DB) UpdateJob(ctx context.Context, id string, changes JobChanges) error {
+	record := createJobRecord(changes)
 	if len(record) == 0 {
 		return nil
 	}
@@ -201,6 +312,85 @@ func (db DB) UpdateJob(ctx context.Context, id string, changes JobChanges) error
 	return nil
 }
 
+// NOTE(patrik): Only updates the job if the current status is one of the
+// statuses, used so the change doesn't race with the workers. Returns false
+// if the job was not updated.
+func (db DB) UpdateJobWithStatus(ctx context.Context, id string, statuses []types.JobStatus, changes JobChanges) (bool, error) {
+	s := make([]any, len(statuses))
+	for i, status := range statuses {
+		s[i] = string(status)
+	}
+
+	return db.updateJobWhere(ctx, changes,
+		goqu.I("jobs.id").Eq(id),
+		goqu.I("jobs.status").In(s...),
+	)
+}
+
+// NOTE(patrik): Only updates the job if it's still running on the worker,
+// returns false if the worker lost the job (the lease expired and the job
+// was recovered or the job was cancelled)
+func (db DB) UpdateJobForWorker(ctx context.Context, id, workerId string, changes JobChanges) (bool, error) {
+	return db.updateJobWhere(ctx, changes,
+		goqu.I("jobs.id").Eq(id),
+		goqu.I("jobs.status").Eq(string(types.JobStatusRunning)),
+		goqu.I("jobs.worker_id").Eq(workerId),
+	)
+}
+
+// NOTE(patrik): Returns the running jobs where the lease has expired, jobs
+// without a lease is included (jobs started before the leases was added)
+func (db DB) GetExpiredJobs(ctx context.Context, now int64) ([]Job, error) {
+	query := JobQuery().
+		Where(
+			goqu.I("jobs.status").Eq(string(types.JobStatusRunning)),
+			goqu.Or(
+				goqu.I("jobs.lease_expires").IsNull(),
+				goqu.I("jobs.lease_expires").Lt(now),
+			),
+		)
+
+	return ember.Multiple[Job](db.db, ctx, query)
+}
+
+// NOTE(patrik): Only updates the job if the lease is still expired, used so
+// the recovery doesn't race with a worker that extends the lease
+func (db DB) UpdateExpiredJob(ctx context.Context, id string, now int64, changes JobChanges) (bool, error) {
+	return db.updateJobWhere(ctx, changes,
+		goqu.I("jobs.id").Eq(id),
+		goqu.I("jobs.status").Eq(string(types.JobStatusRunning)),
+		goqu.Or(
+			goqu.I("jobs.lease_expires").IsNull(),
+			goqu.I("jobs.lease_expires").Lt(now),
+		),
+	)
+}
+
+func (db DB) updateJobWhere(ctx context.Context, changes JobChanges, where ...exp.Expression) (bool, error) {
+	record := createJobRecord(changes)
+	if len(record) == 0 {
+		return false, nil
+	}
+
+	record["updated"] = time.Now().UnixMilli()
+
+	query := dialect.Update("jobs").
+		Set(record).
+		Where(where...)
+
+	res, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
 func (db DB) RemoveJob(ctx context.Context, id string) error {
 	query := dialect.Delete("jobs").
 		Where(goqu.I("jobs.id").Eq(id))
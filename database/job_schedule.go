@@ -0,0 +1,231 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/nanoteck137/pyrin/ember"
+	"github.com/nanoteck137/watchbook/utils"
+)
+
+type JobSchedule struct {
+	RowId int `db:"rowid"`
+
+	Id   string `db:"id"`
+	Name string `db:"name"`
+
+	Type        string `db:"type"`
+	Payload     string `db:"payload"`
+	MaxAttempts int    `db:"max_attempts"`
+
+	// NOTE(patrik): One of cron and interval (milliseconds) is set
+	Cron     sql.NullString `db:"cron"`
+	Interval sql.NullInt64  `db:"interval"`
+
+	Enabled    bool `db:"enabled"`
+	FromConfig bool `db:"from_config"`
+
+	LastRun   sql.NullInt64  `db:"last_run"`
+	NextRun   sql.NullInt64  `db:"next_run"`
+	LastJobId sql.NullString `db:"last_job_id"`
+
+	Created int64 `db:"created"`
+	Updated int64 `db:"updated"`
+}
+
+func JobScheduleQuery() *goqu.SelectDataset {
+	query := dialect.From("job_schedules").
+		Select(
+			"job_schedules.rowid",
+
+			"job_schedules.id",
+			"job_schedules.name",
+
+			"job_schedules.type",
+			"job_schedules.payload",
+			"job_schedules.max_attempts",
+
+			"job_schedules.cron",
+			"job_schedules.interval",
+
+			"job_schedules.enabled",
+			"job_schedules.from_config",
+
+			"job_schedules.last_run",
+			"job_schedules.next_run",
+			"job_schedules.last_job_id",
+
+			"job_schedules.created",
+			"job_schedules.updated",
+		)
+
+	return query
+}
+
+func (db DB) GetAllJobSchedules(ctx context.Context) ([]JobSchedule, error) {
+	query := JobScheduleQuery().
+		Order(goqu.I("job_schedules.name").Asc())
+
+	return ember.Multiple[JobSchedule](db.db, ctx, query)
+}
+
+func (db DB) GetJobScheduleById(ctx context.Context, id string) (JobSchedule, error) {
+	query := JobScheduleQuery().
+		Where(goqu.I("job_schedules.id").Eq(id))
+
+	return ember.Single[JobSchedule](db.db, ctx, query)
+}
+
+func (db DB) GetJobScheduleByName(ctx context.Context, name string) (JobSchedule, error) {
+	query := JobScheduleQuery().
+		Where(goqu.I("job_schedules.name").Eq(name))
+
+	return ember.Single[JobSchedule](db.db, ctx, query)
+}
+
+// NOTE(patrik): Returns the enabled schedules where the next run is at or
+// before now (unix milli)
+func (db DB) GetDueJobSchedules(ctx context.Context, now int64) ([]JobSchedule, error) {
+	query := JobScheduleQuery().
+		Where(
+			goqu.I("job_schedules.enabled").IsTrue(),
+			goqu.I("job_schedules.next_run").Lte(now),
+		).
+		Order(goqu.I("job_schedules.next_run").Asc())
+
+	return ember.Multiple[JobSchedule](db.db, ctx, query)
+}
+
+type CreateJobScheduleParams struct {
+	Id   string
+	Name string
+
+	Type        string
+	Payload     string
+	MaxAttempts int
+
+	Cron     sql.NullString
+	Interval sql.NullInt64
+
+	Enabled    bool
+	FromConfig bool
+
+	LastRun   sql.NullInt64
+	NextRun   sql.NullInt64
+	LastJobId sql.NullString
+
+	Created int64
+	Updated int64
+}
+
+func (db DB) CreateJobSchedule(ctx context.Context, params CreateJobScheduleParams) (string, error) {
+	if params.Created == 0 && params.Updated == 0 {
+		t := time.Now().UnixMilli()
+		params.Created = t
+		params.Updated = t
+	}
+
+	if params.Id == "" {
+		params.Id = utils.CreateJobScheduleId()
+	}
+
+	if params.MaxAttempts <= 0 {
+		params.MaxAttempts = 1
+	}
+
+	query := dialect.Insert("job_schedules").Rows(goqu.Record{
+		"id":   params.Id,
+		"name": params.Name,
+
+		"type":         params.Type,
+		"payload":      params.Payload,
+		"max_attempts": params.MaxAttempts,
+
+		"cron":     params.Cron,
+		"interval": params.Interval,
+
+		"enabled":     params.Enabled,
+		"from_config": params.FromConfig,
+
+		"last_run":    params.LastRun,
+		"next_run":    params.NextRun,
+		"last_job_id": params.LastJobId,
+
+		"created": params.Created,
+		"updated": params.Updated,
+	}).
+		Returning("id")
+
+	return ember.Single[string](db.db, ctx, query)
+}
+
+type JobScheduleChanges struct {
+	Name Change[string]
+
+	Type        Change[string]
+	Payload     Change[string]
+	MaxAttempts Change[int]
+
+	Cron     Change[sql.NullString]
+	Interval Change[sql.NullInt64]
+
+	Enabled Change[bool]
+
+	LastRun   Change[sql.NullInt64]
+	NextRun   Change[sql.NullInt64]
+	LastJobId Change[sql.NullString]
+
+	Created Change[int64]
+}
+
+func (db DB) UpdateJobSchedule(ctx context.Context, id string, changes JobScheduleChanges) error {
+	record := goqu.Record{}
+
+	addToRecord(record, "name", changes.Name)
+
+	addToRecord(record, "type", changes.Type)
+	addToRecord(record, "payload", changes.Payload)
+	addToRecord(record, "max_attempts", changes.MaxAttempts)
+
+	addToRecord(record, "cron", changes.Cron)
+	addToRecord(record, "interval", changes.Interval)
+
+	addToRecord(record, "enabled", changes.Enabled)
+
+	addToRecord(record, "last_run", changes.LastRun)
+	addToRecord(record, "next_run", changes.NextRun)
+	addToRecord(record, "last_job_id", changes.LastJobId)
+
+	addToRecord(record, "created", changes.Created)
+
+	if len(record) == 0 {
+		return nil
+	}
+
+	record["updated"] = time.Now().UnixMilli()
+
+	query := dialect.Update("job_schedules").
+		Set(record).
+		Where(goqu.I("job_schedules.id").Eq(id))
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db DB) RemoveJobSchedule(ctx context.Context, id string) error {
+	query := dialect.Delete("job_schedules").
+		Where(goqu.I("job_schedules.id").Eq(id))
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
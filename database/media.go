@@ -57,6 +57,7 @@ type Media struct {
 
 	DefaultProvider sql.NullString `db:"default_provider"`
 	Providers       ember.KVStore  `db:"providers"`
+	FieldSources    ember.KVStore  `db:"field_sources"`
 
 	Created int64 `db:"created"`
 	Updated int64 `db:"updated"`
@@ -258,6 +259,7 @@ func MediaQuery(userId *string) *goqu.SelectDataset {
 
 			"media.default_provider",
 			"media.providers",
+			"media.field_sources",
 
 			"media.created",
 			"media.updated",
@@ -306,6 +308,10 @@ func (db DB) GetAllMediaIds(ctx context.Context) ([]string, error) {
 type FetchOptions struct {
 	PerPage int
 	Page    int
+
+	// NOTE(patrik): Default region for the availability filters, set from
+	// the user settings
+	Region string
 }
 
 func (db DB) GetPagedMedia(ctx context.Context, userId *string, filterStr, sortStr string, opts FetchOptions) ([]Media, types.Page, error) {
@@ -313,7 +319,9 @@ func (db DB) GetPagedMedia(ctx context.Context, userId *string, filterStr, sortS
 
 	var err error
 
-	a := adapter.MediaResolverAdapter{}
+	a := adapter.MediaResolverAdapter{
+		Region: opts.Region,
+	}
 	resolver := filter.New(&a)
 
 	query, err = applyFilter(query, resolver, filterStr)
@@ -369,6 +377,14 @@ func (db DB) GetAllMediaForUnknownUpdate(ctx context.Context) ([]Media, error) {
 	return ember.Multiple[Media](db.db, ctx, query)
 }
 
+func (db DB) GetAllMediaByStatus(ctx context.Context, statuses ...types.MediaStatus) ([]Media, error) {
+	query := MediaQuery(nil).
+		Where(
+			goqu.I("media.status").In(statuses),
+		)
+	return ember.Multiple[Media](db.db, ctx, query)
+}
+
 func (db DB) GetMediaById(ctx context.Context, userId *string, id string) (Media, error) {
 	query := MediaQuery(userId).
 		Where(goqu.I("media.id").Eq(id))
@@ -407,6 +423,7 @@ type CreateMediaParams struct {
 
 	DefaultProvider sql.NullString
 	Providers       ember.KVStore
+	FieldSources    ember.KVStore
 
 	Created int64
 	Updated int64
@@ -439,6 +456,10 @@ func (db DB) CreateMedia(ctx context.Context, params CreateMediaParams) (string,
 		params.Rating = types.MediaRatingUnknown
 	}
 
+	if params.FieldSources == nil {
+		params.FieldSources = ember.KVStore{}
+	}
+
 	query := dialect.Insert("media").Rows(goqu.Record{
 		"id":   id,
 		"type": params.Type,
@@ -460,6 +481,7 @@ func (db DB) CreateMedia(ctx context.Context, params CreateMediaParams) (string,
 
 		"default_provider": params.DefaultProvider,
 		"providers":        params.Providers,
+		"field_sources":    params.FieldSources,
 
 		"created": created,
 		"updated": updated,
@@ -489,6 +511,7 @@ type MediaChanges struct {
 
 	DefaultProvider Change[sql.NullString]
 	Providers       Change[ember.KVStore]
+	FieldSources    Change[ember.KVStore]
 
 	Created Change[int64]
 }
@@ -515,6 +538,7 @@ func (db DB) UpdateMedia(ctx context.Context, id string, changes MediaChanges) e
 
 	addToRecord(record, "default_provider", changes.DefaultProvider)
 	addToRecord(record, "providers", changes.Providers)
+	addToRecord(record, "field_sources", changes.FieldSources)
 
 	addToRecord(record, "created", changes.Created)
 
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/nanoteck137/pyrin/ember"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+type MediaAvailability struct {
+	MediaId string `db:"media_id"`
+
+	Region      string                 `db:"region"`
+	Type        types.AvailabilityType `db:"type"`
+	Service     string                 `db:"service"`
+	ServiceName string                 `db:"service_name"`
+
+	ProviderName string `db:"provider_name"`
+
+	Created int64 `db:"created"`
+	Updated int64 `db:"updated"`
+}
+
+func MediaAvailabilityQuery() *goqu.SelectDataset {
+	query := dialect.From("media_availability").
+		Select(
+			"media_availability.media_id",
+
+			"media_availability.region",
+			"media_availability.type",
+			"media_availability.service",
+			"media_availability.service_name",
+
+			"media_availability.provider_name",
+
+			"media_availability.created",
+			"media_availability.updated",
+		)
+
+	return query
+}
+
+// NOTE(patrik): region is optional, empty returns all the regions
+func (db DB) GetMediaAvailabilityByMediaId(ctx context.Context, mediaId, region string) ([]MediaAvailability, error) {
+	query := MediaAvailabilityQuery().
+		Where(goqu.I("media_availability.media_id").Eq(mediaId)).
+		Order(
+			goqu.I("media_availability.region").Asc(),
+			goqu.I("media_availability.type").Asc(),
+			goqu.I("media_availability.service_name").Asc(),
+		)
+
+	if region != "" {
+		query = query.Where(goqu.I("media_availability.region").Eq(region))
+	}
+
+	return ember.Multiple[MediaAvailability](db.db, ctx, query)
+}
+
+type CreateMediaAvailabilityParams struct {
+	MediaId string
+
+	Region      string
+	Type        types.AvailabilityType
+	Service     string
+	ServiceName string
+
+	ProviderName string
+
+	Created int64
+	Updated int64
+}
+
+func (db DB) CreateMediaAvailability(ctx context.Context, params CreateMediaAvailabilityParams) error {
+	t := time.Now().UnixMilli()
+	created := params.Created
+	updated := params.Updated
+
+	if created == 0 && updated == 0 {
+		created = t
+		updated = t
+	}
+
+	query := dialect.Insert("media_availability").Rows(goqu.Record{
+		"media_id": params.MediaId,
+
+		"region":       params.Region,
+		"type":         params.Type,
+		"service":      params.Service,
+		"service_name": params.ServiceName,
+
+		"provider_name": params.ProviderName,
+
+		"created": created,
+		"updated": updated,
+	})
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db DB) RemoveAllMediaAvailability(ctx context.Context, mediaId string) error {
+	query := dialect.Delete("media_availability").
+		Where(
+			goqu.I("media_availability.media_id").Eq(mediaId),
+		)
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/nanoteck137/pyrin/ember"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+// NOTE(patrik): Candidate images for the media, the files is stored inside
+// the images dir of the media next to the current cover/banner/logo
+type MediaImage struct {
+	MediaId string `db:"media_id"`
+	File    string `db:"file"`
+
+	Type         types.ImageType `db:"type"`
+	ProviderName string          `db:"provider_name"`
+
+	Created int64 `db:"created"`
+	Updated int64 `db:"updated"`
+}
+
+func MediaImageQuery() *goqu.SelectDataset {
+	query := dialect.From("media_images").
+		Select(
+			"media_images.media_id",
+			"media_images.file",
+
+			"media_images.type",
+			"media_images.provider_name",
+
+			"media_images.created",
+			"media_images.updated",
+		)
+
+	return query
+}
+
+func (db DB) GetMediaImagesByMediaId(ctx context.Context, mediaId string) ([]MediaImage, error) {
+	query := MediaImageQuery().
+		Where(goqu.I("media_images.media_id").Eq(mediaId)).
+		Order(
+			goqu.I("media_images.type").Asc(),
+			goqu.I("media_images.created").Asc(),
+		)
+
+	return ember.Multiple[MediaImage](db.db, ctx, query)
+}
+
+func (db DB) GetMediaImage(ctx context.Context, mediaId, file string) (MediaImage, error) {
+	query := MediaImageQuery().
+		Where(
+			goqu.I("media_images.media_id").Eq(mediaId),
+			goqu.I("media_images.file").Eq(file),
+		)
+
+	return ember.Single[MediaImage](db.db, ctx, query)
+}
+
+type CreateMediaImageParams struct {
+	MediaId string
+	File    string
+
+	Type         types.ImageType
+	ProviderName string
+
+	Created int64
+	Updated int64
+}
+
+func (db DB) CreateMediaImage(ctx context.Context, params CreateMediaImageParams) error {
+	t := time.Now().UnixMilli()
+	created := params.Created
+	updated := params.Updated
+
+	if created == 0 && updated == 0 {
+		created = t
+		updated = t
+	}
+
+	query := dialect.Insert("media_images").Rows(goqu.Record{
+		"media_id": params.MediaId,
+		"file":     params.File,
+
+		"type":          params.Type,
+		"provider_name": params.ProviderName,
+
+		"created": created,
+		"updated": updated,
+	})
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db DB) RemoveMediaImage(ctx context.Context, mediaId, file string) error {
+	query := dialect.Delete("media_images").
+		Where(
+			goqu.I("media_images.media_id").Eq(mediaId),
+			goqu.I("media_images.file").Eq(file),
+		)
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/nanoteck137/pyrin/ember"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+type MediaRelation struct {
+	RowId int `db:"rowid"`
+
+	MediaId string `db:"media_id"`
+
+	ProviderName string `db:"provider_name"`
+	ProviderId   string `db:"provider_id"`
+
+	Type  types.MediaRelationType `db:"type"`
+	Title string                  `db:"title"`
+
+	RelatedMediaId sql.NullString `db:"related_media_id"`
+
+	Created int64 `db:"created"`
+	Updated int64 `db:"updated"`
+
+	MediaTitle string `db:"media_title"`
+}
+
+func MediaRelationQuery() *goqu.SelectDataset {
+	query := dialect.From("media_relations").
+		Select(
+			"media_relations.rowid",
+
+			"media_relations.media_id",
+
+			"media_relations.provider_name",
+			"media_relations.provider_id",
+
+			"media_relations.type",
+			"media_relations.title",
+
+			"media_relations.related_media_id",
+
+			"media_relations.created",
+			"media_relations.updated",
+
+			goqu.I("media.title").As("media_title"),
+		).
+		Join(
+			goqu.I("media"),
+			goqu.On(goqu.I("media_relations.media_id").Eq(goqu.I("media.id"))),
+		)
+
+	return query
+}
+
+func (db DB) GetMediaRelationsByMediaId(ctx context.Context, mediaId string) ([]MediaRelation, error) {
+	query := MediaRelationQuery().
+		Where(goqu.I("media_relations.media_id").Eq(mediaId)).
+		Order(goqu.I("media_relations.rowid").Asc())
+
+	return ember.Multiple[MediaRelation](db.db, ctx, query)
+}
+
+// NOTE(patrik): Returns the relations that points to entries not inside the
+// library, relationTypes is optional
+func (db DB) GetMissingMediaRelations(ctx context.Context, relationTypes []types.MediaRelationType) ([]MediaRelation, error) {
+	query := MediaRelationQuery().
+		Where(goqu.I("media_relations.related_media_id").IsNull()).
+		Order(
+			goqu.I("media.title").Asc(),
+			goqu.I("media_relations.rowid").Asc(),
+		)
+
+	if len(relationTypes) > 0 {
+		query = query.Where(goqu.I("media_relations.type").In(relationTypes))
+	}
+
+	return ember.Multiple[MediaRelation](db.db, ctx, query)
+}
+
+type CreateMediaRelationParams struct {
+	MediaId string
+
+	ProviderName string
+	ProviderId   string
+
+	Type  types.MediaRelationType
+	Title string
+
+	RelatedMediaId sql.NullString
+
+	Created int64
+	Updated int64
+}
+
+func (db DB) CreateMediaRelation(ctx context.Context, params CreateMediaRelationParams) error {
+	t := time.Now().UnixMilli()
+	created := params.Created
+	updated := params.Updated
+
+	if created == 0 && updated == 0 {
+		created = t
+		updated = t
+	}
+
+	query := dialect.Insert("media_relations").Rows(goqu.Record{
+		"media_id": params.MediaId,
+
+		"provider_name": params.ProviderName,
+		"provider_id":   params.ProviderId,
+
+		"type":  params.Type,
+		"title": params.Title,
+
+		"related_media_id": params.RelatedMediaId,
+
+		"created": created,
+		"updated": updated,
+	})
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// NOTE(patrik): Points all the unresolved relations to the provider id at
+// the media, used when a media is added to the library
+func (db DB) LinkMediaRelations(ctx context.Context, providerName, providerId, mediaId string) error {
+	query := dialect.Update("media_relations").
+		Set(goqu.Record{
+			"related_media_id": mediaId,
+			"updated":          time.Now().UnixMilli(),
+		}).
+		Where(
+			goqu.I("media_relations.provider_name").Eq(providerName),
+			goqu.I("media_relations.provider_id").Eq(providerId),
+			goqu.I("media_relations.related_media_id").IsNull(),
+			goqu.I("media_relations.media_id").Neq(mediaId),
+		)
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db DB) RemoveAllMediaRelations(ctx context.Context, mediaId string) error {
+	query := dialect.Delete("media_relations").
+		Where(
+			goqu.I("media_relations.media_id").Eq(mediaId),
+		)
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
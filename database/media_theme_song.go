@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/nanoteck137/pyrin/ember"
+	"github.com/nanoteck137/watchbook/types"
+	"github.com/nanoteck137/watchbook/utils"
+)
+
+type MediaThemeSong struct {
+	RowId int `db:"rowid"`
+
+	MediaId string              `db:"media_id"`
+	Type    types.ThemeSongType `db:"type"`
+	Index   int64               `db:"idx"`
+
+	Name       string `db:"name"`
+	Artist     string `db:"artist"`
+	ArtistSlug string `db:"artist_slug"`
+
+	Created int64 `db:"created"`
+	Updated int64 `db:"updated"`
+}
+
+func MediaThemeSongQuery() *goqu.SelectDataset {
+	query := dialect.From("media_theme_songs").
+		Select(
+			"media_theme_songs.rowid",
+
+			"media_theme_songs.media_id",
+			"media_theme_songs.type",
+			"media_theme_songs.idx",
+
+			"media_theme_songs.name",
+			"media_theme_songs.artist",
+			"media_theme_songs.artist_slug",
+
+			"media_theme_songs.created",
+			"media_theme_songs.updated",
+		)
+
+	return query
+}
+
+func (db DB) GetMediaThemeSongsByMediaId(ctx context.Context, mediaId string) ([]MediaThemeSong, error) {
+	query := MediaThemeSongQuery().
+		Where(goqu.I("media_theme_songs.media_id").Eq(mediaId)).
+		// NOTE(patrik): Openings before endings
+		Order(
+			goqu.I("media_theme_songs.type").Desc(),
+			goqu.I("media_theme_songs.idx").Asc(),
+		)
+
+	return ember.Multiple[MediaThemeSong](db.db, ctx, query)
+}
+
+type CreateMediaThemeSongParams struct {
+	MediaId string
+	Type    types.ThemeSongType
+	Index   int64
+
+	Name   string
+	Artist string
+
+	Created int64
+	Updated int64
+}
+
+func (db DB) CreateMediaThemeSong(ctx context.Context, params CreateMediaThemeSongParams) error {
+	t := time.Now().UnixMilli()
+	created := params.Created
+	updated := params.Updated
+
+	if created == 0 && updated == 0 {
+		created = t
+		updated = t
+	}
+
+	query := dialect.Insert("media_theme_songs").Rows(goqu.Record{
+		"media_id": params.MediaId,
+		"type":     params.Type,
+		"idx":      params.Index,
+
+		"name":        params.Name,
+		"artist":      params.Artist,
+		"artist_slug": utils.Slug(params.Artist),
+
+		"created": created,
+		"updated": updated,
+	})
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db DB) RemoveAllMediaThemeSongs(ctx context.Context, mediaId string) error {
+	query := dialect.Delete("media_theme_songs").
+		Where(
+			goqu.I("media_theme_songs.media_id").Eq(mediaId),
+		)
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
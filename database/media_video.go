@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/nanoteck137/pyrin/ember"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+type MediaVideo struct {
+	MediaId string `db:"media_id"`
+
+	Site types.VideoSite `db:"site"`
+	Key  string          `db:"key"`
+
+	Type     types.VideoType `db:"type"`
+	Name     string          `db:"name"`
+	Language string          `db:"language"`
+	Official bool            `db:"official"`
+
+	Position int `db:"position"`
+
+	Created int64 `db:"created"`
+	Updated int64 `db:"updated"`
+}
+
+func MediaVideoQuery() *goqu.SelectDataset {
+	query := dialect.From("media_videos").
+		Select(
+			"media_videos.media_id",
+
+			"media_videos.site",
+			"media_videos.key",
+
+			"media_videos.type",
+			"media_videos.name",
+			"media_videos.language",
+			"media_videos.official",
+
+			"media_videos.position",
+
+			"media_videos.created",
+			"media_videos.updated",
+		)
+
+	return query
+}
+
+func (db DB) GetMediaVideosByMediaId(ctx context.Context, mediaId string) ([]MediaVideo, error) {
+	query := MediaVideoQuery().
+		Where(goqu.I("media_videos.media_id").Eq(mediaId)).
+		Order(goqu.I("media_videos.position").Asc())
+
+	return ember.Multiple[MediaVideo](db.db, ctx, query)
+}
+
+type CreateMediaVideoParams struct {
+	MediaId string
+
+	Site types.VideoSite
+	Key  string
+
+	Type     types.VideoType
+	Name     string
+	Language string
+	Official bool
+
+	Position int
+
+	Created int64
+	Updated int64
+}
+
+func (db DB) CreateMediaVideo(ctx context.Context, params CreateMediaVideoParams) error {
+	t := time.Now().UnixMilli()
+	created := params.Created
+	updated := params.Updated
+
+	if created == 0 && updated == 0 {
+		created = t
+		updated = t
+	}
+
+	query := dialect.Insert("media_videos").Rows(goqu.Record{
+		"media_id": params.MediaId,
+
+		"site": params.Site,
+		"key":  params.Key,
+
+		"type":     params.Type,
+		"name":     params.Name,
+		"language": params.Language,
+		"official": params.Official,
+
+		"position": params.Position,
+
+		"created": created,
+		"updated": updated,
+	})
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db DB) RemoveAllMediaVideos(ctx context.Context, mediaId string) error {
+	query := dialect.Delete("media_videos").
+		Where(
+			goqu.I("media_videos.media_id").Eq(mediaId),
+		)
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE media ADD COLUMN field_sources TEXT NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE media DROP COLUMN field_sources;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE media_theme_songs (
+    media_id TEXT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    idx INTEGER NOT NULL,
+
+    name TEXT NOT NULL,
+    artist TEXT NOT NULL,
+    artist_slug TEXT NOT NULL,
+
+    created INTEGER NOT NULL,
+    updated INTEGER NOT NULL,
+
+    PRIMARY KEY(media_id, type, idx)
+);
+
+CREATE INDEX media_theme_songs_artist_slug_idx ON media_theme_songs(artist_slug);
+
+-- +goose Down
+DROP INDEX media_theme_songs_artist_slug_idx;
+DROP TABLE media_theme_songs;
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE media_relations (
+    media_id TEXT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+
+    provider_name TEXT NOT NULL,
+    provider_id TEXT NOT NULL,
+
+    type TEXT NOT NULL,
+    title TEXT NOT NULL,
+
+    related_media_id TEXT REFERENCES media(id) ON DELETE SET NULL,
+
+    created INTEGER NOT NULL,
+    updated INTEGER NOT NULL,
+
+    PRIMARY KEY(media_id, provider_name, provider_id)
+);
+
+CREATE INDEX media_relations_provider_idx ON media_relations(provider_name, provider_id);
+
+-- +goose Down
+DROP INDEX media_relations_provider_idx;
+DROP TABLE media_relations;
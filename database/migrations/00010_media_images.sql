@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE media_images (
+    media_id TEXT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    file TEXT NOT NULL,
+
+    type TEXT NOT NULL,
+    provider_name TEXT NOT NULL,
+
+    created INTEGER NOT NULL,
+    updated INTEGER NOT NULL,
+
+    PRIMARY KEY(media_id, file)
+);
+
+-- +goose Down
+DROP TABLE media_images;
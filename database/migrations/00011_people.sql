@@ -0,0 +1,45 @@
+-- +goose Up
+CREATE TABLE people (
+    id TEXT PRIMARY KEY,
+
+    type TEXT NOT NULL,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL,
+
+    providers TEXT,
+
+    created INTEGER NOT NULL,
+    updated INTEGER NOT NULL
+);
+
+CREATE INDEX people_slug_idx ON people(slug);
+
+CREATE TABLE media_people (
+    media_id TEXT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    person_id TEXT NOT NULL REFERENCES people(id) ON DELETE CASCADE,
+
+    role TEXT NOT NULL,
+    character TEXT NOT NULL DEFAULT '',
+
+    -- NOTE(patrik): Copy of people.slug so the filter can lookup the media
+    -- without joining the people table
+    person_slug TEXT NOT NULL,
+
+    position INTEGER NOT NULL,
+
+    created INTEGER NOT NULL,
+    updated INTEGER NOT NULL,
+
+    PRIMARY KEY(media_id, person_id, role, character)
+);
+
+CREATE INDEX media_people_person_id_idx ON media_people(person_id);
+CREATE INDEX media_people_person_slug_idx ON media_people(person_slug);
+
+-- +goose Down
+DROP INDEX media_people_person_slug_idx;
+DROP INDEX media_people_person_id_idx;
+DROP TABLE media_people;
+
+DROP INDEX people_slug_idx;
+DROP TABLE people;
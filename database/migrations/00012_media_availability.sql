@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE media_availability (
+    media_id TEXT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+
+    region TEXT NOT NULL,
+    type TEXT NOT NULL,
+    service TEXT NOT NULL,
+    service_name TEXT NOT NULL,
+
+    provider_name TEXT NOT NULL,
+
+    created INTEGER NOT NULL,
+    updated INTEGER NOT NULL,
+
+    PRIMARY KEY(media_id, region, type, service)
+);
+
+CREATE INDEX media_availability_service_idx ON media_availability(service);
+
+ALTER TABLE users_settings ADD COLUMN region TEXT;
+
+-- +goose Down
+ALTER TABLE users_settings DROP COLUMN region;
+
+DROP INDEX media_availability_service_idx;
+DROP TABLE media_availability;
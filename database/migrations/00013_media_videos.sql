@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE media_videos (
+    media_id TEXT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+
+    site TEXT NOT NULL,
+    key TEXT NOT NULL,
+
+    type TEXT NOT NULL,
+    name TEXT NOT NULL,
+    language TEXT NOT NULL,
+    official INTEGER NOT NULL,
+
+    position INTEGER NOT NULL,
+
+    created INTEGER NOT NULL,
+    updated INTEGER NOT NULL,
+
+    PRIMARY KEY(media_id, site, key)
+);
+
+-- +goose Down
+DROP TABLE media_videos;
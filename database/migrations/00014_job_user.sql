@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE jobs ADD COLUMN user_id TEXT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX jobs_user_id_idx ON jobs (user_id);
+
+-- +goose Down
+DROP INDEX jobs_user_id_idx;
+
+ALTER TABLE jobs DROP COLUMN user_id;
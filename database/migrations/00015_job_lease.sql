@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE jobs ADD COLUMN worker_id TEXT;
+ALTER TABLE jobs ADD COLUMN heartbeat INTEGER;
+ALTER TABLE jobs ADD COLUMN lease_expires INTEGER;
+
+CREATE INDEX jobs_status_lease_expires_idx ON jobs (status, lease_expires);
+
+-- +goose Down
+DROP INDEX jobs_status_lease_expires_idx;
+
+ALTER TABLE jobs DROP COLUMN lease_expires;
+ALTER TABLE jobs DROP COLUMN heartbeat;
+ALTER TABLE jobs DROP COLUMN worker_id;
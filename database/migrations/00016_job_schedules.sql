@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE TABLE job_schedules (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+
+    type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    max_attempts INTEGER NOT NULL,
+
+    cron TEXT,         -- cron expression (0 4 * * *)
+    interval INTEGER,  -- milliseconds, used when cron is not set
+
+    enabled BOOLEAN NOT NULL,
+    from_config BOOLEAN NOT NULL, -- managed by the config file
+
+    last_run INTEGER,
+    next_run INTEGER,
+    last_job_id TEXT REFERENCES jobs(id) ON DELETE SET NULL,
+
+    created INTEGER NOT NULL,
+    updated INTEGER NOT NULL
+);
+
+CREATE INDEX job_schedules_enabled_next_run_idx ON job_schedules (enabled, next_run);
+
+-- +goose Down
+DROP INDEX job_schedules_enabled_next_run_idx;
+DROP TABLE job_schedules;
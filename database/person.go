@@ -0,0 +1,321 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/nanoteck137/pyrin/ember"
+	"github.com/nanoteck137/watchbook/types"
+	"github.com/nanoteck137/watchbook/utils"
+)
+
+type Person struct {
+	Id string `db:"id"`
+
+	Type types.PersonType `db:"type"`
+	Name string           `db:"name"`
+	Slug string           `db:"slug"`
+
+	Providers ember.KVStore `db:"providers"`
+
+	Created int64 `db:"created"`
+	Updated int64 `db:"updated"`
+}
+
+func PersonQuery() *goqu.SelectDataset {
+	query := dialect.From("people").
+		Select(
+			"people.id",
+
+			"people.type",
+			"people.name",
+			"people.slug",
+
+			"people.providers",
+
+			"people.created",
+			"people.updated",
+		)
+
+	return query
+}
+
+func (db DB) GetPersonById(ctx context.Context, id string) (Person, error) {
+	query := PersonQuery().
+		Where(goqu.I("people.id").Eq(id))
+
+	return ember.Single[Person](db.db, ctx, query)
+}
+
+func (db DB) GetPersonByProviderId(ctx context.Context, providerName, value string) (Person, error) {
+	query := PersonQuery().
+		Where(
+			goqu.Func("json_extract", goqu.I("people.providers"), "$."+providerName).Eq(value),
+		)
+
+	return ember.Single[Person](db.db, ctx, query)
+}
+
+// NOTE(patrik): Used to match the credits that don't have any provider id
+// (studios from MyAnimeList), returns the first created person with the slug
+func (db DB) GetPersonBySlug(ctx context.Context, typ types.PersonType, slug string) (Person, error) {
+	query := PersonQuery().
+		Where(
+			goqu.I("people.type").Eq(typ),
+			goqu.I("people.slug").Eq(slug),
+		).
+		Order(goqu.I("people.created").Asc()).
+		Limit(1)
+
+	return ember.Single[Person](db.db, ctx, query)
+}
+
+type CreatePersonParams struct {
+	Id string
+
+	Type types.PersonType
+	Name string
+	Slug string
+
+	Providers ember.KVStore
+
+	Created int64
+	Updated int64
+}
+
+func (db DB) CreatePerson(ctx context.Context, params CreatePersonParams) (string, error) {
+	t := time.Now().UnixMilli()
+	created := params.Created
+	updated := params.Updated
+
+	if created == 0 && updated == 0 {
+		created = t
+		updated = t
+	}
+
+	id := params.Id
+	if id == "" {
+		id = utils.CreatePersonId()
+	}
+
+	if params.Type == "" {
+		params.Type = types.PersonTypePerson
+	}
+
+	if params.Slug == "" {
+		params.Slug = utils.Slug(params.Name)
+	}
+
+	if params.Providers == nil {
+		params.Providers = ember.KVStore{}
+	}
+
+	query := dialect.Insert("people").Rows(goqu.Record{
+		"id": id,
+
+		"type": params.Type,
+		"name": params.Name,
+		"slug": params.Slug,
+
+		"providers": params.Providers,
+
+		"created": created,
+		"updated": updated,
+	}).
+		Returning("id")
+
+	return ember.Single[string](db.db, ctx, query)
+}
+
+type PersonChanges struct {
+	Name      Change[string]
+	Providers Change[ember.KVStore]
+
+	Created Change[int64]
+}
+
+func (db DB) UpdatePerson(ctx context.Context, id string, changes PersonChanges) error {
+	record := goqu.Record{}
+
+	addToRecord(record, "name", changes.Name)
+	if changes.Name.Changed {
+		record["slug"] = utils.Slug(changes.Name.Value)
+	}
+
+	addToRecord(record, "providers", changes.Providers)
+
+	addToRecord(record, "created", changes.Created)
+
+	if len(record) == 0 {
+		return nil
+	}
+
+	record["updated"] = time.Now().UnixMilli()
+
+	query := dialect.Update("people").
+		Set(record).
+		Where(goqu.I("people.id").Eq(id))
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	// NOTE(patrik): media_people has a copy of the slug used by the media
+	// filters, keep it in sync with the new name. Database.UpdatePerson runs
+	// this in a transaction.
+	if slug, ok := record["slug"]; ok {
+		query := dialect.Update("media_people").
+			Set(goqu.Record{
+				"person_slug": slug,
+			}).
+			Where(goqu.I("media_people.person_id").Eq(id))
+
+		_, err := db.db.Exec(ctx, query)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NOTE(patrik): Runs the update in a transaction so the slug copy in
+// media_people never gets out of sync with the person
+func (db *Database) UpdatePerson(ctx context.Context, id string, changes PersonChanges) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.UpdatePerson(ctx, id, changes)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type MediaPerson struct {
+	MediaId  string `db:"media_id"`
+	PersonId string `db:"person_id"`
+
+	Role      types.PersonRole `db:"role"`
+	Character string           `db:"character"`
+
+	Position int `db:"position"`
+
+	PersonType types.PersonType `db:"person_type"`
+	PersonName string           `db:"person_name"`
+
+	Created int64 `db:"created"`
+	Updated int64 `db:"updated"`
+}
+
+func MediaPersonQuery() *goqu.SelectDataset {
+	query := dialect.From("media_people").
+		Select(
+			"media_people.media_id",
+			"media_people.person_id",
+
+			"media_people.role",
+			"media_people.character",
+
+			"media_people.position",
+
+			goqu.I("people.type").As("person_type"),
+			goqu.I("people.name").As("person_name"),
+
+			"media_people.created",
+			"media_people.updated",
+		).
+		Join(
+			goqu.I("people"),
+			goqu.On(goqu.I("media_people.person_id").Eq(goqu.I("people.id"))),
+		)
+
+	return query
+}
+
+func (db DB) GetMediaPeopleByMediaId(ctx context.Context, mediaId string) ([]MediaPerson, error) {
+	query := MediaPersonQuery().
+		Where(goqu.I("media_people.media_id").Eq(mediaId)).
+		Order(goqu.I("media_people.position").Asc())
+
+	return ember.Multiple[MediaPerson](db.db, ctx, query)
+}
+
+func (db DB) GetMediaPeopleByPersonId(ctx context.Context, personId string) ([]MediaPerson, error) {
+	query := MediaPersonQuery().
+		Where(goqu.I("media_people.person_id").Eq(personId)).
+		Order(
+			goqu.I("media_people.media_id").Asc(),
+			goqu.I("media_people.position").Asc(),
+		)
+
+	return ember.Multiple[MediaPerson](db.db, ctx, query)
+}
+
+type CreateMediaPersonParams struct {
+	MediaId  string
+	PersonId string
+
+	Role      types.PersonRole
+	Character string
+
+	PersonSlug string
+
+	Position int
+
+	Created int64
+	Updated int64
+}
+
+func (db DB) CreateMediaPerson(ctx context.Context, params CreateMediaPersonParams) error {
+	t := time.Now().UnixMilli()
+	created := params.Created
+	updated := params.Updated
+
+	if created == 0 && updated == 0 {
+		created = t
+		updated = t
+	}
+
+	query := dialect.Insert("media_people").Rows(goqu.Record{
+		"media_id":  params.MediaId,
+		"person_id": params.PersonId,
+
+		"role":      params.Role,
+		"character": params.Character,
+
+		"person_slug": params.PersonSlug,
+
+		"position": params.Position,
+
+		"created": created,
+		"updated": updated,
+	})
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db DB) RemoveAllMediaPeople(ctx context.Context, mediaId string) error {
+	query := dialect.Delete("media_people").
+		Where(
+			goqu.I("media_people.media_id").Eq(mediaId),
+		)
+
+	_, err := db.db.Exec(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 type UserSettings struct {
 	Id          string         `db:"id"`
 	DisplayName sql.NullString `db:"display_name"`
+	Region      sql.NullString `db:"region"`
 }
 
 type User struct {
@@ -26,12 +27,14 @@ type User struct {
 
 	// NOTE(patrik): This needs to match UserSettings
 	DisplayName sql.NullString `db:"display_name"`
+	Region      sql.NullString `db:"region"`
 }
 
 func (u User) ToUserSettings() UserSettings {
 	return UserSettings{
 		Id:          u.Id,
 		DisplayName: u.DisplayName,
+		Region:      u.Region,
 	}
 }
 
@@ -48,6 +51,7 @@ func UserQuery() *goqu.SelectDataset {
 			"users.updated",
 
 			"users_settings.display_name",
+			"users_settings.region",
 		).
 		LeftJoin(
 			goqu.I("users_settings"),
@@ -62,6 +66,7 @@ func UserSettingsQuery() *goqu.SelectDataset {
 		Select(
 			"users_settings.id",
 			"users_settings.display_name",
+			"users_settings.region",
 		)
 
 	return query
@@ -173,9 +178,11 @@ func (db DB) UpdateUserSettings(ctx context.Context, settings UserSettings) erro
 		Rows(goqu.Record{
 			"id":           settings.Id,
 			"display_name": settings.DisplayName,
+			"region":       settings.Region,
 		}).
 		OnConflict(goqu.DoUpdate("id", goqu.Record{
 			"display_name": settings.DisplayName,
+			"region":       settings.Region,
 		}))
 
 	_, err := db.db.Exec(ctx, query)
@@ -43,11 +43,17 @@ type Table struct {
 	WhereName  string
 }
 
+type TableCondition struct {
+	Column string
+	Value  string
+}
+
 type InTableExpr struct {
 	Not        bool
 	IdSelector string
 	Table      Table
 	Ids        []string
+	Conditions []TableCondition
 }
 
 type InExpr struct {
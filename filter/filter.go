@@ -183,6 +183,42 @@ func (r *Resolver) InTable(name, typ, idSelector string, args []ast.Expr) (*InTa
 	}, nil
 }
 
+// NOTE(patrik): Same as InTable but only takes one name, the rest of the
+// parameters is optional and matched against the columns of the table, used
+// for functions like hasPerson("name", "director")
+func (r *Resolver) InTableWhere(name, typ, idSelector string, columns []string, args []ast.Expr) (*InTableExpr, error) {
+	if len(args) <= 0 {
+		return nil, fmt.Errorf("'%s' requires at least 1 parameter", name)
+	}
+
+	if len(args) > len(columns)+1 {
+		return nil, fmt.Errorf("'%s' takes at most %d parameters", name, len(columns)+1)
+	}
+
+	expr, err := r.InTable(name, typ, idSelector, args[:1])
+	if err != nil {
+		return nil, err
+	}
+
+	for i, arg := range args[1:] {
+		s, err := r.ResolveToStr(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if s == "" {
+			continue
+		}
+
+		expr.Conditions = append(expr.Conditions, TableCondition{
+			Column: columns[i],
+			Value:  s,
+		})
+	}
+
+	return expr, nil
+}
+
 func (r *Resolver) In(name, variable string, args []ast.Expr) (*InExpr, error) {
 	if len(args) <= 0 {
 		return nil, fmt.Errorf("'%s' requires at least 1 parameter", name)
@@ -6,66 +6,436 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/nanoteck137/watchbook/database"
 	"github.com/nanoteck137/watchbook/types"
+	"github.com/nanoteck137/watchbook/utils"
 )
 
+const (
+	// NOTE(patrik): How long a worker owns a running job, the worker
+	// extends the lease with a heartbeat while the handler is running
+	defaultLeaseDuration     = 2 * time.Minute
+	defaultHeartbeatInterval = 30 * time.Second
+
+	// NOTE(patrik): Used when the job type doesn't declare a timeout
+	defaultJobTimeout = 30 * time.Minute
+
+	// NOTE(patrik): How often the schedules is checked for runs, the cron
+	// schedules only has minute precision
+	defaultScheduleCheckInterval = 30 * time.Second
+
+	// NOTE(patrik): How long Stop waits for the cancelled handlers to return
+	// before the jobs are queued again from under them
+	stopGracePeriod = 5 * time.Second
+)
+
+var (
+	errJobAbandoned = errors.New("job was abandoned by the worker (lease expired)")
+
+	// NOTE(patrik): Causes for why the context of a running job was
+	// cancelled
+	errJobCancelled     = errors.New("job was cancelled")
+	errJobTimeout       = errors.New("job timed out")
+	errJobLost          = errors.New("worker lost the job")
+	errProcessorStopped = errors.New("job processor was stopped")
+)
+
+// NOTE(patrik): Returned when queueing a job after Stop has been called
+var ErrStopping = errors.New("job processor is stopping")
+
 type JobHandler func(ctx context.Context, job database.Job) error
 
+type jobType struct {
+	handler JobHandler
+	timeout time.Duration
+}
+
+type runningJob struct {
+	job      *database.Job
+	workerId string
+	cancel   context.CancelCauseFunc
+}
+
 type JobProcessor struct {
 	db       *database.Database
-	handlers map[string]JobHandler
+	handlers map[string]jobType
+
+	// NOTE(patrik): Unique for every run of the server, used to create the
+	// worker ids
+	id string
+
+	LeaseDuration         time.Duration
+	HeartbeatInterval     time.Duration
+	ScheduleCheckInterval time.Duration
+
+	mutex   sync.Mutex
+	running map[string]runningJob
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	workers  sync.WaitGroup
 }
 
 func NewJobProcessor(db *database.Database) *JobProcessor {
+	hostname, _ := os.Hostname()
+
 	return &JobProcessor{
-		db:       db,
-		handlers: make(map[string]JobHandler),
+		db:                    db,
+		handlers:              make(map[string]jobType),
+		id:                    fmt.Sprintf("%s:%d:%s", hostname, os.Getpid(), utils.CreateSmallId()),
+		LeaseDuration:         defaultLeaseDuration,
+		HeartbeatInterval:     defaultHeartbeatInterval,
+		ScheduleCheckInterval: defaultScheduleCheckInterval,
+		running:               make(map[string]runningJob),
+		stop:                  make(chan struct{}),
 	}
 }
 
-func (p *JobProcessor) RegisterHandler(jobType string, handler JobHandler) {
-	p.handlers[jobType] = handler
+func (p *JobProcessor) HasHandler(name string) bool {
+	_, ok := p.handlers[name]
+	return ok
+}
+
+// NOTE(patrik): The handler is cancelled when the job has been running for
+// longer than the timeout, 0 uses the default timeout
+func (p *JobProcessor) RegisterHandler(name string, timeout time.Duration, handler JobHandler) {
+	if timeout <= 0 {
+		timeout = defaultJobTimeout
+	}
+
+	p.handlers[name] = jobType{
+		handler: handler,
+		timeout: timeout,
+	}
 }
 
 func (p *JobProcessor) Start(workerCount int) {
-	for range workerCount {
-		go p.workerLoop()
+	// NOTE(patrik): Only one server runs on the database so all the
+	// running jobs was abandoned when the server was stopped, recover them
+	// without waiting for the leases to expire
+	recovered, err := p.recoverJobs(context.Background(), math.MaxInt64)
+	if err != nil {
+		slog.Error("failed to recover abandoned jobs", "err", err)
+	} else if recovered > 0 {
+		slog.Info("recovered abandoned jobs", "count", recovered)
+	}
+
+	go p.recoveryLoop()
+	go p.scheduleLoop()
+
+	p.workers.Add(workerCount)
+	for i := range workerCount {
+		go func() {
+			defer p.workers.Done()
+			p.workerLoop(fmt.Sprintf("%s:%d", p.id, i))
+		}()
+	}
+}
+
+// NOTE(patrik): Stops fetching new jobs and waits for the running jobs to
+// finish, when the context is done before that the running jobs are
+// cancelled and queued again so they can run on the next start. The workers
+// queue their own job when the handler returns, the jobs where the handler
+// doesn't return within the grace period is queued by Stop.
+func (p *JobProcessor) Stop(ctx context.Context) error {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	p.mutex.Lock()
+	running := make([]runningJob, 0, len(p.running))
+	for _, r := range p.running {
+		running = append(running, r)
+	}
+	p.mutex.Unlock()
+
+	for _, r := range running {
+		r.cancel(errProcessorStopped)
+	}
+
+	select {
+	case <-done:
+		return ctx.Err()
+	case <-time.After(stopGracePeriod):
 	}
+
+	p.mutex.Lock()
+	running = running[:0]
+	for _, r := range p.running {
+		running = append(running, r)
+	}
+	p.mutex.Unlock()
+
+	for _, r := range running {
+		slog.Warn("job didn't stop in time", "id", r.job.Id)
+		p.requeue(r.job, r.workerId)
+	}
+
+	return ctx.Err()
+}
+
+// NOTE(patrik): Cancels the handler if the job is running on this
+// processor, the status of the job should be updated before this is called.
+// Returns false if the job isn't running.
+func (p *JobProcessor) Cancel(jobId string) bool {
+	p.mutex.Lock()
+	r, ok := p.running[jobId]
+	p.mutex.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	r.cancel(errJobCancelled)
+	return true
+}
+
+// NOTE(patrik): New jobs should not be queued after this returns true, the
+// server is shutting down
+func (p *JobProcessor) IsStopping() bool {
+	return p.isStopped()
+}
+
+func (p *JobProcessor) isStopped() bool {
+	select {
+	case <-p.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// NOTE(patrik): Returns false if the processor was stopped while waiting
+func (p *JobProcessor) wait(d time.Duration) bool {
+	select {
+	case <-p.stop:
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// NOTE(patrik): Requeues the running jobs where the lease has expired, the
+// jobs that has used all the attempts is marked as failed. Returns the
+// number of recovered jobs.
+func (p *JobProcessor) RecoverAbandonedJobs(ctx context.Context) (int, error) {
+	return p.recoverJobs(ctx, time.Now().UnixMilli())
 }
 
-func (p *JobProcessor) workerLoop() {
+func (p *JobProcessor) recoveryLoop() {
+	ticker := time.NewTicker(p.LeaseDuration)
+	defer ticker.Stop()
+
 	for {
-		job, err := p.fetchNextJob()
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+
+		recovered, err := p.RecoverAbandonedJobs(context.Background())
 		if err != nil {
-			time.Sleep(1 * time.Second)
+			slog.Error("failed to recover abandoned jobs", "err", err)
+			continue
+		}
+
+		if recovered > 0 {
+			slog.Info("recovered abandoned jobs", "count", recovered)
+		}
+	}
+}
+
+// NOTE(patrik): Recovers the running jobs with a lease that expired before
+// the time (unix milli)
+func (p *JobProcessor) recoverJobs(ctx context.Context, before int64) (int, error) {
+	jobs, err := p.db.GetExpiredJobs(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	recovered := 0
+
+	for _, job := range jobs {
+		// NOTE(patrik): The abandoned run counts as an attempt so a job
+		// that crashes the server doesn't run forever
+		attempts := job.Attempts + 1
+
+		changes := database.JobChanges{
+			Attempts: database.Change[int]{
+				Value:   attempts,
+				Changed: true,
+			},
+			Error: database.Change[sql.NullString]{
+				Value: sql.NullString{
+					String: errJobAbandoned.Error(),
+					Valid:  true,
+				},
+				Changed: true,
+			},
+		}
+		clearLease(&changes)
+
+		if attempts >= job.MaxAttempts {
+			changes.Status = database.Change[types.JobStatus]{
+				Value:   types.JobStatusFailed,
+				Changed: true,
+			}
+		} else {
+			changes.Status = database.Change[types.JobStatus]{
+				Value:   types.JobStatusQueued,
+				Changed: true,
+			}
+			changes.RunAt = database.Change[int64]{
+				Value:   time.Now().UnixMilli(),
+				Changed: true,
+			}
+		}
+
+		updated, err := p.db.UpdateExpiredJob(ctx, job.Id, before, changes)
+		if err != nil {
+			return recovered, err
+		}
+
+		if updated {
+			slog.Warn("recovered abandoned job", "id", job.Id, "type", job.Type, "workerId", job.WorkerId.String, "status", changes.Status.Value)
+			recovered++
+		}
+	}
+
+	return recovered, nil
+}
+
+func (p *JobProcessor) workerLoop(workerId string) {
+	for !p.isStopped() {
+		job, err := p.fetchNextJob(workerId)
+		if err != nil {
+			p.wait(1 * time.Second)
 			continue
 		}
 		if job == nil {
-			time.Sleep(500 * time.Millisecond)
+			p.wait(500 * time.Millisecond)
 			continue
 		}
 
-		handler, ok := p.handlers[job.Type]
-		if !ok {
-			slog.Error("No handler for job type", "type", job.Type)
-			p.markFailed(job, fmt.Errorf("no handler"))
-			continue
+		p.runJob(job, workerId)
+	}
+}
+
+func (p *JobProcessor) runJob(job *database.Job, workerId string) {
+	t, ok := p.handlers[job.Type]
+	if !ok {
+		slog.Error("No handler for job type", "type", job.Type)
+		p.markFailed(job, workerId, fmt.Errorf("no handler"))
+		return
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, t.timeout, errJobTimeout)
+
+	p.mutex.Lock()
+	p.running[job.Id] = runningJob{
+		job:      job,
+		workerId: workerId,
+		cancel:   cancel,
+	}
+	p.mutex.Unlock()
+
+	stopHeartbeat := p.startHeartbeat(job, workerId, cancel)
+
+	err := t.handler(ctx, *job)
+
+	stopHeartbeat()
+
+	cause := context.Cause(ctx)
+
+	cancelTimeout()
+	cancel(nil)
+
+	p.mutex.Lock()
+	delete(p.running, job.Id)
+	p.mutex.Unlock()
+
+	switch {
+	case errors.Is(cause, errJobCancelled):
+		slog.Info("job was cancelled", "id", job.Id)
+	case errors.Is(cause, errJobLost):
+		// NOTE(patrik): Someone else has already updated the job
+	case errors.Is(cause, errProcessorStopped) && err != nil:
+		p.requeue(job, workerId)
+	case err != nil:
+		if errors.Is(cause, errJobTimeout) {
+			err = fmt.Errorf("%w after %s: %w", errJobTimeout, t.timeout, err)
 		}
 
-		ctx := context.Background()
-		err = handler(ctx, *job)
-		if err != nil {
-			p.retryOrFail(job, err)
-		} else {
-			p.markSuccess(job)
+		p.retryOrFail(job, workerId, err)
+	default:
+		p.markSuccess(job, workerId)
+	}
+}
+
+// NOTE(patrik): Extends the lease of the job until the returned function is
+// called, cancel is called if the worker loses the job
+func (p *JobProcessor) startHeartbeat(job *database.Job, workerId string, cancel context.CancelCauseFunc) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(p.HeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				now := time.Now()
+
+				changes := database.JobChanges{}
+				setLease(&changes, workerId, now, p.LeaseDuration)
+
+				updated, err := p.db.UpdateJobForWorker(context.Background(), job.Id, workerId, changes)
+				if err != nil {
+					// NOTE(patrik): Try again on the next tick, the job is
+					// only lost when the lease expires
+					slog.Error("failed to extend job lease", "id", job.Id, "err", err)
+					continue
+				}
+
+				if !updated {
+					slog.Warn("worker lost the job, stopping the handler", "id", job.Id, "workerId", workerId)
+					cancel(errJobLost)
+					return
+				}
+			}
 		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
 	}
 }
 
-func (p *JobProcessor) fetchNextJob() (*database.Job, error) {
+func (p *JobProcessor) fetchNextJob(workerId string) (*database.Job, error) {
 	tx, err := p.db.Begin()
 	if err != nil {
 		return nil, err
@@ -81,34 +451,46 @@ func (p *JobProcessor) fetchNextJob() (*database.Job, error) {
 		return nil, err
 	}
 
-	err = tx.UpdateJob(context.Background(), job.Id, database.JobChanges{
+	changes := database.JobChanges{
 		Status: database.Change[types.JobStatus]{
 			Value:   types.JobStatusRunning,
 			Changed: true,
 		},
-	})
+	}
+	setLease(&changes, workerId, time.Now(), p.LeaseDuration)
+
+	// NOTE(patrik): Only claim the job if it's still queued, the job could
+	// have been cancelled after it was fetched
+	claimed, err := tx.UpdateJobWithStatus(context.Background(), job.Id, []types.JobStatus{types.JobStatusQueued}, changes)
 	if err != nil {
 		return nil, err
 	}
 
+	if !claimed {
+		return nil, nil
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
 
+	job.Status = types.JobStatusRunning
+	job.WorkerId = sql.NullString{String: workerId, Valid: true}
+
 	return &job, nil
 }
 
-func (p *JobProcessor) retryOrFail(job *database.Job, jobErr error) {
+func (p *JobProcessor) retryOrFail(job *database.Job, workerId string, jobErr error) {
 	job.Attempts++
 	if job.Attempts >= job.MaxAttempts {
-		p.markFailed(job, jobErr)
+		p.markFailed(job, workerId, jobErr)
 		return
 	}
 
 	backoff := time.Duration(job.Attempts*job.Attempts) * time.Minute
 
-	err := p.db.UpdateJob(context.Background(), job.Id, database.JobChanges{
+	changes := database.JobChanges{
 		Status: database.Change[types.JobStatus]{
 			Value:   types.JobStatusQueued,
 			Changed: true,
@@ -128,28 +510,61 @@ func (p *JobProcessor) retryOrFail(job *database.Job, jobErr error) {
 			},
 			Changed: true,
 		},
-	})
+	}
+	clearLease(&changes)
+
+	updated, err := p.db.UpdateJobForWorker(context.Background(), job.Id, workerId, changes)
 	if err != nil {
 		slog.Error("failed to update job retryOrFail", "id", job.Id, "err", err)
+	} else if !updated {
+		slog.Warn("worker lost the job, skipping retry", "id", job.Id, "workerId", workerId)
+	}
+}
+
+// NOTE(patrik): Queues the job again without using an attempt, used when
+// the job was stopped by the shutdown and not by the job itself
+func (p *JobProcessor) requeue(job *database.Job, workerId string) {
+	changes := database.JobChanges{
+		Status: database.Change[types.JobStatus]{
+			Value:   types.JobStatusQueued,
+			Changed: true,
+		},
+		RunAt: database.Change[int64]{
+			Value:   time.Now().UnixMilli(),
+			Changed: true,
+		},
+	}
+	clearLease(&changes)
+
+	updated, err := p.db.UpdateJobForWorker(context.Background(), job.Id, workerId, changes)
+	if err != nil {
+		slog.Error("failed to requeue job", "id", job.Id, "err", err)
+	} else if updated {
+		slog.Info("job was stopped and queued again", "id", job.Id)
 	}
 }
 
-func (p *JobProcessor) markSuccess(job *database.Job) {
-	err := p.db.UpdateJob(context.Background(), job.Id, database.JobChanges{
+func (p *JobProcessor) markSuccess(job *database.Job, workerId string) {
+	changes := database.JobChanges{
 		Status: database.Change[types.JobStatus]{
 			Value:   types.JobStatusSuccess,
 			Changed: true,
 		},
-	})
+	}
+	clearLease(&changes)
+
+	updated, err := p.db.UpdateJobForWorker(context.Background(), job.Id, workerId, changes)
 	if err != nil {
 		slog.Error("failed to mark job success", "err", err)
+	} else if !updated {
+		slog.Warn("worker lost the job, skipping success", "id", job.Id, "workerId", workerId)
 	} else {
 		slog.Info("job is marked success", "id", job.Id)
 	}
 }
 
-func (p *JobProcessor) markFailed(job *database.Job, jobErr error) {
-	err := p.db.UpdateJob(context.Background(), job.Id, database.JobChanges{
+func (p *JobProcessor) markFailed(job *database.Job, workerId string, jobErr error) {
+	changes := database.JobChanges{
 		Status: database.Change[types.JobStatus]{
 			Value:   types.JobStatusFailed,
 			Changed: true,
@@ -161,10 +576,43 @@ func (p *JobProcessor) markFailed(job *database.Job, jobErr error) {
 			},
 			Changed: true,
 		},
-	})
+	}
+	clearLease(&changes)
+
+	updated, err := p.db.UpdateJobForWorker(context.Background(), job.Id, workerId, changes)
 	if err != nil {
 		slog.Error("failed to mark job failed", "err", err)
+	} else if !updated {
+		slog.Warn("worker lost the job, skipping failure", "id", job.Id, "workerId", workerId)
 	} else {
 		slog.Error("job is marked failed", "id", job.Id, "err", jobErr)
 	}
 }
+
+func setLease(changes *database.JobChanges, workerId string, now time.Time, duration time.Duration) {
+	changes.WorkerId = database.Change[sql.NullString]{
+		Value:   sql.NullString{String: workerId, Valid: true},
+		Changed: true,
+	}
+	changes.Heartbeat = database.Change[sql.NullInt64]{
+		Value:   sql.NullInt64{Int64: now.UnixMilli(), Valid: true},
+		Changed: true,
+	}
+	changes.LeaseExpires = database.Change[sql.NullInt64]{
+		Value:   sql.NullInt64{Int64: now.Add(duration).UnixMilli(), Valid: true},
+		Changed: true,
+	}
+}
+
+// NOTE(patrik): The worker id is kept so it's possible to see what worker
+// ran the job last
+func clearLease(changes *database.JobChanges) {
+	changes.Heartbeat = database.Change[sql.NullInt64]{
+		Value:   sql.NullInt64{},
+		Changed: true,
+	}
+	changes.LeaseExpires = database.Change[sql.NullInt64]{
+		Value:   sql.NullInt64{},
+		Changed: true,
+	}
+}
@@ -0,0 +1,181 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nanoteck137/watchbook/database"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+func openTestDB(t *testing.T) *database.Database {
+	t.Helper()
+
+	db, err := database.Open(filepath.Join(t.TempDir(), "data.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	err = db.RunMigrateUp()
+	if err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+
+	return db
+}
+
+// NOTE(patrik): Creates a job that is running on "worker" with a lease that
+// expires at leaseExpires (unix milli)
+func createRunningJob(t *testing.T, db *database.Database, attempts, maxAttempts int, leaseExpires int64) string {
+	t.Helper()
+
+	ctx := context.Background()
+
+	id, err := db.CreateJob(ctx, database.CreateJobParams{
+		Type:        "test",
+		Status:      types.JobStatusRunning,
+		Attempts:    attempts,
+		MaxAttempts: maxAttempts,
+	})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+
+	changes := database.JobChanges{}
+	setLease(&changes, "worker", time.UnixMilli(leaseExpires), 0)
+
+	err = db.UpdateJob(ctx, id, changes)
+	if err != nil {
+		t.Fatalf("failed to set lease: %v", err)
+	}
+
+	return id
+}
+
+func getJob(t *testing.T, db *database.Database, id string) database.Job {
+	t.Helper()
+
+	job, err := db.GetJobById(context.Background(), id)
+	if err != nil {
+		t.Fatalf("failed to get job: %v", err)
+	}
+
+	return job
+}
+
+func TestRecoverAbandonedJobs(t *testing.T) {
+	db := openTestDB(t)
+	p := NewJobProcessor(db)
+
+	now := time.Now().UnixMilli()
+
+	expired := createRunningJob(t, db, 0, 3, now-time.Minute.Milliseconds())
+	lastAttempt := createRunningJob(t, db, 2, 3, now-time.Minute.Milliseconds())
+	live := createRunningJob(t, db, 0, 3, now+time.Minute.Milliseconds())
+
+	recovered, err := p.RecoverAbandonedJobs(context.Background())
+	if err != nil {
+		t.Fatalf("failed to recover jobs: %v", err)
+	}
+
+	if recovered != 2 {
+		t.Errorf("expected 2 recovered jobs, got %d", recovered)
+	}
+
+	t.Run("expired job is requeued", func(t *testing.T) {
+		job := getJob(t, db, expired)
+
+		if job.Status != types.JobStatusQueued {
+			t.Errorf("expected status %q, got %q", types.JobStatusQueued, job.Status)
+		}
+
+		if job.Attempts != 1 {
+			t.Errorf("expected 1 attempt, got %d", job.Attempts)
+		}
+
+		if job.Heartbeat.Valid || job.LeaseExpires.Valid {
+			t.Errorf("expected the lease to be cleared, got heartbeat %v and lease %v", job.Heartbeat, job.LeaseExpires)
+		}
+
+		if job.Error.String != errJobAbandoned.Error() {
+			t.Errorf("expected error %q, got %q", errJobAbandoned.Error(), job.Error.String)
+		}
+	})
+
+	t.Run("job on the last attempt is failed", func(t *testing.T) {
+		job := getJob(t, db, lastAttempt)
+
+		if job.Status != types.JobStatusFailed {
+			t.Errorf("expected status %q, got %q", types.JobStatusFailed, job.Status)
+		}
+
+		if job.Attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", job.Attempts)
+		}
+	})
+
+	t.Run("job with a live lease is left alone", func(t *testing.T) {
+		job := getJob(t, db, live)
+
+		if job.Status != types.JobStatusRunning {
+			t.Errorf("expected status %q, got %q", types.JobStatusRunning, job.Status)
+		}
+
+		if job.Attempts != 0 {
+			t.Errorf("expected 0 attempts, got %d", job.Attempts)
+		}
+
+		if job.WorkerId.String != "worker" || !job.LeaseExpires.Valid {
+			t.Errorf("expected the lease to be kept, got worker %q and lease %v", job.WorkerId.String, job.LeaseExpires)
+		}
+	})
+}
+
+func TestUpdateExpiredJobAfterHeartbeat(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	now := time.Now().UnixMilli()
+	id := createRunningJob(t, db, 0, 3, now-time.Minute.Milliseconds())
+
+	// NOTE(patrik): The job was seen as expired but the worker extends the
+	// lease before the recovery updates the job
+	extended := database.JobChanges{}
+	setLease(&extended, "worker", time.UnixMilli(now), time.Minute)
+
+	updated, err := db.UpdateJobForWorker(ctx, id, "worker", extended)
+	if err != nil || !updated {
+		t.Fatalf("failed to extend the lease: updated %v, err %v", updated, err)
+	}
+
+	updated, err = db.UpdateExpiredJob(ctx, id, now, database.JobChanges{
+		Status: database.Change[types.JobStatus]{
+			Value:   types.JobStatusQueued,
+			Changed: true,
+		},
+		Error: database.Change[sql.NullString]{
+			Value:   sql.NullString{String: errJobAbandoned.Error(), Valid: true},
+			Changed: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to update expired job: %v", err)
+	}
+
+	if updated {
+		t.Errorf("expected the job with an extended lease to not be updated")
+	}
+
+	job := getJob(t, db, id)
+
+	if job.Status != types.JobStatusRunning {
+		t.Errorf("expected status %q, got %q", types.JobStatusRunning, job.Status)
+	}
+
+	if job.Error.Valid {
+		t.Errorf("expected no error, got %q", job.Error.String)
+	}
+}
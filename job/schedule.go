@@ -0,0 +1,253 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// NOTE(patrik): Returns the next time after t that the schedule should run
+// at, the zero time is returned if the schedule never runs again
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+type IntervalSchedule struct {
+	Interval time.Duration
+}
+
+func (s IntervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(s.Interval)
+}
+
+// NOTE(patrik): Standard 5 field cron expression (minute hour day-of-month
+// month day-of-week) evaluated in the local time zone, every field is a bit
+// set of the allowed values
+type CronSchedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// NOTE(patrik): Like cron the day matches if either the day of month or
+	// the day of week matches when both are restricted, a field starting
+	// with "*" (like "*/2") counts as unrestricted
+	domStar bool
+	dowStar bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteField = cronField{"minute", 0, 59}
+	hourField   = cronField{"hour", 0, 23}
+	domField    = cronField{"day of month", 1, 31}
+	monthField  = cronField{"month", 1, 12}
+	dowField    = cronField{"day of week", 0, 7}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// NOTE(patrik): Only one of cron and interval should be set
+func ParseSchedule(cron string, interval time.Duration) (Schedule, error) {
+	cron = strings.TrimSpace(cron)
+
+	switch {
+	case cron != "" && interval != 0:
+		return nil, fmt.Errorf("%w: both cron and interval is set", ErrInvalidSchedule)
+	case cron != "":
+		return ParseCron(cron)
+	case interval < time.Minute:
+		return nil, fmt.Errorf("%w: interval needs to be at least 1m", ErrInvalidSchedule)
+	default:
+		return IntervalSchedule{Interval: interval}, nil
+	}
+}
+
+// NOTE(patrik): Returns the next run for a new or changed schedule, the
+// interval schedules counts from the last run (directly if it never ran)
+// and the cron schedules waits for the next match
+func NextRun(s Schedule, lastRun, now time.Time) time.Time {
+	if _, ok := s.(IntervalSchedule); ok {
+		if lastRun.IsZero() {
+			return now
+		}
+
+		next := s.Next(lastRun)
+		if next.Before(now) {
+			return now
+		}
+
+		return next
+	}
+
+	return s.Next(now)
+}
+
+func ParseCron(expr string) (CronSchedule, error) {
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("%w: expected 5 fields in cron expression got %d", ErrInvalidSchedule, len(fields))
+	}
+
+	var s CronSchedule
+	var err error
+
+	s.minute, err = parseCronField(fields[0], minuteField)
+	if err != nil {
+		return CronSchedule{}, err
+	}
+
+	s.hour, err = parseCronField(fields[1], hourField)
+	if err != nil {
+		return CronSchedule{}, err
+	}
+
+	s.dom, err = parseCronField(fields[2], domField)
+	if err != nil {
+		return CronSchedule{}, err
+	}
+
+	s.month, err = parseCronField(fields[3], monthField)
+	if err != nil {
+		return CronSchedule{}, err
+	}
+
+	s.dow, err = parseCronField(fields[4], dowField)
+	if err != nil {
+		return CronSchedule{}, err
+	}
+
+	// NOTE(patrik): Both 0 and 7 is sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+
+	return s, nil
+}
+
+// NOTE(patrik): Supports "*", "5", "1-5", "*/15", "1-30/5" and lists of
+// them ("0,30")
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%w: invalid step %q for %s", ErrInvalidSchedule, stepPart, field.name)
+			}
+
+			step = n
+		}
+
+		start, end := field.min, field.max
+
+		if rangePart != "*" {
+			startStr, endStr, isRange := strings.Cut(rangePart, "-")
+
+			n, err := strconv.Atoi(startStr)
+			if err != nil {
+				return 0, fmt.Errorf("%w: invalid value %q for %s", ErrInvalidSchedule, startStr, field.name)
+			}
+
+			start = n
+			end = n
+
+			if isRange {
+				n, err := strconv.Atoi(endStr)
+				if err != nil {
+					return 0, fmt.Errorf("%w: invalid value %q for %s", ErrInvalidSchedule, endStr, field.name)
+				}
+
+				end = n
+			} else if hasStep {
+				// NOTE(patrik): "5/15" means starting at 5 every 15
+				end = field.max
+			}
+		}
+
+		if start < field.min || end > field.max || start > end {
+			return 0, fmt.Errorf("%w: %q is out of range for %s (%d-%d)", ErrInvalidSchedule, rangePart, field.name, field.min, field.max)
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << i
+		}
+	}
+
+	return bits, nil
+}
+
+func (s CronSchedule) matchesDay(t time.Time) bool {
+	domMatch := s.dom&(1<<t.Day()) != 0
+	dowMatch := s.dow&(1<<int(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+func (s CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// NOTE(patrik): Expressions like "0 0 30 2 *" never matches, stop
+	// searching after a couple of years
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<int(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.hour&(1<<t.Hour()) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.minute&(1<<t.Minute()) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,159 @@
+package job
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr  string
+		valid bool
+	}{
+		{"* * * * *", true},
+		{"0,30 * * * *", true},
+		{"1-30/5 * * * *", true},
+		{"5/15 * * * *", true},
+		{"0 0 * * 7", true},
+		{"@daily", true},
+		{"@WEEKLY", true},
+
+		{"", false},
+		{"* * * *", false},
+		{"* * * * * *", false},
+		{"60 * * * *", false},
+		{"* 24 * * *", false},
+		{"* * 0 * *", false},
+		{"* * * 13 *", false},
+		{"* * * * 8", false},
+		{"*/0 * * * *", false},
+		{"5-1 * * * *", false},
+		{"a * * * *", false},
+		{"@never", false},
+	}
+
+	for _, test := range tests {
+		_, err := ParseCron(test.expr)
+
+		if test.valid && err != nil {
+			t.Errorf("ParseCron(%q): unexpected error: %v", test.expr, err)
+		}
+
+		if !test.valid {
+			if err == nil {
+				t.Errorf("ParseCron(%q): expected an error", test.expr)
+			} else if !errors.Is(err, ErrInvalidSchedule) {
+				t.Errorf("ParseCron(%q): expected ErrInvalidSchedule, got %v", test.expr, err)
+			}
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	date := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+	}
+
+	// NOTE(patrik): 2026-01-01 is a thursday
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"step", "*/15 * * * *", date(2026, 1, 1, 10, 7).Add(30 * time.Second), date(2026, 1, 1, 10, 15)},
+		{"strictly after", "@hourly", date(2026, 1, 1, 10, 0), date(2026, 1, 1, 11, 0)},
+		{"next day", "0 0 * * *", date(2026, 1, 1, 10, 0), date(2026, 1, 2, 0, 0)},
+		{"weekdays", "0 9 * * 1-5", date(2026, 1, 2, 10, 0), date(2026, 1, 5, 9, 0)},
+		{"sunday as 7", "0 0 * * 7", date(2026, 1, 1, 0, 0), date(2026, 1, 4, 0, 0)},
+		{"sunday as 0", "0 0 * * 0", date(2026, 1, 1, 0, 0), date(2026, 1, 4, 0, 0)},
+		{"month rollover", "0 0 1 * *", date(2026, 1, 31, 12, 0), date(2026, 2, 1, 0, 0)},
+		{"skips short months", "0 0 31 * *", date(2026, 2, 1, 0, 0), date(2026, 3, 31, 0, 0)},
+		{"year rollover", "0 0 1 1 *", date(2026, 6, 1, 0, 0), date(2027, 1, 1, 0, 0)},
+		{"leap day", "0 0 29 2 *", date(2026, 1, 1, 0, 0), date(2028, 2, 29, 0, 0)},
+		{"month list", "0 12 * 12 *", date(2026, 6, 15, 0, 0), date(2026, 12, 1, 12, 0)},
+		// NOTE(patrik): Both days restricted, either one matches
+		{"dom or dow", "0 0 13 * 5", date(2026, 1, 1, 0, 0), date(2026, 1, 2, 0, 0)},
+		// NOTE(patrik): "*/2" counts as unrestricted so both needs to match,
+		// the first odd monday
+		{"star step dom and dow", "0 0 */2 * 1", date(2026, 1, 1, 0, 0), date(2026, 1, 5, 0, 0)},
+		{"never", "0 0 30 2 *", date(2026, 1, 1, 0, 0), time.Time{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := ParseCron(test.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", test.expr, err)
+			}
+
+			got := s.Next(test.from)
+			if !got.Equal(test.want) {
+				t.Errorf("Next(%s) for %q = %s, want %s", test.from, test.expr, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNextDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+
+	s, err := ParseCron("30 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE(patrik): 02:00-03:00 doesn't exist on 2026-03-29 so the run is
+	// skipped that day
+	from := time.Date(2026, 3, 29, 0, 0, 0, 0, loc)
+	want := time.Date(2026, 3, 30, 2, 30, 0, 0, loc)
+
+	got := s.Next(from)
+	if !got.Equal(want) {
+		t.Errorf("Next(%s) = %s, want %s", from, got, want)
+	}
+
+	// NOTE(patrik): Runs in local time after the clocks has changed
+	s, err = ParseCron("0 12 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from = time.Date(2026, 3, 28, 13, 0, 0, 0, loc)
+	want = time.Date(2026, 3, 29, 12, 0, 0, 0, loc)
+
+	got = s.Next(from)
+	if !got.Equal(want) {
+		t.Errorf("Next(%s) = %s, want %s", from, got, want)
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		cron     string
+		interval time.Duration
+		valid    bool
+	}{
+		{"cron", "@daily", 0, true},
+		{"interval", "", time.Hour, true},
+		{"both", "@daily", time.Hour, false},
+		{"none", "", 0, false},
+		{"short interval", "", 30 * time.Second, false},
+	}
+
+	for _, test := range tests {
+		_, err := ParseSchedule(test.cron, test.interval)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+
+		if !test.valid && !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("%s: expected ErrInvalidSchedule, got %v", test.name, err)
+		}
+	}
+}
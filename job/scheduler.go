@@ -0,0 +1,189 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/nanoteck137/watchbook/database"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+func (p *JobProcessor) scheduleLoop() {
+	ticker := time.NewTicker(p.ScheduleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		queued, err := p.RunDueSchedules(context.Background())
+		if err != nil {
+			slog.Error("failed to run job schedules", "err", err)
+		} else if queued > 0 {
+			slog.Info("queued scheduled jobs", "count", queued)
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// NOTE(patrik): Parses the schedule stored in the database
+func ScheduleFromDB(s database.JobSchedule) (Schedule, error) {
+	interval := time.Duration(s.Interval.Int64) * time.Millisecond
+	return ParseSchedule(s.Cron.String, interval)
+}
+
+// NOTE(patrik): Queues the jobs for the schedules that is due and moves the
+// schedules to the next run. Runs missed while the server was down is only
+// queued once. Returns the number of queued jobs.
+func (p *JobProcessor) RunDueSchedules(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	schedules, err := p.db.GetDueJobSchedules(ctx, now.UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+
+	for _, s := range schedules {
+		schedule, err := ScheduleFromDB(s)
+		if err != nil {
+			// NOTE(patrik): Clear the next run so the broken schedule
+			// doesn't show up every check, it's set again when the schedule
+			// is fixed
+			slog.Error("invalid job schedule", "id", s.Id, "name", s.Name, "err", err)
+
+			err = p.db.UpdateJobSchedule(ctx, s.Id, database.JobScheduleChanges{
+				NextRun: database.Change[sql.NullInt64]{
+					Value:   sql.NullInt64{},
+					Changed: true,
+				},
+			})
+			if err != nil {
+				return queued, err
+			}
+
+			continue
+		}
+
+		changes := database.JobScheduleChanges{
+			NextRun: database.Change[sql.NullInt64]{
+				Value:   nextRunValue(schedule, now),
+				Changed: true,
+			},
+		}
+
+		active, err := p.isScheduleActive(ctx, s)
+		if err != nil {
+			return queued, err
+		}
+
+		if active {
+			// NOTE(patrik): Don't pile up jobs when the last run is still
+			// waiting or running, skip this run
+			slog.Warn("skipping scheduled run, the last job is still active", "id", s.Id, "name", s.Name, "jobId", s.LastJobId.String)
+
+			err = p.db.UpdateJobSchedule(ctx, s.Id, changes)
+			if err != nil {
+				return queued, err
+			}
+
+			continue
+		}
+
+		_, err = p.queueSchedule(ctx, s, now, changes)
+		if err != nil {
+			return queued, err
+		}
+
+		queued++
+	}
+
+	return queued, nil
+}
+
+// NOTE(patrik): Queues a job for the schedule right now, the next run of
+// the schedule is not changed. Returns the id of the job.
+func (p *JobProcessor) RunSchedule(ctx context.Context, s database.JobSchedule) (string, error) {
+	if p.isStopped() {
+		return "", ErrStopping
+	}
+
+	return p.queueSchedule(ctx, s, time.Now(), database.JobScheduleChanges{})
+}
+
+func (p *JobProcessor) isScheduleActive(ctx context.Context, s database.JobSchedule) (bool, error) {
+	if !s.LastJobId.Valid {
+		return false, nil
+	}
+
+	job, err := p.db.GetJobById(ctx, s.LastJobId.String)
+	if err != nil {
+		if errors.Is(err, database.ErrItemNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return !types.IsFinishedJobStatus(job.Status), nil
+}
+
+func (p *JobProcessor) queueSchedule(ctx context.Context, s database.JobSchedule, now time.Time, changes database.JobScheduleChanges) (string, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	jobId, err := tx.CreateJob(ctx, database.CreateJobParams{
+		Type:        s.Type,
+		Status:      types.JobStatusQueued,
+		Priority:    0,
+		RunAt:       now.UnixMilli(),
+		Attempts:    0,
+		MaxAttempts: s.MaxAttempts,
+		Payload:     s.Payload,
+		Error:       sql.NullString{},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	changes.LastRun = database.Change[sql.NullInt64]{
+		Value:   sql.NullInt64{Int64: now.UnixMilli(), Valid: true},
+		Changed: true,
+	}
+	changes.LastJobId = database.Change[sql.NullString]{
+		Value:   sql.NullString{String: jobId, Valid: true},
+		Changed: true,
+	}
+
+	err = tx.UpdateJobSchedule(ctx, s.Id, changes)
+	if err != nil {
+		return "", err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return "", err
+	}
+
+	slog.Info("queued scheduled job", "schedule", s.Name, "type", s.Type, "jobId", jobId)
+
+	return jobId, nil
+}
+
+func nextRunValue(s Schedule, now time.Time) sql.NullInt64 {
+	next := s.Next(now)
+	if next.IsZero() {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: next.UnixMilli(), Valid: true}
+}
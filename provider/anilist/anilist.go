@@ -0,0 +1,477 @@
+package anilist
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/types"
+	"github.com/nanoteck137/watchbook/utils"
+)
+
+const AnimeProviderName = "anilist-anime"
+
+const (
+	mediaTypeAnime = "ANIME"
+	mediaTypeManga = "MANGA"
+)
+
+// NOTE(patrik): Max number of entries to walk when building a collection
+// from the relations graph
+const maxCollectionEntries = 50
+
+var _ provider.Provider = (*AnilistAnimeProvider)(nil)
+var _ provider.LinkResolver = (*AnilistAnimeProvider)(nil)
+
+type AnilistAnimeProvider struct {
+	client *provider.HTTPClient
+}
+
+func NewAnilistAnimeProvider(config provider.Config) *AnilistAnimeProvider {
+	return &AnilistAnimeProvider{
+		client: newHttpClient(config),
+	}
+}
+
+func (a *AnilistAnimeProvider) Info() provider.Info {
+	return provider.Info{
+		Name:                    AnimeProviderName,
+		DisplayName:             "AniList Anime",
+		SupportGetMedia:         true,
+		SupportSearchMedia:      true,
+		SupportGetCollection:    true,
+		SupportSearchCollection: true,
+	}
+}
+
+func (a *AnilistAnimeProvider) GetMedia(c provider.Context, id string) (provider.Media, error) {
+	apiClient := NewApiClient(a.client, c.Cache())
+
+	anilistId, err := strconv.Atoi(id)
+	if err != nil {
+		return provider.Media{}, provider.NotFound
+	}
+
+	media, err := apiClient.GetMedia(c.Context(), anilistId, mediaTypeAnime)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	schedule, err := apiClient.GetAiringSchedule(c.Context(), anilistId)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	res := convertMedia(media)
+	res.Type = ConvertAnimeFormat(media.Format)
+
+	if media.Season != nil && media.SeasonYear != nil {
+		s := utils.Slug(fmt.Sprintf("%s %d", *media.Season, *media.SeasonYear))
+		res.AiringSeason = &s
+	}
+
+	creators := []string{}
+	for _, edge := range media.Studios.Edges {
+		if edge.IsMain {
+			creators = append(creators, utils.Slug(edge.Node.Name))
+		}
+	}
+	res.Creators = creators
+
+	airingAt := make(map[int]time.Time, len(schedule))
+	for _, s := range schedule {
+		airingAt[s.Episode] = time.Unix(s.AiringAt, 0).UTC()
+	}
+
+	episodeCount := 0
+	if media.Episodes != nil {
+		episodeCount = *media.Episodes
+	}
+
+	for _, s := range schedule {
+		episodeCount = max(episodeCount, s.Episode)
+	}
+
+	// NOTE(patrik): Movies doesn't have a episode count sometimes
+	if episodeCount == 0 && res.Type == types.MediaTypeAnimeMovie {
+		episodeCount = 1
+	}
+
+	res.Parts = make([]provider.MediaPart, episodeCount)
+	for i := range episodeCount {
+		n := i + 1
+
+		var releaseDate *time.Time
+		if t, ok := airingAt[n]; ok {
+			releaseDate = &t
+		}
+
+		res.Parts[i] = provider.MediaPart{
+			Name:        fmt.Sprintf("Episode %d", n),
+			Number:      n,
+			ReleaseDate: releaseDate,
+		}
+	}
+
+	if t, ok := airingAt[1]; ok {
+		res.Release = &t
+	}
+
+	if media.IdMal != nil {
+		res.ExtraProviderIds[provider.ProviderNameMyAnimeListAnime] = strconv.Itoa(*media.IdMal)
+	}
+
+	return res, nil
+}
+
+func (a *AnilistAnimeProvider) SearchMedia(c provider.Context, query string) ([]provider.SearchResult, error) {
+	return search(c, a.client, query, mediaTypeAnime, provider.SearchResultTypeMedia)
+}
+
+func (a *AnilistAnimeProvider) GetCollection(c provider.Context, id string) (provider.Collection, error) {
+	apiClient := NewApiClient(a.client, c.Cache())
+
+	anilistId, err := strconv.Atoi(id)
+	if err != nil {
+		return provider.Collection{}, provider.NotFound
+	}
+
+	entries, err := walkRelations(c, apiClient, anilistId, mediaTypeAnime)
+	if err != nil {
+		return provider.Collection{}, err
+	}
+
+	if len(entries) == 0 {
+		return provider.Collection{}, provider.NotFound
+	}
+
+	first := entries[0]
+
+	res := provider.Collection{
+		ProviderId:       id,
+		Type:             types.CollectionTypeAnime,
+		Name:             first.Title.Preferred(),
+		CoverUrl:         coverUrl(first.CoverImage),
+		BannerUrl:        first.BannerImage,
+		Items:            make([]provider.CollectionItem, len(entries)),
+		ExtraProviderIds: map[string]string{},
+	}
+
+	for i, entry := range entries {
+		res.Items[i] = provider.CollectionItem{
+			Id:       strconv.Itoa(entry.Id),
+			Name:     entry.Title.Preferred(),
+			Position: i + 1,
+		}
+	}
+
+	return res, nil
+}
+
+func (a *AnilistAnimeProvider) SearchCollection(c provider.Context, query string) ([]provider.SearchResult, error) {
+	return search(c, a.client, query, mediaTypeAnime, provider.SearchResultTypeCollection)
+}
+
+// NOTE(patrik): Walks the relation graph starting at the id and returns
+// every entry in the same franchise sorted by start date
+func walkRelations(c provider.Context, apiClient *ApiClient, id int, mediaType string) ([]Media, error) {
+	visited := map[int]bool{id: true}
+	queue := []int{id}
+
+	var entries []Media
+
+	for len(queue) > 0 && len(entries) < maxCollectionEntries {
+		current := queue[0]
+		queue = queue[1:]
+
+		media, err := apiClient.GetMedia(c.Context(), current, mediaType)
+		if err != nil {
+			// NOTE(patrik): The root needs to exist but related entries
+			// can be missing
+			if current == id || !errors.Is(err, provider.NotFound) {
+				return nil, err
+			}
+
+			continue
+		}
+
+		entries = append(entries, media)
+
+		for _, edge := range media.Relations.Edges {
+			if edge.Node.Type != mediaType {
+				continue
+			}
+
+			switch edge.RelationType {
+			case "SEQUEL", "PREQUEL", "PARENT", "SIDE_STORY":
+			default:
+				continue
+			}
+
+			if visited[edge.Node.Id] {
+				continue
+			}
+
+			visited[edge.Node.Id] = true
+			queue = append(queue, edge.Node.Id)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a := entries[i].StartDate.Time()
+		b := entries[j].StartDate.Time()
+
+		if a == nil {
+			return false
+		}
+
+		if b == nil {
+			return true
+		}
+
+		return a.Before(*b)
+	})
+
+	return entries, nil
+}
+
+func search(c provider.Context, client *provider.HTTPClient, query, mediaType string, searchType provider.SearchResultType) ([]provider.SearchResult, error) {
+	apiClient := NewApiClient(client, c.Cache())
+
+	items, err := apiClient.Search(c.Context(), query, mediaType)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]provider.SearchResult, len(items))
+
+	for i, item := range items {
+		var mediaTyp types.MediaType
+		switch mediaType {
+		case mediaTypeManga:
+			mediaTyp = types.MediaTypeManga
+		default:
+			mediaTyp = ConvertAnimeFormat(item.Format)
+		}
+
+		res[i] = provider.SearchResult{
+			SearchType:       searchType,
+			ProviderId:       strconv.Itoa(item.Id),
+			Title:            item.Title.Preferred(),
+			MediaType:        mediaTyp,
+			ImageUrl:         utils.NullToDefault(coverUrl(item.CoverImage)),
+			Year:             utils.NullToDefault(item.StartDate.Year),
+			ExtraProviderIds: map[string]string{},
+		}
+
+		if item.IdMal != nil && mediaType == mediaTypeAnime {
+			res[i].ExtraProviderIds[provider.ProviderNameMyAnimeListAnime] = strconv.Itoa(*item.IdMal)
+		}
+	}
+
+	return res, nil
+}
+
+// NOTE(patrik): Converts the fields that are shared between the media types
+func convertMedia(media Media) provider.Media {
+	var description *string
+	if media.Description != nil {
+		d := cleanDescription(*media.Description)
+		if d != "" {
+			description = &d
+		}
+	}
+
+	var score *float64
+	if media.AverageScore != nil {
+		s := utils.RoundFloat(float64(*media.AverageScore)/10.0, 2)
+		score = &s
+	}
+
+	rating := types.MediaRatingUnknown
+	if media.IsAdult {
+		rating = types.MediaRatingRHentai
+	}
+
+	tags := make([]string, 0, len(media.Genres)+len(media.Tags))
+	for _, genre := range media.Genres {
+		tags = append(tags, utils.Slug(genre))
+	}
+
+	for _, tag := range media.Tags {
+		if tag.IsGeneralSpoiler || tag.IsMediaSpoiler {
+			continue
+		}
+
+		tags = append(tags, utils.Slug(tag.Name))
+	}
+
+	return provider.Media{
+		ProviderId:       strconv.Itoa(media.Id),
+		Title:            media.Title.Preferred(),
+		Description:      description,
+		Score:            score,
+		Status:           ConvertStatus(media.Status),
+		Rating:           rating,
+		StartDate:        media.StartDate.Time(),
+		EndDate:          media.EndDate.Time(),
+		CoverUrl:         coverUrl(media.CoverImage),
+		BannerUrl:        media.BannerImage,
+		Creators:         []string{},
+		Tags:             tags,
+		Parts:            []provider.MediaPart{},
+		Relations:        convertRelations(media.Relations.Edges),
+		ExtraProviderIds: map[string]string{},
+	}
+}
+
+func convertRelations(edges []RelationEdge) []provider.MediaRelation {
+	res := make([]provider.MediaRelation, 0, len(edges))
+
+	for _, edge := range edges {
+		var providerName string
+		switch edge.Node.Type {
+		case mediaTypeAnime:
+			providerName = provider.ProviderNameAnilistAnime
+		case mediaTypeManga:
+			providerName = provider.ProviderNameAnilistManga
+		default:
+			continue
+		}
+
+		res = append(res, provider.MediaRelation{
+			Type:         ConvertRelationType(edge.RelationType),
+			ProviderName: providerName,
+			ProviderId:   strconv.Itoa(edge.Node.Id),
+			Title:        edge.Node.Title.Preferred(),
+		})
+	}
+
+	return res
+}
+
+func ConvertRelationType(relationType string) types.MediaRelationType {
+	switch relationType {
+	case "SEQUEL":
+		return types.MediaRelationTypeSequel
+	case "PREQUEL":
+		return types.MediaRelationTypePrequel
+	case "SIDE_STORY":
+		return types.MediaRelationTypeSideStory
+	case "PARENT":
+		return types.MediaRelationTypeParentStory
+	case "ALTERNATIVE":
+		return types.MediaRelationTypeAlternative
+	case "SPIN_OFF":
+		return types.MediaRelationTypeSpinOff
+	case "SUMMARY", "COMPILATION":
+		return types.MediaRelationTypeSummary
+	case "ADAPTATION":
+		return types.MediaRelationTypeAdaptation
+	case "SOURCE":
+		return types.MediaRelationTypeSource
+	case "CHARACTER":
+		return types.MediaRelationTypeCharacter
+	}
+
+	return types.MediaRelationTypeOther
+}
+
+func coverUrl(img CoverImage) *string {
+	if img.ExtraLarge != nil && *img.ExtraLarge != "" {
+		return img.ExtraLarge
+	}
+
+	if img.Large != nil && *img.Large != "" {
+		return img.Large
+	}
+
+	return nil
+}
+
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+func cleanDescription(s string) string {
+	s = strings.ReplaceAll(s, "<br>", "\n")
+	s = strings.ReplaceAll(s, "<br />", "\n")
+	s = htmlTagRegex.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	return strings.TrimSpace(s)
+}
+
+func ConvertAnimeFormat(format *string) types.MediaType {
+	if format == nil {
+		return types.MediaTypeUnknown
+	}
+
+	switch *format {
+	case "TV", "TV_SHORT", "OVA", "ONA", "SPECIAL":
+		return types.MediaTypeAnimeSeason
+	case "MOVIE":
+		return types.MediaTypeAnimeMovie
+	case "MUSIC":
+		return types.MediaTypeUnknown
+	default:
+		// TODO(patrik): Better logging
+		fmt.Printf("WARN: Unknown anilist format \"%s\"\n", *format)
+	}
+
+	return types.MediaTypeUnknown
+}
+
+func ConvertStatus(status *string) types.MediaStatus {
+	if status == nil {
+		return types.MediaStatusUnknown
+	}
+
+	switch *status {
+	case "FINISHED":
+		return types.MediaStatusCompleted
+	case "RELEASING", "HIATUS":
+		return types.MediaStatusOngoing
+	case "NOT_YET_RELEASED":
+		return types.MediaStatusUpcoming
+	case "CANCELLED":
+		return types.MediaStatusUnknown
+	default:
+		// TODO(patrik): Better logging
+		fmt.Printf("WARN: Unknown anilist status \"%s\"\n", *status)
+	}
+
+	return types.MediaStatusUnknown
+}
+
+func (a *AnilistAnimeProvider) ResolveLink(u *url.URL) (provider.ResolvedLink, bool) {
+	return resolveLink(u, "anime")
+}
+
+// NOTE(patrik): Handles anilist.co/anime/154587/Sousou-no-Frieren/ and
+// anilist.co/manga/30002/Berserk/, kind is the first segment of the path
+func resolveLink(u *url.URL, kind string) (provider.ResolvedLink, bool) {
+	if !provider.IsHost(u, "anilist.co") {
+		return provider.ResolvedLink{}, false
+	}
+
+	segments := provider.PathSegments(u)
+	if len(segments) < 2 || segments[0] != kind {
+		return provider.ResolvedLink{}, false
+	}
+
+	id, ok := provider.LeadingId(segments[1])
+	if !ok {
+		return provider.ResolvedLink{}, false
+	}
+
+	return provider.ResolvedLink{
+		ProviderId: id,
+		Type:       provider.SearchResultTypeMedia,
+	}, true
+}
@@ -0,0 +1,396 @@
+package anilist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/tools/cache"
+)
+
+const DefaultBaseUrl = "https://graphql.anilist.co"
+
+type FuzzyDate struct {
+	Year  *int `json:"year"`
+	Month *int `json:"month"`
+	Day   *int `json:"day"`
+}
+
+// NOTE(patrik): Returns nil if the date is missing any of the parts
+func (d FuzzyDate) Time() *time.Time {
+	if d.Year == nil || d.Month == nil || d.Day == nil {
+		return nil
+	}
+
+	t := time.Date(*d.Year, time.Month(*d.Month), *d.Day, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+type MediaTitle struct {
+	Romaji  string  `json:"romaji"`
+	English *string `json:"english"`
+	Native  *string `json:"native"`
+}
+
+func (t MediaTitle) Preferred() string {
+	if t.English != nil && *t.English != "" {
+		return *t.English
+	}
+
+	return t.Romaji
+}
+
+type CoverImage struct {
+	ExtraLarge *string `json:"extraLarge"`
+	Large      *string `json:"large"`
+}
+
+type Studio struct {
+	Id                int    `json:"id"`
+	Name              string `json:"name"`
+	IsAnimationStudio bool   `json:"isAnimationStudio"`
+}
+
+type StudioEdge struct {
+	IsMain bool   `json:"isMain"`
+	Node   Studio `json:"node"`
+}
+
+type Tag struct {
+	Name             string `json:"name"`
+	Rank             int    `json:"rank"`
+	IsGeneralSpoiler bool   `json:"isGeneralSpoiler"`
+	IsMediaSpoiler   bool   `json:"isMediaSpoiler"`
+}
+
+type StaffEdge struct {
+	Role string `json:"role"`
+	Node struct {
+		Id   int `json:"id"`
+		Name struct {
+			Full string `json:"full"`
+		} `json:"name"`
+	} `json:"node"`
+}
+
+type RelationNode struct {
+	Id         int        `json:"id"`
+	Type       string     `json:"type"`
+	Format     *string    `json:"format"`
+	Title      MediaTitle `json:"title"`
+	StartDate  FuzzyDate  `json:"startDate"`
+	CoverImage CoverImage `json:"coverImage"`
+}
+
+type RelationEdge struct {
+	RelationType string       `json:"relationType"`
+	Node         RelationNode `json:"node"`
+}
+
+type Media struct {
+	Id           int        `json:"id"`
+	IdMal        *int       `json:"idMal"`
+	Type         string     `json:"type"`
+	Format       *string    `json:"format"`
+	Status       *string    `json:"status"`
+	Title        MediaTitle `json:"title"`
+	Description  *string    `json:"description"`
+	Season       *string    `json:"season"`
+	SeasonYear   *int       `json:"seasonYear"`
+	StartDate    FuzzyDate  `json:"startDate"`
+	EndDate      FuzzyDate  `json:"endDate"`
+	Episodes     *int       `json:"episodes"`
+	Chapters     *int       `json:"chapters"`
+	Volumes      *int       `json:"volumes"`
+	AverageScore *int       `json:"averageScore"`
+	IsAdult      bool       `json:"isAdult"`
+	Genres       []string   `json:"genres"`
+	Tags         []Tag      `json:"tags"`
+	CoverImage   CoverImage `json:"coverImage"`
+	BannerImage  *string    `json:"bannerImage"`
+	Studios      struct {
+		Edges []StudioEdge `json:"edges"`
+	} `json:"studios"`
+	Staff struct {
+		Edges []StaffEdge `json:"edges"`
+	} `json:"staff"`
+	Relations struct {
+		Edges []RelationEdge `json:"edges"`
+	} `json:"relations"`
+}
+
+type AiringSchedule struct {
+	Episode  int   `json:"episode"`
+	AiringAt int64 `json:"airingAt"`
+}
+
+type PageInfo struct {
+	Total       int  `json:"total"`
+	CurrentPage int  `json:"currentPage"`
+	LastPage    int  `json:"lastPage"`
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+type SearchMedia struct {
+	Id         int        `json:"id"`
+	IdMal      *int       `json:"idMal"`
+	Type       string     `json:"type"`
+	Format     *string    `json:"format"`
+	Title      MediaTitle `json:"title"`
+	StartDate  FuzzyDate  `json:"startDate"`
+	CoverImage CoverImage `json:"coverImage"`
+}
+
+const mediaFields = `
+	id
+	idMal
+	type
+	format
+	status
+	title { romaji english native }
+	description(asHtml: false)
+	season
+	seasonYear
+	startDate { year month day }
+	endDate { year month day }
+	episodes
+	chapters
+	volumes
+	averageScore
+	isAdult
+	genres
+	tags { name rank isGeneralSpoiler isMediaSpoiler }
+	coverImage { extraLarge large }
+	bannerImage
+	studios { edges { isMain node { id name isAnimationStudio } } }
+	staff(sort: RELEVANCE, perPage: 25) { edges { role node { id name { full } } } }
+	relations {
+		edges {
+			relationType(version: 2)
+			node {
+				id
+				type
+				format
+				title { romaji english native }
+				startDate { year month day }
+				coverImage { extraLarge large }
+			}
+		}
+	}
+`
+
+var mediaQuery = `
+query ($id: Int, $type: MediaType) {
+	Media(id: $id, type: $type) {` + mediaFields + `}
+}`
+
+const searchQuery = `
+query ($search: String, $type: MediaType, $page: Int, $perPage: Int) {
+	Page(page: $page, perPage: $perPage) {
+		pageInfo { total currentPage lastPage hasNextPage }
+		media(search: $search, type: $type, sort: SEARCH_MATCH) {
+			id
+			idMal
+			type
+			format
+			title { romaji english native }
+			startDate { year month day }
+			coverImage { extraLarge large }
+		}
+	}
+}`
+
+const airingScheduleQuery = `
+query ($id: Int, $page: Int, $perPage: Int) {
+	Page(page: $page, perPage: $perPage) {
+		pageInfo { total currentPage lastPage hasNextPage }
+		airingSchedules(mediaId: $id, sort: EPISODE) {
+			episode
+			airingAt
+		}
+	}
+}`
+
+const requestTTL = 1 * time.Hour
+
+type graphqlError struct {
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+}
+
+type graphqlResponse[T any] struct {
+	Data   T              `json:"data"`
+	Errors []graphqlError `json:"errors"`
+}
+
+type ApiClient struct {
+	client *provider.HTTPClient
+	cache  cache.Cache
+}
+
+// NOTE(patrik): AniList allows 90 requests per minute
+func newHttpClient(config provider.Config) *provider.HTTPClient {
+	return config.NewHttpClient(DefaultBaseUrl, 1, 5, "")
+}
+
+func NewApiClient(client *provider.HTTPClient, cache cache.Cache) *ApiClient {
+	return &ApiClient{
+		client: client,
+		cache:  cache,
+	}
+}
+
+type requestData struct {
+	client *provider.HTTPClient
+	cache  cache.Cache
+
+	cacheKey string
+
+	query     string
+	variables map[string]any
+}
+
+func apiRequest[T any](ctx context.Context, req requestData) (T, error) {
+	var res T
+
+	if data, ok := cache.GetJson[T](req.cache, req.cacheKey); ok {
+		return data, nil
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"query":     req.query,
+		"variables": req.variables,
+	})
+	if err != nil {
+		return res, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	d, err := req.client.Post(ctx, "/", body, provider.RequestOptions{
+		Headers: http.Header{
+			"Accept":       {"application/json"},
+			"Content-Type": {"application/json"},
+		},
+	})
+	if err != nil {
+		return res, err
+	}
+
+	var resp graphqlResponse[T]
+	err = json.Unmarshal(d, &resp)
+	if err != nil {
+		return res, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(resp.Errors) > 0 {
+		for _, e := range resp.Errors {
+			if e.Status == http.StatusNotFound {
+				return res, provider.NotFound
+			}
+		}
+
+		msgs := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			msgs[i] = e.Message
+		}
+
+		return res, errors.New("anilist: " + strings.Join(msgs, ", "))
+	}
+
+	// TODO(patrik): Log error?
+	cache.SetJson(req.cache, req.cacheKey, resp.Data, requestTTL)
+
+	return resp.Data, nil
+}
+
+func (c *ApiClient) GetMedia(ctx context.Context, id int, mediaType string) (Media, error) {
+	type data struct {
+		Media Media `json:"Media"`
+	}
+
+	res, err := apiRequest[data](ctx, requestData{
+		client:   c.client,
+		cache:    c.cache,
+		cacheKey: "api:media:" + mediaType + ":" + strconv.Itoa(id),
+		query:    mediaQuery,
+		variables: map[string]any{
+			"id":   id,
+			"type": mediaType,
+		},
+	})
+	if err != nil {
+		return Media{}, err
+	}
+
+	return res.Media, nil
+}
+
+func (c *ApiClient) Search(ctx context.Context, query string, mediaType string) ([]SearchMedia, error) {
+	type data struct {
+		Page struct {
+			PageInfo PageInfo      `json:"pageInfo"`
+			Media    []SearchMedia `json:"media"`
+		} `json:"Page"`
+	}
+
+	res, err := apiRequest[data](ctx, requestData{
+		client:   c.client,
+		cache:    c.cache,
+		cacheKey: "api:search:" + mediaType + ":" + query,
+		query:    searchQuery,
+		variables: map[string]any{
+			"search":  query,
+			"type":    mediaType,
+			"page":    1,
+			"perPage": 25,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Page.Media, nil
+}
+
+func (c *ApiClient) GetAiringSchedule(ctx context.Context, id int) ([]AiringSchedule, error) {
+	type data struct {
+		Page struct {
+			PageInfo        PageInfo         `json:"pageInfo"`
+			AiringSchedules []AiringSchedule `json:"airingSchedules"`
+		} `json:"Page"`
+	}
+
+	var res []AiringSchedule
+
+	// NOTE(patrik): Limit the number of pages so long running shows
+	// doesn't end up doing too many requests
+	for page := 1; page <= 10; page++ {
+		d, err := apiRequest[data](ctx, requestData{
+			client:   c.client,
+			cache:    c.cache,
+			cacheKey: fmt.Sprintf("api:airing-schedule:%d:%d", id, page),
+			query:    airingScheduleQuery,
+			variables: map[string]any{
+				"id":      id,
+				"page":    page,
+				"perPage": 50,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, d.Page.AiringSchedules...)
+
+		if !d.Page.PageInfo.HasNextPage {
+			break
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,123 @@
+package anilist
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/types"
+	"github.com/nanoteck137/watchbook/utils"
+)
+
+const MangaProviderName = "anilist-manga"
+
+var _ provider.Provider = (*AnilistMangaProvider)(nil)
+var _ provider.LinkResolver = (*AnilistMangaProvider)(nil)
+
+type AnilistMangaProvider struct {
+	client *provider.HTTPClient
+}
+
+func NewAnilistMangaProvider(config provider.Config) *AnilistMangaProvider {
+	return &AnilistMangaProvider{
+		client: newHttpClient(config),
+	}
+}
+
+func (a *AnilistMangaProvider) Info() provider.Info {
+	return provider.Info{
+		Name:                    MangaProviderName,
+		DisplayName:             "AniList Manga",
+		SupportGetMedia:         true,
+		SupportSearchMedia:      true,
+		SupportGetCollection:    false,
+		SupportSearchCollection: false,
+	}
+}
+
+func (a *AnilistMangaProvider) GetMedia(c provider.Context, id string) (provider.Media, error) {
+	apiClient := NewApiClient(a.client, c.Cache())
+
+	anilistId, err := strconv.Atoi(id)
+	if err != nil {
+		return provider.Media{}, provider.NotFound
+	}
+
+	media, err := apiClient.GetMedia(c.Context(), anilistId, mediaTypeManga)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	res := convertMedia(media)
+	res.Type = types.MediaTypeManga
+
+	if res.StartDate != nil {
+		s := types.GetAiringSeason(res.StartDate.Format(types.MediaDateLayout))
+		res.AiringSeason = &s
+	}
+
+	creators := []string{}
+	for _, edge := range media.Staff.Edges {
+		if isAuthorRole(edge.Role) {
+			creators = append(creators, utils.Slug(edge.Node.Name.Full))
+		}
+	}
+	res.Creators = creators
+
+	// NOTE(patrik): Prefer chapters as the parts and fallback to volumes,
+	// ongoing series usually doesn't have any counts
+	switch {
+	case media.Chapters != nil && *media.Chapters > 0:
+		res.Parts = createParts("Chapter", *media.Chapters)
+	case media.Volumes != nil && *media.Volumes > 0:
+		res.Parts = createParts("Volume", *media.Volumes)
+	}
+
+	return res, nil
+}
+
+func (a *AnilistMangaProvider) SearchMedia(c provider.Context, query string) ([]provider.SearchResult, error) {
+	return search(c, a.client, query, mediaTypeManga, provider.SearchResultTypeMedia)
+}
+
+func (a *AnilistMangaProvider) GetCollection(c provider.Context, id string) (provider.Collection, error) {
+	return provider.Collection{}, provider.ErrCollectionsNotSupported
+}
+
+func (a *AnilistMangaProvider) SearchCollection(c provider.Context, query string) ([]provider.SearchResult, error) {
+	return nil, provider.ErrCollectionsNotSupported
+}
+
+func (a *AnilistMangaProvider) ResolveLink(u *url.URL) (provider.ResolvedLink, bool) {
+	return resolveLink(u, "manga")
+}
+
+func createParts(name string, count int) []provider.MediaPart {
+	parts := make([]provider.MediaPart, count)
+
+	for i := range count {
+		n := i + 1
+		parts[i] = provider.MediaPart{
+			Name:   fmt.Sprintf("%s %d", name, n),
+			Number: n,
+		}
+	}
+
+	return parts
+}
+
+// NOTE(patrik): AniList staff roles looks like "Story & Art",
+// "Story", "Art (assistant)" or "Original Creator"
+func isAuthorRole(role string) bool {
+	role = strings.ToLower(role)
+
+	if strings.Contains(role, "assistant") {
+		return false
+	}
+
+	return strings.HasPrefix(role, "story") ||
+		strings.HasPrefix(role, "art") ||
+		strings.HasPrefix(role, "original creator")
+}
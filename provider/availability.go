@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nanoteck137/watchbook/tools/cache"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+// NOTE(patrik): The streaming services changes their catalogs often so the
+// availability is not cached for as long as the media
+const availabilityTTL = 24 * time.Hour
+
+var ErrAvailabilityNotSupported = errors.New("provider doesn't support availability")
+
+type Availability struct {
+	Region string                 `json:"region"`
+	Type   types.AvailabilityType `json:"type"`
+	// NOTE(patrik): Slug of the service name (netflix, crunchyroll), used
+	// by the filters
+	Service     string `json:"service"`
+	ServiceName string `json:"serviceName"`
+}
+
+// NOTE(patrik): Optional interface for the providers that knows where the
+// media can be watched (tmdb watch providers)
+type AvailabilityProvider interface {
+	GetAvailability(c Context, id string) ([]Availability, error)
+}
+
+func (p *ProviderManager) SupportsAvailability(providerName string) bool {
+	provider, ok := p.providers[providerName]
+	if !ok {
+		return false
+	}
+
+	_, ok = provider.(AvailabilityProvider)
+	return ok
+}
+
+func (p *ProviderManager) GetAvailability(ctx context.Context, providerName, id string) ([]Availability, error) {
+	provider, err := p.getProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	availabilityProvider, ok := provider.(AvailabilityProvider)
+	if !ok {
+		return nil, ErrAvailabilityNotSupported
+	}
+
+	cacheKey := fmt.Sprintf("availability:%s", id)
+
+	providerCache := p.cache.WithName(providerName)
+	noCache := p.providerInfos[providerName].NoCache
+
+	if data, ok := cache.GetJson[[]Availability](providerCache, cacheKey); ok && !noCache {
+		return data, nil
+	}
+
+	c := Context{
+		ctx:   ctx,
+		cache: providerCache,
+	}
+
+	items, err := availabilityProvider.GetAvailability(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !noCache {
+		err = cache.SetJson(providerCache, cacheKey, items, availabilityTTL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
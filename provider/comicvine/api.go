@@ -0,0 +1,291 @@
+package comicvine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/tools/cache"
+)
+
+const DefaultBaseUrl = "https://comicvine.gamespot.com"
+
+// NOTE(patrik): ComicVine requires a unique user agent
+const UserAgent = "watchbook"
+
+// NOTE(patrik): Resource type prefixes used in the detail urls
+const (
+	resourceVolume   = "4050"
+	resourceStoryArc = "4045"
+)
+
+const (
+	statusOk             = 1
+	statusObjectNotFound = 101
+)
+
+// NOTE(patrik): Max number of items the api returns per request
+const pageLimit = 100
+
+type Image struct {
+	OriginalUrl string `json:"original_url"`
+	SuperUrl    string `json:"super_url"`
+	ScreenUrl   string `json:"screen_url"`
+}
+
+type NamedItem struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Volume struct {
+	Id            int         `json:"id"`
+	Name          string      `json:"name"`
+	Deck          *string     `json:"deck"`
+	Description   *string     `json:"description"`
+	StartYear     *string     `json:"start_year"`
+	CountOfIssues int         `json:"count_of_issues"`
+	Image         *Image      `json:"image"`
+	Publisher     *NamedItem  `json:"publisher"`
+	Concepts      []NamedItem `json:"concepts"`
+}
+
+type Issue struct {
+	Id          int        `json:"id"`
+	Name        *string    `json:"name"`
+	IssueNumber string     `json:"issue_number"`
+	CoverDate   *string    `json:"cover_date"`
+	Volume      *NamedItem `json:"volume"`
+}
+
+type StoryArc struct {
+	Id          int         `json:"id"`
+	Name        string      `json:"name"`
+	Deck        *string     `json:"deck"`
+	Description *string     `json:"description"`
+	Image       *Image      `json:"image"`
+	Issues      []NamedItem `json:"issues"`
+}
+
+type SearchResult struct {
+	Id           int        `json:"id"`
+	Name         string     `json:"name"`
+	ResourceType string     `json:"resource_type"`
+	StartYear    *string    `json:"start_year"`
+	Image        *Image     `json:"image"`
+	Publisher    *NamedItem `json:"publisher"`
+}
+
+type Response[T any] struct {
+	Error                string `json:"error"`
+	StatusCode           int    `json:"status_code"`
+	Limit                int    `json:"limit"`
+	Offset               int    `json:"offset"`
+	NumberOfPageResults  int    `json:"number_of_page_results"`
+	NumberOfTotalResults int    `json:"number_of_total_results"`
+	Results              T      `json:"results"`
+}
+
+const requestTTL = 1 * time.Hour
+
+type ApiClient struct {
+	client *provider.HTTPClient
+	cache  cache.Cache
+	apiKey string
+}
+
+// NOTE(patrik): ComicVine limits to 200 requests per resource per hour
+// and doesn't like bursts
+func newHttpClient(config provider.Config) *provider.HTTPClient {
+	return config.NewHttpClient(DefaultBaseUrl, 1, 1, UserAgent)
+}
+
+func NewApiClient(client *provider.HTTPClient, cache cache.Cache, apiKey string) *ApiClient {
+	return &ApiClient{
+		client: client,
+		cache:  cache,
+		apiKey: apiKey,
+	}
+}
+
+type requestData struct {
+	client *provider.HTTPClient
+	cache  cache.Cache
+
+	cacheKey string
+
+	path  string
+	query url.Values
+}
+
+func apiRequest[T any](ctx context.Context, req requestData) (Response[T], error) {
+	var res Response[T]
+
+	if data, ok := cache.GetJson[Response[T]](req.cache, req.cacheKey); ok {
+		return data, nil
+	}
+
+	d, err := req.client.Get(ctx, req.path, provider.RequestOptions{
+		Headers: http.Header{
+			"Accept": {"application/json"},
+		},
+		Query: req.query,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	err = json.Unmarshal(d, &res)
+	if err != nil {
+		return res, err
+	}
+
+	switch res.StatusCode {
+	case statusOk:
+	case statusObjectNotFound:
+		return res, provider.NotFound
+	default:
+		return res, fmt.Errorf("comicvine: %s (%d)", res.Error, res.StatusCode)
+	}
+
+	// TODO(patrik): Log error?
+	cache.SetJson(req.cache, req.cacheKey, res, requestTTL)
+
+	return res, nil
+}
+
+func (c *ApiClient) query(values url.Values) url.Values {
+	values.Set("api_key", c.apiKey)
+	values.Set("format", "json")
+	return values
+}
+
+func (c *ApiClient) GetVolume(ctx context.Context, id string) (Volume, error) {
+	res, err := apiRequest[Volume](ctx, requestData{
+		client:   c.client,
+		cache:    c.cache,
+		cacheKey: "api:volume:" + id,
+		path:     fmt.Sprintf("/api/volume/%s-%s/", resourceVolume, url.PathEscape(id)),
+		query: c.query(url.Values{
+			"field_list": {"id,name,deck,description,start_year,count_of_issues,image,publisher,concepts"},
+		}),
+	})
+	if err != nil {
+		return Volume{}, err
+	}
+
+	return res.Results, nil
+}
+
+// NOTE(patrik): The volume resource doesn't include the cover date so we
+// need to use the issues resource instead
+func (c *ApiClient) GetVolumeIssues(ctx context.Context, id string) ([]Issue, error) {
+	var res []Issue
+
+	offset := 0
+	for {
+		page, err := apiRequest[[]Issue](ctx, requestData{
+			client:   c.client,
+			cache:    c.cache,
+			cacheKey: fmt.Sprintf("api:volume-issues:%s:%d", id, offset),
+			path:     "/api/issues/",
+			query: c.query(url.Values{
+				"filter":     {"volume:" + id},
+				"sort":       {"cover_date:asc"},
+				"field_list": {"id,name,issue_number,cover_date"},
+				"limit":      {strconv.Itoa(pageLimit)},
+				"offset":     {strconv.Itoa(offset)},
+			}),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, page.Results...)
+
+		offset += page.NumberOfPageResults
+		if page.NumberOfPageResults == 0 || offset >= page.NumberOfTotalResults {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+func (c *ApiClient) GetIssuesById(ctx context.Context, ids []int) ([]Issue, error) {
+	var res []Issue
+
+	for start := 0; start < len(ids); start += pageLimit {
+		end := min(start+pageLimit, len(ids))
+
+		idStrs := make([]string, 0, end-start)
+		for _, id := range ids[start:end] {
+			idStrs = append(idStrs, strconv.Itoa(id))
+		}
+
+		filter := strings.Join(idStrs, "|")
+
+		page, err := apiRequest[[]Issue](ctx, requestData{
+			client:   c.client,
+			cache:    c.cache,
+			cacheKey: "api:issues:" + filter,
+			path:     "/api/issues/",
+			query: c.query(url.Values{
+				"filter":     {"id:" + filter},
+				"field_list": {"id,name,issue_number,cover_date,volume"},
+				"limit":      {strconv.Itoa(pageLimit)},
+			}),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, page.Results...)
+	}
+
+	return res, nil
+}
+
+func (c *ApiClient) GetStoryArc(ctx context.Context, id string) (StoryArc, error) {
+	res, err := apiRequest[StoryArc](ctx, requestData{
+		client:   c.client,
+		cache:    c.cache,
+		cacheKey: "api:story-arc:" + id,
+		path:     fmt.Sprintf("/api/story_arc/%s-%s/", resourceStoryArc, url.PathEscape(id)),
+		query: c.query(url.Values{
+			"field_list": {"id,name,deck,description,image,issues"},
+		}),
+	})
+	if err != nil {
+		return StoryArc{}, err
+	}
+
+	return res.Results, nil
+}
+
+// NOTE(patrik): resource is either "volume" or "story_arc"
+func (c *ApiClient) Search(ctx context.Context, query, resource string) ([]SearchResult, error) {
+	res, err := apiRequest[[]SearchResult](ctx, requestData{
+		client:   c.client,
+		cache:    c.cache,
+		cacheKey: "api:search:" + resource + ":" + query,
+		path:     "/api/search/",
+		query: c.query(url.Values{
+			"query":      {query},
+			"resources":  {resource},
+			"field_list": {"id,name,resource_type,start_year,image,publisher"},
+			"limit":      {"20"},
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Results, nil
+}
@@ -0,0 +1,321 @@
+package comicvine
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/types"
+	"github.com/nanoteck137/watchbook/utils"
+)
+
+const ComicProviderName = "comicvine-comic"
+
+var ErrMissingApiKey = errors.New("comicvine: missing api key")
+
+var _ provider.Provider = (*ComicVineComicProvider)(nil)
+
+type ComicVineComicProvider struct {
+	config provider.Config
+	client *provider.HTTPClient
+}
+
+func NewComicVineComicProvider(config provider.Config) *ComicVineComicProvider {
+	return &ComicVineComicProvider{
+		config: config,
+		client: newHttpClient(config),
+	}
+}
+
+func (p *ComicVineComicProvider) Info() provider.Info {
+	return p.config.RequireApiKey(provider.Info{
+		Name:                    ComicProviderName,
+		DisplayName:             "ComicVine Comics",
+		SupportGetMedia:         true,
+		SupportSearchMedia:      true,
+		SupportGetCollection:    true,
+		SupportSearchCollection: true,
+	})
+}
+
+func (p *ComicVineComicProvider) apiClient(c provider.Context) (*ApiClient, error) {
+	if p.config.ApiKey == "" {
+		return nil, ErrMissingApiKey
+	}
+
+	return NewApiClient(p.client, c.Cache(), p.config.ApiKey), nil
+}
+
+func (p *ComicVineComicProvider) GetMedia(c provider.Context, id string) (provider.Media, error) {
+	apiClient, err := p.apiClient(c)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	volume, err := apiClient.GetVolume(c.Context(), id)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	issues, err := apiClient.GetVolumeIssues(c.Context(), id)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	// NOTE(patrik): Issue numbers can be things like "1.5" or "½" so we
+	// use the position in the cover date order as the part number
+	parts := make([]provider.MediaPart, len(issues))
+	for i, issue := range issues {
+		name := "#" + issue.IssueNumber
+		if issue.Name != nil && *issue.Name != "" {
+			name = fmt.Sprintf("#%s: %s", issue.IssueNumber, *issue.Name)
+		}
+
+		parts[i] = provider.MediaPart{
+			Name:        name,
+			Number:      i + 1,
+			ReleaseDate: parseCoverDate(issue.CoverDate),
+		}
+	}
+
+	var startDate *time.Time
+	var endDate *time.Time
+
+	if len(parts) > 0 {
+		startDate = parts[0].ReleaseDate
+		endDate = parts[len(parts)-1].ReleaseDate
+	}
+
+	var airingSeason *string
+	if startDate != nil {
+		s := types.GetAiringSeason(startDate.Format(types.MediaDateLayout))
+		airingSeason = &s
+	}
+
+	status := types.MediaStatusUnknown
+	if endDate != nil {
+		// NOTE(patrik): ComicVine doesn't tell us if a volume has ended,
+		// so guess based on when the last issue was released
+		if time.Since(*endDate) > 180*24*time.Hour {
+			status = types.MediaStatusCompleted
+		} else {
+			status = types.MediaStatusOngoing
+		}
+	}
+
+	creators := []string{}
+	if volume.Publisher != nil {
+		creators = append(creators, utils.Slug(volume.Publisher.Name))
+	}
+
+	tags := make([]string, 0, len(volume.Concepts))
+	for _, concept := range volume.Concepts {
+		tags = append(tags, utils.Slug(concept.Name))
+	}
+
+	return provider.Media{
+		ProviderId:       id,
+		Type:             types.MediaTypeComic,
+		Title:            volume.Name,
+		Description:      description(volume.Deck, volume.Description),
+		Score:            nil,
+		Status:           status,
+		Rating:           types.MediaRatingUnknown,
+		AiringSeason:     airingSeason,
+		StartDate:        startDate,
+		EndDate:          endDate,
+		CoverUrl:         imageUrl(volume.Image),
+		Creators:         creators,
+		Tags:             tags,
+		Parts:            parts,
+		ExtraProviderIds: map[string]string{},
+	}, nil
+}
+
+func (p *ComicVineComicProvider) SearchMedia(c provider.Context, query string) ([]provider.SearchResult, error) {
+	return p.search(c, query, "volume", provider.SearchResultTypeMedia)
+}
+
+// NOTE(patrik): Story arcs spans multiple volumes, so the collection
+// items are the volumes the issues of the arc belongs to
+func (p *ComicVineComicProvider) GetCollection(c provider.Context, id string) (provider.Collection, error) {
+	apiClient, err := p.apiClient(c)
+	if err != nil {
+		return provider.Collection{}, err
+	}
+
+	arc, err := apiClient.GetStoryArc(c.Context(), id)
+	if err != nil {
+		return provider.Collection{}, err
+	}
+
+	issueIds := make([]int, len(arc.Issues))
+	for i, issue := range arc.Issues {
+		issueIds[i] = issue.Id
+	}
+
+	issues, err := apiClient.GetIssuesById(c.Context(), issueIds)
+	if err != nil {
+		return provider.Collection{}, err
+	}
+
+	type volumeEntry struct {
+		volume    NamedItem
+		firstDate *time.Time
+	}
+
+	volumes := map[int]*volumeEntry{}
+	for _, issue := range issues {
+		if issue.Volume == nil {
+			continue
+		}
+
+		date := parseCoverDate(issue.CoverDate)
+
+		entry, exists := volumes[issue.Volume.Id]
+		if !exists {
+			volumes[issue.Volume.Id] = &volumeEntry{
+				volume:    *issue.Volume,
+				firstDate: date,
+			}
+			continue
+		}
+
+		if date != nil && (entry.firstDate == nil || date.Before(*entry.firstDate)) {
+			entry.firstDate = date
+		}
+	}
+
+	entries := make([]*volumeEntry, 0, len(volumes))
+	for _, entry := range volumes {
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a := entries[i].firstDate
+		b := entries[j].firstDate
+
+		if a == nil || b == nil {
+			if a == nil && b == nil {
+				return entries[i].volume.Id < entries[j].volume.Id
+			}
+
+			return b == nil
+		}
+
+		return a.Before(*b)
+	})
+
+	res := provider.Collection{
+		ProviderId:       id,
+		Type:             types.CollectionTypeSeries,
+		Name:             arc.Name,
+		CoverUrl:         imageUrl(arc.Image),
+		Items:            make([]provider.CollectionItem, len(entries)),
+		ExtraProviderIds: map[string]string{},
+	}
+
+	for i, entry := range entries {
+		res.Items[i] = provider.CollectionItem{
+			Id:       strconv.Itoa(entry.volume.Id),
+			Name:     entry.volume.Name,
+			Position: i + 1,
+		}
+	}
+
+	return res, nil
+}
+
+func (p *ComicVineComicProvider) SearchCollection(c provider.Context, query string) ([]provider.SearchResult, error) {
+	return p.search(c, query, "story_arc", provider.SearchResultTypeCollection)
+}
+
+func (p *ComicVineComicProvider) search(c provider.Context, query, resource string, searchType provider.SearchResultType) ([]provider.SearchResult, error) {
+	apiClient, err := p.apiClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := apiClient.Search(c.Context(), query, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]provider.SearchResult, 0, len(items))
+
+	for _, item := range items {
+		// NOTE(patrik): Make sure we only get the requested resource
+		if item.ResourceType != resource {
+			continue
+		}
+
+		res = append(res, provider.SearchResult{
+			SearchType: searchType,
+			ProviderId: strconv.Itoa(item.Id),
+			Title:      item.Name,
+			MediaType:  types.MediaTypeComic,
+			ImageUrl:   utils.NullToDefault(imageUrl(item.Image)),
+			Year:       provider.ParseYear(utils.NullToDefault(item.StartYear)),
+		})
+	}
+
+	return res, nil
+}
+
+func parseCoverDate(s *string) *time.Time {
+	if s == nil {
+		return nil
+	}
+
+	d, err := time.Parse(types.MediaDateLayout, *s)
+	if err != nil {
+		return nil
+	}
+
+	return &d
+}
+
+func imageUrl(img *Image) *string {
+	if img == nil {
+		return nil
+	}
+
+	if img.OriginalUrl != "" {
+		return &img.OriginalUrl
+	}
+
+	if img.SuperUrl != "" {
+		return &img.SuperUrl
+	}
+
+	return nil
+}
+
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// NOTE(patrik): The description is html and usually very long, so use
+// the deck (short summary) if we have it
+func description(deck, desc *string) *string {
+	if deck != nil && strings.TrimSpace(*deck) != "" {
+		d := strings.TrimSpace(*deck)
+		return &d
+	}
+
+	if desc == nil {
+		return nil
+	}
+
+	d := htmlTagRegex.ReplaceAllString(*desc, "")
+	d = strings.TrimSpace(html.UnescapeString(d))
+	if d == "" {
+		return nil
+	}
+
+	return &d
+}
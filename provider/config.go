@@ -0,0 +1,92 @@
+package provider
+
+// NOTE(patrik): Settings for a provider, comes from the [providers.<name>]
+// section inside the config
+type Config struct {
+	ApiKey    string
+	BaseUrl   string
+	Language  string
+	UserAgent string
+
+	// NOTE(patrik): Directory used by the providers that reads from disk
+	Dir string
+
+	// NOTE(patrik): Requests per second, 0 means use the provider default
+	RateLimit float64
+	RateBurst int
+}
+
+// NOTE(patrik): Used by providers that can't work without an api key,
+// marks the provider as disabled if the key is missing
+func (c Config) RequireApiKey(info Info) Info {
+	if c.ApiKey == "" {
+		info.Disabled = true
+		info.DisabledReason = "missing api key"
+	}
+
+	return info
+}
+
+func (c Config) GetBaseUrl(def string) string {
+	if c.BaseUrl != "" {
+		return c.BaseUrl
+	}
+
+	return def
+}
+
+func (c Config) GetLanguage(def string) string {
+	if c.Language != "" {
+		return c.Language
+	}
+
+	return def
+}
+
+func (c Config) GetDir(def string) string {
+	if c.Dir != "" {
+		return c.Dir
+	}
+
+	return def
+}
+
+func (c Config) GetUserAgent(def string) string {
+	if c.UserAgent != "" {
+		return c.UserAgent
+	}
+
+	return def
+}
+
+// NOTE(patrik): Creates the client options from the config, the defaults
+// are used when the config doesn't override them
+func (c Config) ClientOptions(defaultRate float64, defaultBurst int, defaultUserAgent string) []ClientOption {
+	rate := defaultRate
+	if c.RateLimit > 0 {
+		rate = c.RateLimit
+	}
+
+	burst := defaultBurst
+	if c.RateBurst > 0 {
+		burst = c.RateBurst
+	}
+
+	opts := []ClientOption{
+		WithRate(rate, burst),
+	}
+
+	userAgent := c.GetUserAgent(defaultUserAgent)
+	if userAgent != "" {
+		opts = append(opts, WithUserAgent(userAgent))
+	}
+
+	return opts
+}
+
+func (c Config) NewHttpClient(defaultBaseUrl string, defaultRate float64, defaultBurst int, defaultUserAgent string) *HTTPClient {
+	return NewHttpClient(
+		c.GetBaseUrl(defaultBaseUrl),
+		c.ClientOptions(defaultRate, defaultBurst, defaultUserAgent)...,
+	)
+}
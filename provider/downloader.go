@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -16,7 +17,7 @@ import (
 var NotFound = errors.New("page not found")
 
 type HTTPClient struct {
-	BaseUrl      string
+	BaseUrl string
 
 	client    *http.Client
 	limiter   *rate.Limiter
@@ -46,11 +47,11 @@ func WithRate(rps float64, burst int) ClientOption {
 
 func NewHttpClient(baseUrl string, opts ...ClientOption) *HTTPClient {
 	downloader := &HTTPClient{
-		BaseUrl:      baseUrl,
-		client:       &http.Client{Timeout: 30 * time.Second},
-		limiter:      rate.NewLimiter(1, 1),
-		userAgent:    "",
-		timeout:      30 * time.Second,
+		BaseUrl:   baseUrl,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		limiter:   rate.NewLimiter(1, 1),
+		userAgent: "",
+		timeout:   30 * time.Second,
 	}
 
 	for _, opt := range opts {
@@ -66,6 +67,14 @@ type RequestOptions struct {
 }
 
 func (c *HTTPClient) Get(ctx context.Context, path string, opts RequestOptions) ([]byte, error) {
+	return c.do(ctx, "GET", path, nil, opts)
+}
+
+func (c *HTTPClient) Post(ctx context.Context, path string, body []byte, opts RequestOptions) ([]byte, error) {
+	return c.do(ctx, "POST", path, body, opts)
+}
+
+func (c *HTTPClient) do(ctx context.Context, method, path string, body []byte, opts RequestOptions) ([]byte, error) {
 	url, err := utils.CreateUrlBase(c.BaseUrl, path, opts.Query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create url: %w", err)
@@ -79,13 +88,21 @@ func (c *HTTPClient) Get(ctx context.Context, path string, opts RequestOptions)
 	reqCtx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
 	u := url.String()
-	req, err := http.NewRequestWithContext(reqCtx, "GET", u, nil)
+	req, err := http.NewRequestWithContext(reqCtx, method, u, bodyReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build request: %w", err)
 	}
 
 	headers := opts.Headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
 
 	if c.userAgent != "" {
 		headers.Set("User-Agent", c.userAgent)
@@ -3,6 +3,7 @@ package dummy
 import (
 	"errors"
 	"net/url"
+	"strings"
 
 	"github.com/nanoteck137/watchbook/provider"
 	"github.com/nanoteck137/watchbook/types"
@@ -20,7 +21,7 @@ func (d *DummyProvider) Info() provider.Info {
 		Name:                    ProviderName,
 		DisplayName:             "Dummy",
 		SupportGetMedia:         true,
-		SupportSearchMedia:      false,
+		SupportSearchMedia:      true,
 		SupportGetCollection:    true,
 		SupportSearchCollection: true,
 	}
@@ -109,6 +110,16 @@ func (d *DummyProvider) GetMedia(c provider.Context, id string) (provider.Media,
 				"myanimelist-anime": "21",
 			},
 		}, nil
+	case "adult":
+		title := "Attack on Titan Adult Entry"
+		cover := "https://placehold.co/300x450/png?text=" + url.QueryEscape(title)
+		return provider.Media{
+			ProviderId: id,
+			Type:       types.MediaTypeAnimeSeason,
+			Title:      title,
+			Rating:     types.MediaRatingRHentai,
+			CoverUrl:   &cover,
+		}, nil
 	}
 
 	return provider.Media{}, errors.New("not found")
@@ -133,7 +144,82 @@ func (d *DummyProvider) SearchCollection(c provider.Context, query string) ([]pr
 	}, nil
 }
 
+// NOTE(patrik): Small page size so the pagination can be tested without a
+// lot of entries
+const searchPageSize = 2
+
+var searchEntries = []provider.SearchResult{
+	{
+		SearchType: provider.SearchResultTypeMedia,
+		ProviderId: "1@1",
+		Title:      "Attack on Titan Season 1",
+		MediaType:  types.MediaTypeAnimeSeason,
+		Year:       2013,
+	},
+	{
+		SearchType: provider.SearchResultTypeMedia,
+		ProviderId: "1@2",
+		Title:      "Attack on Titan Season 2",
+		MediaType:  types.MediaTypeAnimeSeason,
+		Year:       2017,
+	},
+	{
+		SearchType: provider.SearchResultTypeMedia,
+		ProviderId: "1@3",
+		Title:      "Attack on Titan Season 3",
+		MediaType:  types.MediaTypeAnimeSeason,
+		Year:       2018,
+	},
+	{
+		SearchType: provider.SearchResultTypeMedia,
+		ProviderId: "1@4",
+		Title:      "Attack on Titan Final Season",
+		MediaType:  types.MediaTypeAnimeSeason,
+		Year:       2020,
+	},
+	// NOTE(patrik): Only shown when the search includes adult entries
+	{
+		SearchType: provider.SearchResultTypeMedia,
+		ProviderId: "adult",
+		Title:      "Attack on Titan Adult Entry",
+		MediaType:  types.MediaTypeAnimeSeason,
+		Year:       2021,
+		Adult:      true,
+	},
+}
+
+func searchDummyEntries(query string) []provider.SearchResult {
+	var res []provider.SearchResult
+	for _, entry := range searchEntries {
+		if strings.Contains(strings.ToLower(entry.Title), strings.ToLower(query)) {
+			entry.ImageUrl = "https://placehold.co/300x450/png?text=" + url.QueryEscape(entry.Title)
+			res = append(res, entry)
+		}
+	}
+
+	return res
+}
+
 func (d *DummyProvider) SearchMedia(c provider.Context, query string) ([]provider.SearchResult, error) {
-	panic("unsupported")
+	return provider.FilterSearchResults(searchDummyEntries(query), provider.SearchParams{Query: query}), nil
+}
+
+var _ provider.SearchParamsProvider = (*DummyProvider)(nil)
+
+func (d *DummyProvider) SearchMediaWithParams(c provider.Context, params provider.SearchParams) (provider.SearchPage, error) {
+	items := provider.FilterSearchResults(searchDummyEntries(params.Query), params)
+
+	page := params.GetPage()
+	totalPages := (len(items) + searchPageSize - 1) / searchPageSize
+
+	start := min((page-1)*searchPageSize, len(items))
+	end := min(start+searchPageSize, len(items))
+
+	return provider.SearchPage{
+		Results:     items[start:end],
+		Page:        page,
+		TotalPages:  totalPages,
+		HasNextPage: page < totalPages,
+	}, nil
 }
 
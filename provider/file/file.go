@@ -0,0 +1,453 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/types"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// NOTE(patrik): Layout of the metadata directory:
+//
+//	<dir>/media/<id>/media.toml (or media.json)
+//	<dir>/media/<id>/cover.png  (optional, png/jpg/jpeg)
+//	<dir>/media/<id>/banner.png (optional)
+//	<dir>/media/<id>/logo.png   (optional)
+//
+//	<dir>/collections/<id>/collection.toml (or collection.json)
+//	<dir>/collections/<id>/cover.png       (optional)
+//	...
+//
+// The id of the entry is the name of the directory, the items of a
+// collection references the ids of the media entries.
+
+const ProviderName = "file"
+
+const (
+	mediaDirName      = "media"
+	collectionDirName = "collections"
+
+	mediaFileName      = "media"
+	collectionFileName = "collection"
+)
+
+var metadataExts = []string{".toml", ".json"}
+var imageExts = []string{".png", ".jpg", ".jpeg"}
+
+type MediaPart struct {
+	Name        string `json:"name" toml:"name"`
+	Number      int    `json:"number" toml:"number"`
+	ReleaseDate string `json:"releaseDate" toml:"release_date"`
+}
+
+type Media struct {
+	Type         types.MediaType   `json:"type" toml:"type"`
+	Title        string            `json:"title" toml:"title"`
+	Description  string            `json:"description" toml:"description"`
+	Score        *float64          `json:"score" toml:"score"`
+	Status       types.MediaStatus `json:"status" toml:"status"`
+	Rating       types.MediaRating `json:"rating" toml:"rating"`
+	AiringSeason string            `json:"airingSeason" toml:"airing_season"`
+
+	// NOTE(patrik): Format: 2006-01-02
+	StartDate string `json:"startDate" toml:"start_date"`
+	EndDate   string `json:"endDate" toml:"end_date"`
+	// NOTE(patrik): Format: RFC3339
+	Release string `json:"release" toml:"release"`
+
+	Creators []string    `json:"creators" toml:"creators"`
+	Tags     []string    `json:"tags" toml:"tags"`
+	Parts    []MediaPart `json:"parts" toml:"parts"`
+
+	ExtraProviderIds map[string]string `json:"extraProviderIds" toml:"extra_provider_ids"`
+}
+
+type CollectionItem struct {
+	Id       string `json:"id" toml:"id"`
+	Name     string `json:"name" toml:"name"`
+	Position int    `json:"position" toml:"position"`
+}
+
+type Collection struct {
+	Type  types.CollectionType `json:"type" toml:"type"`
+	Name  string               `json:"name" toml:"name"`
+	Items []CollectionItem     `json:"items" toml:"items"`
+
+	ExtraProviderIds map[string]string `json:"extraProviderIds" toml:"extra_provider_ids"`
+}
+
+var _ provider.Provider = (*FileProvider)(nil)
+
+type FileProvider struct {
+	dir string
+}
+
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{
+		dir: dir,
+	}
+}
+
+func (f *FileProvider) Info() provider.Info {
+	return provider.Info{
+		Name:                    ProviderName,
+		DisplayName:             "Local Files",
+		SupportGetMedia:         true,
+		SupportSearchMedia:      true,
+		SupportGetCollection:    true,
+		SupportSearchCollection: true,
+		NoCache:                 true,
+	}
+}
+
+func (f *FileProvider) GetMedia(c provider.Context, id string) (provider.Media, error) {
+	dir, err := f.entryDir(mediaDirName, id)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	var media Media
+	err = readMetadata(dir, mediaFileName, &media)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	return convertMedia(id, dir, media), nil
+}
+
+func (f *FileProvider) SearchMedia(c provider.Context, query string) ([]provider.SearchResult, error) {
+	ids, err := f.listEntries(mediaDirName)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []provider.SearchResult{}
+
+	for _, id := range ids {
+		dir := filepath.Join(f.dir, mediaDirName, id)
+
+		var media Media
+		err := readMetadata(dir, mediaFileName, &media)
+		if err != nil {
+			// TODO(patrik): Better logging
+			fmt.Printf("WARN: failed to read media metadata \"%s\": %v\n", id, err)
+			continue
+		}
+
+		if !matchQuery(query, id, media.Title) {
+			continue
+		}
+
+		res = append(res, provider.SearchResult{
+			SearchType: provider.SearchResultTypeMedia,
+			ProviderId: id,
+			Title:      media.Title,
+			MediaType:  convertMediaType(media.Type),
+		})
+	}
+
+	return res, nil
+}
+
+func (f *FileProvider) GetCollection(c provider.Context, id string) (provider.Collection, error) {
+	dir, err := f.entryDir(collectionDirName, id)
+	if err != nil {
+		return provider.Collection{}, err
+	}
+
+	var col Collection
+	err = readMetadata(dir, collectionFileName, &col)
+	if err != nil {
+		return provider.Collection{}, err
+	}
+
+	typ := col.Type
+	if !types.IsValidCollectionType(typ) {
+		typ = types.CollectionTypeUnknown
+	}
+
+	res := provider.Collection{
+		ProviderId:       id,
+		Type:             typ,
+		Name:             col.Name,
+		CoverUrl:         findImage(dir, "cover"),
+		LogoUrl:          findImage(dir, "logo"),
+		BannerUrl:        findImage(dir, "banner"),
+		Items:            make([]provider.CollectionItem, len(col.Items)),
+		ExtraProviderIds: nonNilMap(col.ExtraProviderIds),
+	}
+
+	for i, item := range col.Items {
+		position := item.Position
+		if position == 0 {
+			position = i + 1
+		}
+
+		res.Items[i] = provider.CollectionItem{
+			Id:       item.Id,
+			Name:     item.Name,
+			Position: position,
+		}
+	}
+
+	return res, nil
+}
+
+func (f *FileProvider) SearchCollection(c provider.Context, query string) ([]provider.SearchResult, error) {
+	ids, err := f.listEntries(collectionDirName)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []provider.SearchResult{}
+
+	for _, id := range ids {
+		dir := filepath.Join(f.dir, collectionDirName, id)
+
+		var col Collection
+		err := readMetadata(dir, collectionFileName, &col)
+		if err != nil {
+			// TODO(patrik): Better logging
+			fmt.Printf("WARN: failed to read collection metadata \"%s\": %v\n", id, err)
+			continue
+		}
+
+		if !matchQuery(query, id, col.Name) {
+			continue
+		}
+
+		res = append(res, provider.SearchResult{
+			SearchType: provider.SearchResultTypeCollection,
+			ProviderId: id,
+			Title:      col.Name,
+		})
+	}
+
+	return res, nil
+}
+
+// NOTE(patrik): Makes sure the id can't escape the metadata directory
+func (f *FileProvider) entryDir(kind, id string) (string, error) {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return "", provider.NotFound
+	}
+
+	dir := filepath.Join(f.dir, kind, id)
+
+	stat, err := os.Stat(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", provider.NotFound
+		}
+
+		return "", err
+	}
+
+	if !stat.IsDir() {
+		return "", provider.NotFound
+	}
+
+	return dir, nil
+}
+
+func (f *FileProvider) listEntries(kind string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(f.dir, kind))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var res []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			res = append(res, entry.Name())
+		}
+	}
+
+	sort.Strings(res)
+
+	return res, nil
+}
+
+func readMetadata(dir, name string, dest any) error {
+	for _, ext := range metadataExts {
+		p := filepath.Join(dir, name+ext)
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return err
+		}
+
+		switch ext {
+		case ".toml":
+			err = toml.Unmarshal(data, dest)
+		case ".json":
+			err = json.Unmarshal(data, dest)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", p, err)
+		}
+
+		return nil
+	}
+
+	return provider.NotFound
+}
+
+// NOTE(patrik): Returns a file:// url to the image if it exists
+func findImage(dir, name string) *string {
+	for _, ext := range imageExts {
+		p := filepath.Join(dir, name+ext)
+
+		_, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			continue
+		}
+
+		u := "file://" + filepath.ToSlash(abs)
+		return &u
+	}
+
+	return nil
+}
+
+func matchQuery(query, id, title string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return true
+	}
+
+	return strings.Contains(strings.ToLower(title), query) ||
+		strings.Contains(strings.ToLower(id), query)
+}
+
+func convertMediaType(t types.MediaType) types.MediaType {
+	if !types.IsValidMediaType(t) {
+		return types.MediaTypeUnknown
+	}
+
+	return t
+}
+
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+
+	return m
+}
+
+func parseDate(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+
+	d, err := time.Parse(types.MediaDateLayout, s)
+	if err != nil {
+		return nil
+	}
+
+	return &d
+}
+
+func convertMedia(id, dir string, media Media) provider.Media {
+	var description *string
+	if media.Description != "" {
+		description = &media.Description
+	}
+
+	startDate := parseDate(media.StartDate)
+	endDate := parseDate(media.EndDate)
+
+	var release *time.Time
+	if media.Release != "" {
+		t, err := time.Parse(time.RFC3339, media.Release)
+		if err == nil {
+			release = &t
+		}
+	}
+
+	var airingSeason *string
+	if media.AiringSeason != "" {
+		airingSeason = &media.AiringSeason
+	} else if startDate != nil {
+		s := types.GetAiringSeason(startDate.Format(types.MediaDateLayout))
+		airingSeason = &s
+	}
+
+	status := media.Status
+	if !types.IsValidMediaStatus(status) {
+		status = types.MediaStatusUnknown
+	}
+
+	rating := media.Rating
+	if !types.IsValidMediaRating(rating) {
+		rating = types.MediaRatingUnknown
+	}
+
+	parts := make([]provider.MediaPart, len(media.Parts))
+	for i, part := range media.Parts {
+		number := part.Number
+		if number == 0 {
+			number = i + 1
+		}
+
+		parts[i] = provider.MediaPart{
+			Name:        part.Name,
+			Number:      number,
+			ReleaseDate: parseDate(part.ReleaseDate),
+		}
+	}
+
+	creators := media.Creators
+	if creators == nil {
+		creators = []string{}
+	}
+
+	tags := media.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return provider.Media{
+		ProviderId:       id,
+		Type:             convertMediaType(media.Type),
+		Title:            media.Title,
+		Description:      description,
+		Score:            media.Score,
+		Status:           status,
+		Rating:           rating,
+		AiringSeason:     airingSeason,
+		StartDate:        startDate,
+		EndDate:          endDate,
+		Release:          release,
+		CoverUrl:         findImage(dir, "cover"),
+		LogoUrl:          findImage(dir, "logo"),
+		BannerUrl:        findImage(dir, "banner"),
+		Creators:         creators,
+		Tags:             tags,
+		Parts:            parts,
+		ExtraProviderIds: nonNilMap(media.ExtraProviderIds),
+	}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nanoteck137/watchbook/tools/cache"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+var ErrImagesNotSupported = errors.New("provider doesn't support media images")
+
+type Image struct {
+	Type types.ImageType `json:"type"`
+	Url  string          `json:"url"`
+}
+
+// NOTE(patrik): Optional interface for the providers that has more images
+// for the media than the ones returned by GetMedia (picture pages, tmdb
+// images)
+type ImageProvider interface {
+	GetMediaImages(c Context, id string) ([]Image, error)
+}
+
+func (p *ProviderManager) SupportsMediaImages(providerName string) bool {
+	provider, ok := p.providers[providerName]
+	if !ok {
+		return false
+	}
+
+	_, ok = provider.(ImageProvider)
+	return ok
+}
+
+func (p *ProviderManager) GetMediaImages(ctx context.Context, providerName, id string) ([]Image, error) {
+	provider, err := p.getProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	imageProvider, ok := provider.(ImageProvider)
+	if !ok {
+		return nil, ErrImagesNotSupported
+	}
+
+	cacheKey := fmt.Sprintf("media-images:%s", id)
+
+	providerCache := p.cache.WithName(providerName)
+	noCache := p.providerInfos[providerName].NoCache
+
+	if data, ok := cache.GetJson[[]Image](providerCache, cacheKey); ok && !noCache {
+		return data, nil
+	}
+
+	c := Context{
+		ctx:   ctx,
+		cache: providerCache,
+	}
+
+	images, err := imageProvider.GetMediaImages(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !noCache {
+		err = cache.SetJson(providerCache, cacheKey, images, mediaTTL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return images, nil
+}
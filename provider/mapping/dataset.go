@@ -0,0 +1,225 @@
+package mapping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nanoteck137/watchbook/provider"
+)
+
+// NOTE(patrik): Supported dataset formats, the format is detected from the
+// content of the file:
+//
+// anime-offline-database (https://github.com/manami-project/anime-offline-database)
+//
+//	{ "data": [{ "sources": ["https://myanimelist.net/anime/1", "https://anilist.co/anime/1"] }] }
+//
+// anime-lists (https://github.com/Fribb/anime-lists)
+//
+//	[{ "mal_id": 1, "anilist_id": 1, "themoviedb_id": 30991, "imdb_id": "tt0213338", "type": "TV", "season": { "tmdb": 1 } }]
+//
+// watchbook, the keys are provider names
+//
+//	{ "mappings": [{ "myanimelist-anime": "1", "tmdb-tv": "30991@1" }] }
+
+var ErrUnknownFormat = errors.New("unknown id mapping format")
+
+var sourcePatterns = []struct {
+	provider string
+	regex    *regexp.Regexp
+}{
+	{
+		provider: provider.ProviderNameMyAnimeListAnime,
+		regex:    regexp.MustCompile(`^https?://myanimelist\.net/anime/(\d+)`),
+	},
+	{
+		provider: provider.ProviderNameAnilistAnime,
+		regex:    regexp.MustCompile(`^https?://anilist\.co/anime/(\d+)`),
+	},
+}
+
+type animeOfflineDatabase struct {
+	Data []struct {
+		Sources []string `json:"sources"`
+	} `json:"data"`
+}
+
+// NOTE(patrik): The ids inside anime-lists can be numbers or strings
+type jsonId string
+
+func (i *jsonId) UnmarshalJSON(data []byte) error {
+	var n json.Number
+	err := json.Unmarshal(data, &n)
+	if err == nil {
+		*i = jsonId(n.String())
+		return nil
+	}
+
+	var s string
+	err = json.Unmarshal(data, &s)
+	if err == nil {
+		*i = jsonId(s)
+		return nil
+	}
+
+	// NOTE(patrik): Ignore everything else (null, arrays...)
+	*i = ""
+	return nil
+}
+
+type animeListsEntry struct {
+	Type       string `json:"type"`
+	MalId      jsonId `json:"mal_id"`
+	AnilistId  jsonId `json:"anilist_id"`
+	TheMovieDb jsonId `json:"themoviedb_id"`
+	ImdbId     jsonId `json:"imdb_id"`
+	Season     *struct {
+		Tmdb jsonId `json:"tmdb"`
+	} `json:"season"`
+}
+
+type watchbookDataset struct {
+	Mappings []Ids `json:"mappings"`
+}
+
+// NOTE(patrik): Loads the dataset into the database, returns the number of
+// entries inside the file
+func (db *Database) LoadFile(p string) (int, error) {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return 0, err
+	}
+
+	entries, err := parseDataset(data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse id mapping file %s: %w", p, err)
+	}
+
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	for _, ids := range entries {
+		db.add(ids)
+	}
+
+	return len(entries), nil
+}
+
+func parseDataset(data []byte) ([]Ids, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, ErrUnknownFormat
+	}
+
+	if data[0] == '[' {
+		var entries []animeListsEntry
+		err := json.Unmarshal(data, &entries)
+		if err != nil {
+			return nil, err
+		}
+
+		return convertAnimeLists(entries), nil
+	}
+
+	var probe map[string]json.RawMessage
+	err := json.Unmarshal(data, &probe)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case probe["data"] != nil:
+		var dataset animeOfflineDatabase
+		err := json.Unmarshal(data, &dataset)
+		if err != nil {
+			return nil, err
+		}
+
+		return convertAnimeOfflineDatabase(dataset), nil
+	case probe["mappings"] != nil:
+		var dataset watchbookDataset
+		err := json.Unmarshal(data, &dataset)
+		if err != nil {
+			return nil, err
+		}
+
+		return dataset.Mappings, nil
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+func convertAnimeOfflineDatabase(dataset animeOfflineDatabase) []Ids {
+	res := make([]Ids, 0, len(dataset.Data))
+
+	for _, entry := range dataset.Data {
+		ids := Ids{}
+
+		for _, source := range entry.Sources {
+			for _, pattern := range sourcePatterns {
+				m := pattern.regex.FindStringSubmatch(source)
+				if m != nil {
+					ids[pattern.provider] = m[1]
+				}
+			}
+		}
+
+		if len(ids) > 1 {
+			res = append(res, ids)
+		}
+	}
+
+	return res
+}
+
+func convertAnimeLists(entries []animeListsEntry) []Ids {
+	res := make([]Ids, 0, len(entries))
+
+	validId := func(id jsonId) bool {
+		_, err := strconv.Atoi(string(id))
+		return err == nil
+	}
+
+	for _, entry := range entries {
+		ids := Ids{}
+
+		if validId(entry.MalId) {
+			ids[provider.ProviderNameMyAnimeListAnime] = string(entry.MalId)
+		}
+
+		if validId(entry.AnilistId) {
+			ids[provider.ProviderNameAnilistAnime] = string(entry.AnilistId)
+		}
+
+		switch strings.ToUpper(entry.Type) {
+		case "MOVIE":
+			if validId(entry.TheMovieDb) {
+				ids[provider.ProviderNameTheMovieDbMovie] = string(entry.TheMovieDb)
+			}
+
+			// NOTE(patrik): Only movies, for series the imdb id points to
+			// the whole series and not the season
+			if strings.HasPrefix(string(entry.ImdbId), "tt") {
+				ids[provider.ProviderNameImdb] = string(entry.ImdbId)
+			}
+		default:
+			// NOTE(patrik): TV entries on tmdb are seasons so we need to
+			// know the season number
+			if validId(entry.TheMovieDb) && entry.Season != nil && validId(entry.Season.Tmdb) {
+				ids[provider.ProviderNameTheMovieDbTv] = string(entry.TheMovieDb) + "@" + string(entry.Season.Tmdb)
+			}
+		}
+
+		if len(ids) > 1 {
+			res = append(res, ids)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,129 @@
+package mapping
+
+import (
+	"maps"
+	"sync"
+)
+
+// NOTE(patrik): Provider name -> id inside that provider, same format as
+// the providers list stored on the media
+type Ids map[string]string
+
+type key struct {
+	provider string
+	id       string
+}
+
+// NOTE(patrik): In memory database of ids that points to the same entry
+// on different providers, filled from offline datasets like the
+// anime-offline-database
+type Database struct {
+	mutex   sync.RWMutex
+	entries []Ids
+	index   map[key]int
+}
+
+func NewDatabase() *Database {
+	return &Database{
+		index: map[key]int{},
+	}
+}
+
+// NOTE(patrik): Adds the ids as one entry, if any of the ids is already
+// inside the database the new ids are merged into that entry (existing
+// ids are never replaced)
+func (db *Database) Add(ids Ids) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.add(ids)
+}
+
+func (db *Database) add(ids Ids) {
+	if len(ids) < 2 {
+		return
+	}
+
+	idx := -1
+	for name, id := range ids {
+		if i, ok := db.index[key{name, id}]; ok {
+			idx = i
+			break
+		}
+	}
+
+	if idx == -1 {
+		idx = len(db.entries)
+		db.entries = append(db.entries, Ids{})
+	}
+
+	entry := db.entries[idx]
+	for name, id := range ids {
+		if name == "" || id == "" {
+			continue
+		}
+
+		if _, exists := entry[name]; exists {
+			continue
+		}
+
+		k := key{name, id}
+		if _, used := db.index[k]; used {
+			// NOTE(patrik): The id already belongs to another entry,
+			// skip it instead of linking two unrelated entries
+			continue
+		}
+
+		entry[name] = id
+		db.index[k] = idx
+	}
+}
+
+// NOTE(patrik): Returns all the ids linked to the provider id, returns nil
+// if there is no entry
+func (db *Database) Lookup(providerName, id string) Ids {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	idx, ok := db.index[key{providerName, id}]
+	if !ok {
+		return nil
+	}
+
+	return maps.Clone(db.entries[idx])
+}
+
+func (db *Database) Len() int {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	return len(db.entries)
+}
+
+// NOTE(patrik): Adds the missing ids from the database to ids, returns the
+// names of the providers that was added
+func (db *Database) Fill(ids Ids) []string {
+	var added []string
+
+	// NOTE(patrik): Loop until nothing new is found, one id can lead to
+	// another entry that has more ids
+	for {
+		found := false
+
+		for name, id := range maps.Clone(ids) {
+			for otherName, otherId := range db.Lookup(name, id) {
+				if _, exists := ids[otherName]; exists {
+					continue
+				}
+
+				ids[otherName] = otherId
+				added = append(added, otherName)
+				found = true
+			}
+		}
+
+		if !found {
+			return added
+		}
+	}
+}
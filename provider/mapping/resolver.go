@@ -0,0 +1,105 @@
+package mapping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/nanoteck137/watchbook/provider"
+)
+
+// NOTE(patrik): Max number of provider requests for one resolve, stops
+// providers that keeps pointing to new ids from running forever
+const maxProviderFetches = 10
+
+type ResolveOptions struct {
+	// NOTE(patrik): Fetch the media from the providers to get the ids the
+	// providers reports, otherwise only the offline database is used
+	FetchProviders bool
+}
+
+// NOTE(patrik): Finds the ids of the media on other providers by using the
+// offline database and the ids reported by the providers, the ids already
+// inside ids are never replaced. The result is always valid, the returned
+// error contains the provider requests that failed.
+func Resolve(ctx context.Context, pm *provider.ProviderManager, db *Database, ids Ids, opts ResolveOptions) (Ids, error) {
+	res := maps.Clone(ids)
+	if res == nil {
+		res = Ids{}
+	}
+
+	db.Fill(res)
+
+	if !opts.FetchProviders {
+		return res, nil
+	}
+
+	var errs []error
+
+	fetched := map[string]bool{}
+	fetches := 0
+
+	for fetches < maxProviderFetches {
+		name, id, ok := nextFetch(pm, res, fetched)
+		if !ok {
+			break
+		}
+
+		fetched[name] = true
+		fetches++
+
+		media, err := pm.GetMedia(ctx, name, id)
+		if err != nil {
+			if !errors.Is(err, provider.NotFound) {
+				errs = append(errs, fmt.Errorf("%s (%s): %w", name, id, err))
+			}
+
+			continue
+		}
+
+		for otherName, otherId := range media.ExtraProviderIds {
+			if otherId == "" {
+				continue
+			}
+
+			if _, exists := res[otherName]; !exists {
+				res[otherName] = otherId
+			}
+		}
+
+		db.Fill(res)
+	}
+
+	return res, errors.Join(errs...)
+}
+
+func nextFetch(pm *provider.ProviderManager, ids Ids, fetched map[string]bool) (string, string, bool) {
+	for name, id := range ids {
+		if fetched[name] {
+			continue
+		}
+
+		info, ok := pm.GetProviderInfo(name)
+		if !ok || info.Disabled || !info.SupportGetMedia {
+			continue
+		}
+
+		return name, id, true
+	}
+
+	return "", "", false
+}
+
+// NOTE(patrik): Returns the names of the providers inside b that is
+// missing from a
+func Added(a, b Ids) []string {
+	var res []string
+	for name := range b {
+		if _, exists := a[name]; !exists {
+			res = append(res, name)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,280 @@
+package merge
+
+import (
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+type Field string
+
+const (
+	FieldTitle        Field = "title"
+	FieldDescription  Field = "description"
+	FieldScore        Field = "score"
+	FieldStatus       Field = "status"
+	FieldRating       Field = "rating"
+	FieldAiringSeason Field = "airingSeason"
+	FieldStartDate    Field = "startDate"
+	FieldEndDate      Field = "endDate"
+	FieldCover        Field = "cover"
+	FieldBanner       Field = "banner"
+	FieldLogo         Field = "logo"
+	FieldCreators     Field = "creators"
+	FieldTags         Field = "tags"
+	FieldParts        Field = "parts"
+	FieldThemeSongs   Field = "themeSongs"
+	FieldRelations    Field = "relations"
+	FieldCredits      Field = "credits"
+	FieldVideos       Field = "videos"
+)
+
+var Fields = []Field{
+	FieldTitle,
+	FieldDescription,
+	FieldScore,
+	FieldStatus,
+	FieldRating,
+	FieldAiringSeason,
+	FieldStartDate,
+	FieldEndDate,
+	FieldCover,
+	FieldBanner,
+	FieldLogo,
+	FieldCreators,
+	FieldTags,
+	FieldParts,
+	FieldThemeSongs,
+	FieldRelations,
+	FieldCredits,
+	FieldVideos,
+}
+
+// NOTE(patrik): Field -> Provider name
+type Sources map[Field]string
+
+// NOTE(patrik): Used as the source when the field was edited by the user
+const SourceManual = "manual"
+
+// NOTE(patrik): Field -> Provider names in priority order, providers not
+// in the list are used after the listed ones (the primary provider first)
+type Policy map[Field][]string
+
+const (
+	mal          = provider.ProviderNameMyAnimeListAnime
+	anilistAnime = provider.ProviderNameAnilistAnime
+	tmdbMovie    = provider.ProviderNameTheMovieDbMovie
+	tmdbTv       = provider.ProviderNameTheMovieDbTv
+)
+
+var DefaultPolicies = map[types.MediaType]Policy{
+	types.MediaTypeAnimeSeason: {
+		FieldTitle:        {tmdbTv, mal, anilistAnime},
+		FieldDescription:  {tmdbTv, mal, anilistAnime},
+		FieldScore:        {mal, anilistAnime},
+		FieldAiringSeason: {mal, anilistAnime},
+		FieldStatus:       {mal, anilistAnime},
+		FieldStartDate:    {mal, anilistAnime},
+		FieldEndDate:      {mal, anilistAnime},
+		FieldCover:        {mal, anilistAnime, tmdbTv},
+		FieldBanner:       {anilistAnime, tmdbTv},
+		FieldLogo:         {tmdbTv},
+		FieldParts:        {anilistAnime, tmdbTv, mal},
+		FieldThemeSongs:   {mal},
+		FieldRelations:    {mal, anilistAnime},
+		FieldCredits:      {mal, tmdbTv},
+		FieldVideos:       {mal, tmdbTv},
+	},
+	types.MediaTypeAnimeMovie: {
+		FieldTitle:        {tmdbMovie, mal, anilistAnime},
+		FieldDescription:  {tmdbMovie, mal, anilistAnime},
+		FieldScore:        {mal, anilistAnime},
+		FieldAiringSeason: {mal, anilistAnime},
+		FieldCover:        {mal, anilistAnime, tmdbMovie},
+		FieldBanner:       {tmdbMovie, anilistAnime},
+		FieldLogo:         {tmdbMovie},
+		FieldThemeSongs:   {mal},
+		FieldRelations:    {mal, anilistAnime},
+		FieldCredits:      {mal, tmdbMovie},
+		FieldVideos:       {tmdbMovie, mal},
+	},
+	types.MediaTypeTV: {
+		FieldTitle:       {tmdbTv},
+		FieldDescription: {tmdbTv},
+		FieldParts:       {tmdbTv},
+		FieldCredits:     {tmdbTv},
+		FieldVideos:      {tmdbTv},
+	},
+	types.MediaTypeMovie: {
+		FieldTitle:       {tmdbMovie},
+		FieldDescription: {tmdbMovie},
+		FieldCredits:     {tmdbMovie},
+		FieldVideos:      {tmdbMovie},
+	},
+}
+
+// NOTE(patrik): Returns the default policy for the media type with the
+// overrides applied on top
+func GetPolicy(typ types.MediaType, overrides map[string][]string) Policy {
+	res := Policy{}
+	maps.Copy(res, DefaultPolicies[typ])
+
+	// NOTE(patrik): The config keys is lowercased (viper) so the fields
+	// is matched without case
+	for key, providers := range overrides {
+		for _, field := range Fields {
+			if strings.EqualFold(key, string(field)) {
+				res[field] = providers
+			}
+		}
+	}
+
+	return res
+}
+
+func hasValue(m provider.Media, field Field) bool {
+	switch field {
+	case FieldTitle:
+		return m.Title != ""
+	case FieldDescription:
+		return m.Description != nil && *m.Description != ""
+	case FieldScore:
+		return m.Score != nil && *m.Score > 0
+	case FieldStatus:
+		return m.Status != "" && m.Status != types.MediaStatusUnknown
+	case FieldRating:
+		return m.Rating != "" && m.Rating != types.MediaRatingUnknown
+	case FieldAiringSeason:
+		return m.AiringSeason != nil && *m.AiringSeason != ""
+	case FieldStartDate:
+		return m.StartDate != nil
+	case FieldEndDate:
+		return m.EndDate != nil
+	case FieldCover:
+		return m.CoverUrl != nil && *m.CoverUrl != ""
+	case FieldBanner:
+		return m.BannerUrl != nil && *m.BannerUrl != ""
+	case FieldLogo:
+		return m.LogoUrl != nil && *m.LogoUrl != ""
+	case FieldCreators:
+		return len(m.Creators) > 0
+	case FieldTags:
+		return len(m.Tags) > 0
+	case FieldParts:
+		return len(m.Parts) > 0
+	case FieldThemeSongs:
+		return len(m.ThemeSongs) > 0
+	case FieldRelations:
+		return len(m.Relations) > 0
+	case FieldCredits:
+		return len(m.Credits) > 0
+	case FieldVideos:
+		return len(m.Videos) > 0
+	}
+
+	return false
+}
+
+func copyField(dst *provider.Media, src provider.Media, field Field) {
+	switch field {
+	case FieldTitle:
+		dst.Title = src.Title
+	case FieldDescription:
+		dst.Description = src.Description
+	case FieldScore:
+		dst.Score = src.Score
+	case FieldStatus:
+		dst.Status = src.Status
+	case FieldRating:
+		dst.Rating = src.Rating
+	case FieldAiringSeason:
+		dst.AiringSeason = src.AiringSeason
+	case FieldStartDate:
+		dst.StartDate = src.StartDate
+		dst.Release = src.Release
+	case FieldEndDate:
+		dst.EndDate = src.EndDate
+	case FieldCover:
+		dst.CoverUrl = src.CoverUrl
+	case FieldBanner:
+		dst.BannerUrl = src.BannerUrl
+	case FieldLogo:
+		dst.LogoUrl = src.LogoUrl
+	case FieldCreators:
+		dst.Creators = src.Creators
+	case FieldTags:
+		dst.Tags = src.Tags
+	case FieldParts:
+		dst.Parts = src.Parts
+	case FieldThemeSongs:
+		dst.ThemeSongs = src.ThemeSongs
+	case FieldRelations:
+		dst.Relations = src.Relations
+	case FieldCredits:
+		dst.Credits = src.Credits
+	case FieldVideos:
+		dst.Videos = src.Videos
+	}
+}
+
+// NOTE(patrik): Returns the sources when all the data comes from one
+// provider
+func Single(providerName string, m provider.Media) Sources {
+	res := Sources{}
+	for _, field := range Fields {
+		if hasValue(m, field) {
+			res[field] = providerName
+		}
+	}
+
+	return res
+}
+
+// NOTE(patrik): Composes one media from the data of multiple providers,
+// every field is taken from the first provider (in policy order) that has
+// a value for it. The type and the provider id always comes from the
+// primary provider.
+func Merge(primary string, policy Policy, data map[string]provider.Media) (provider.Media, Sources) {
+	base := data[primary]
+
+	rest := make([]string, 0, len(data))
+	for name := range data {
+		if name != primary {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+
+	res := base
+	res.ExtraProviderIds = map[string]string{}
+
+	for _, name := range rest {
+		maps.Copy(res.ExtraProviderIds, data[name].ExtraProviderIds)
+	}
+	maps.Copy(res.ExtraProviderIds, base.ExtraProviderIds)
+
+	sources := Sources{}
+
+	for _, field := range Fields {
+		order := make([]string, 0, len(policy[field])+len(data))
+		order = append(order, policy[field]...)
+		order = append(order, primary)
+		order = append(order, rest...)
+
+		for _, name := range order {
+			m, ok := data[name]
+			if !ok || !hasValue(m, field) {
+				continue
+			}
+
+			copyField(&res, m, field)
+			sources[field] = name
+			break
+		}
+	}
+
+	return res, sources
+}
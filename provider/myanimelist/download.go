@@ -16,7 +16,6 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/kr/pretty"
-	"github.com/nanoteck137/watchbook/provider"
 	"github.com/nanoteck137/watchbook/provider/downloader"
 	"github.com/nanoteck137/watchbook/utils"
 )
@@ -274,6 +273,60 @@ func FetchAnimeData(dl *downloader.Downloader, id string, fetchPictures bool) (*
 	return &anime, nil
 }
 
+func FetchAnimePictures(dl *downloader.Downloader, id string) ([]string, error) {
+	p, err := os.MkdirTemp("", "anime*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(p)
+
+	baseUrl := fmt.Sprintf("https://myanimelist.net/anime/%s/random", id)
+
+	picturesDst := path.Join(p, "pictures.html")
+	err = dl.DownloadToFile(baseUrl+"/pics", picturesDst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download entry pictures page: %w", err)
+	}
+
+	return ExtractPictures(picturesDst)
+}
+
+func FetchAnimeStaff(dl *downloader.Downloader, id string) (AnimeStaff, error) {
+	p, err := os.MkdirTemp("", "anime*")
+	if err != nil {
+		return AnimeStaff{}, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(p)
+
+	baseUrl := fmt.Sprintf("https://myanimelist.net/anime/%s/random", id)
+
+	dst := path.Join(p, "characters.html")
+	err = dl.DownloadToFile(baseUrl+"/characters", dst)
+	if err != nil {
+		return AnimeStaff{}, fmt.Errorf("failed to download entry characters page: %w", err)
+	}
+
+	return ExtractStaff(dst)
+}
+
+func FetchAnimeVideos(dl *downloader.Downloader, id string) ([]PromotionalVideo, error) {
+	p, err := os.MkdirTemp("", "anime*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(p)
+
+	baseUrl := fmt.Sprintf("https://myanimelist.net/anime/%s/random", id)
+
+	dst := path.Join(p, "video.html")
+	err = dl.DownloadToFile(baseUrl+"/video", dst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download entry video page: %w", err)
+	}
+
+	return ExtractPromotionalVideos(dst)
+}
+
 func FetchAnimeEpisodes(dl *downloader.Downloader, id string) ([]Episode, error) {
 	p, err := os.MkdirTemp("", "anime*")
 	if err != nil {
@@ -335,7 +388,7 @@ func FetchAnimeEpisodes(dl *downloader.Downloader, id string) ([]Episode, error)
 	return episodes, nil
 }
 
-func FetchSeasonal(client *provider.HTTPClient, season string, year int) (Seasonal, error) {
+func FetchSeasonal(dl *downloader.Downloader, season string, year int) (Seasonal, error) {
 	p, err := os.MkdirTemp("", "anime*")
 	if err != nil {
 		return Seasonal{}, fmt.Errorf("failed to create temp dir: %w", err)
@@ -362,7 +415,29 @@ func FetchSeasonal(client *provider.HTTPClient, season string, year int) (Season
 	return seasonal, nil
 }
 
-func FetchSearch(query string) ([]SearchResult, error) {
+// NOTE(patrik): Number of results per page on the search page
+const SearchPageSize = 50
+
+// NOTE(patrik): The MyAnimeList ids for the anime types used by the search
+const (
+	SearchTypeAll   = 0
+	SearchTypeTV    = 1
+	SearchTypeOVA   = 2
+	SearchTypeMovie = 3
+)
+
+const genreHentai = "12"
+
+type SearchOptions struct {
+	Query string
+	// NOTE(patrik): 1 based
+	Page int
+	Type int
+
+	ExcludeAdult bool
+}
+
+func FetchSearch(dl *downloader.Downloader, opts SearchOptions) ([]SearchResult, error) {
 	p, err := os.MkdirTemp("", "anime*")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create temp dir: %w", err)
@@ -374,7 +449,34 @@ func FetchSearch(query string) ([]SearchResult, error) {
 		return nil, fmt.Errorf("failed to create entry dir: %w", err)
 	}
 
-	baseUrl := fmt.Sprintf("https://myanimelist.net/anime.php?cat=anime&q=%s&type=0&score=0&status=0&p=0&r=0&sm=0&sd=0&sy=0&em=0&ed=0&ey=0&c%%5B%%5D=a&c%%5B%%5D=b&c%%5B%%5D=c&c%%5B%%5D=f", url.QueryEscape(query))
+	// NOTE(patrik): The c[] is the columns of the result table (type,
+	// episodes, score, start date, members)
+	query := url.Values{
+		"cat":    {"anime"},
+		"q":      {opts.Query},
+		"type":   {strconv.Itoa(opts.Type)},
+		"score":  {"0"},
+		"status": {"0"},
+		"p":      {"0"},
+		"r":      {"0"},
+		"sm":     {"0"},
+		"sd":     {"0"},
+		"sy":     {"0"},
+		"em":     {"0"},
+		"ed":     {"0"},
+		"ey":     {"0"},
+		"c[]":    {"a", "b", "c", "d", "f"},
+	}
+
+	if opts.Page > 1 {
+		query.Set("show", strconv.Itoa((opts.Page-1)*SearchPageSize))
+	}
+
+	if opts.ExcludeAdult {
+		query.Set("genre_ex[]", genreHentai)
+	}
+
+	baseUrl := "https://myanimelist.net/anime.php?" + query.Encode()
 
 	err = dl.DownloadToFile(baseUrl, path.Join(p, "root.html"))
 	if err != nil {
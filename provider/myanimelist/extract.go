@@ -117,6 +117,29 @@ type Episode struct {
 	AverageScore    float64 `json:"averageScore"`
 }
 
+type StaffMember struct {
+	Url       string   `json:"url"`
+	Name      string   `json:"name"`
+	Positions []string `json:"positions"`
+}
+
+type VoiceActor struct {
+	Url       string `json:"url"`
+	Name      string `json:"name"`
+	Character string `json:"character"`
+	Language  string `json:"language"`
+}
+
+type AnimeStaff struct {
+	Staff       []StaffMember `json:"staff"`
+	VoiceActors []VoiceActor  `json:"voiceActors"`
+}
+
+type PromotionalVideo struct {
+	YoutubeId string
+	Title     string
+}
+
 type Seasonal struct {
 	Animes []SeasonalAnime
 }
@@ -126,6 +149,8 @@ type SearchResult struct {
 	Type     types.MediaType
 	Title    string
 	ImageUrl string
+	// NOTE(patrik): 0 when the start date is unknown
+	Year int
 }
 
 func ExtractAnimeData(pagePath string) (Anime, error) {
@@ -323,6 +348,27 @@ func ExtractAnimeData(pagePath string) (Anime, error) {
 		})
 	})
 
+	// NOTE(patrik): The rest of the relations (adaptations, characters...)
+	// is inside a table below the tiles
+	relatedEntriesEl.Find(".entries-table tr").Each(func(i int, s *goquery.Selection) {
+		relation := s.Find("td").First().Text()
+		relation = strings.TrimSpace(relation)
+		relation = strings.TrimSuffix(relation, ":")
+		relation = utils.FixSpaces(relation)
+
+		s.Find("td").Last().Find("a").Each(func(i int, a *goquery.Selection) {
+			href, _ := a.Attr("href")
+			title := a.Text()
+			title = strings.TrimSpace(title)
+
+			relatedEntries = append(relatedEntries, RelatedEntry{
+				Title:    title,
+				Url:      href,
+				Relation: relation,
+			})
+		})
+	})
+
 	aniDbUrl, _ := doc.Find("a[data-ga-click-type=\"external-links-anime-pc-anidb\"]").Attr("href")
 	annUrl, _ := doc.Find("a[data-ga-click-type=\"external-links-anime-pc-ann\"]").Attr("href")
 
@@ -494,6 +540,129 @@ func ExtractPictures(pagePath string) ([]string, error) {
 	return images, nil
 }
 
+var youtubeEmbedRegex = regexp.MustCompile(`youtube(?:-nocookie)?\.com/embed/([A-Za-z0-9_-]+)`)
+
+// NOTE(patrik): Extracts the promotional videos (PVs, CMs) from the videos
+// page (/anime/{id}/{name}/video), the videos is embedded youtube videos
+func ExtractPromotionalVideos(pagePath string) ([]PromotionalVideo, error) {
+	f, err := os.Open(pagePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var videos []PromotionalVideo
+	seen := map[string]bool{}
+
+	doc.Find(".promotional-video a.js-fancybox-video").Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+
+		m := youtubeEmbedRegex.FindStringSubmatch(href)
+		if m == nil || seen[m[1]] {
+			return
+		}
+		seen[m[1]] = true
+
+		title := strings.TrimSpace(s.Find(".title").First().Text())
+		if title == "" {
+			title, _ = s.Attr("title")
+			title = strings.TrimSpace(title)
+		}
+
+		videos = append(videos, PromotionalVideo{
+			YoutubeId: m[1],
+			Title:     title,
+		})
+	})
+
+	return videos, nil
+}
+
+// NOTE(patrik): Extracts the staff and the voice actors from the
+// characters page (/anime/{id}/{name}/characters)
+func ExtractStaff(pagePath string) (AnimeStaff, error) {
+	f, err := os.Open(pagePath)
+	if err != nil {
+		return AnimeStaff{}, err
+	}
+	defer f.Close()
+
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		return AnimeStaff{}, err
+	}
+
+	var res AnimeStaff
+
+	doc.Find("table.js-anime-character-table").Each(func(i int, s *goquery.Selection) {
+		character := strings.TrimSpace(s.Find(".h3_character_name").First().Text())
+
+		s.Find("tr.js-anime-character-va-lang").Each(func(i int, row *goquery.Selection) {
+			link := row.Find("a[href*='/people/']").FilterFunction(func(i int, a *goquery.Selection) bool {
+				return strings.TrimSpace(a.Text()) != ""
+			}).First()
+
+			name := strings.TrimSpace(link.Text())
+			if name == "" {
+				return
+			}
+
+			url, _ := link.Attr("href")
+			language := strings.TrimSpace(row.Find(".js-anime-character-language").First().Text())
+
+			res.VoiceActors = append(res.VoiceActors, VoiceActor{
+				Url:       url,
+				Name:      name,
+				Character: character,
+				Language:  language,
+			})
+		})
+	})
+
+	doc.Find("td.borderClass").Each(func(i int, s *goquery.Selection) {
+		if s.Closest(".js-anime-character-table").Length() > 0 {
+			return
+		}
+
+		link := s.Find("a[href*='/people/']").FilterFunction(func(i int, a *goquery.Selection) bool {
+			return strings.TrimSpace(a.Text()) != ""
+		}).First()
+
+		name := strings.TrimSpace(link.Text())
+		if name == "" {
+			return
+		}
+
+		positionsStr := strings.TrimSpace(s.Find(".spaceit_pad small").First().Text())
+		if positionsStr == "" {
+			return
+		}
+
+		var positions []string
+		for _, p := range strings.Split(positionsStr, ",") {
+			p = strings.TrimSpace(p)
+			if p != "" {
+				positions = append(positions, p)
+			}
+		}
+
+		url, _ := link.Attr("href")
+
+		res.Staff = append(res.Staff, StaffMember{
+			Url:       url,
+			Name:      name,
+			Positions: positions,
+		})
+	})
+
+	return res, nil
+}
+
 var rxClassTrim = regexp.MustCompile("[\t\r\n]")
 
 func getClassesSlice(classes string) []string {
@@ -704,6 +873,28 @@ func ExtractSeasonalAnimes(pagePath string) (Seasonal, error) {
 	}, nil
 }
 
+// NOTE(patrik): The start dates on the search page is formatted as
+// MM-DD-YY with ?? for the unknown parts
+func parseSearchStartYear(s string) int {
+	splits := strings.Split(s, "-")
+	if len(splits) != 3 {
+		return 0
+	}
+
+	yy, err := strconv.Atoi(splits[2])
+	if err != nil {
+		return 0
+	}
+
+	// NOTE(patrik): The first anime is from 1917 so the two digit years
+	// after the next few years is from the 1900s
+	if yy > (time.Now().Year()%100)+5 {
+		return 1900 + yy
+	}
+
+	return 2000 + yy
+}
+
 func ExtractSearchResults(pagePath string) ([]SearchResult, error) {
 	f, err := os.Open(pagePath)
 	if err != nil {
@@ -716,10 +907,11 @@ func ExtractSearchResults(pagePath string) ([]SearchResult, error) {
 	}
 
 	type entry struct {
-		Title string
-		Link  string
-		Type  string
-		Image string
+		Title     string
+		Link      string
+		Type      string
+		Image     string
+		StartDate string
 	}
 
 	var entries []entry
@@ -740,11 +932,15 @@ func ExtractSearchResults(pagePath string) ([]SearchResult, error) {
 		typ := s.Find("td:nth-child(3)").Text()
 		typ = strings.TrimSpace(typ)
 
+		startDate := s.Find("td:nth-child(6)").Text()
+		startDate = strings.TrimSpace(startDate)
+
 		entries = append(entries, entry{
-			Title: title,
-			Link:  href,
-			Type:  typ,
-			Image: img,
+			Title:     title,
+			Link:      href,
+			Type:      typ,
+			Image:     img,
+			StartDate: startDate,
 		})
 	})
 
@@ -764,6 +960,7 @@ func ExtractSearchResults(pagePath string) ([]SearchResult, error) {
 			Type:     ConvertAnimeType(e.Type),
 			Title:    e.Title,
 			ImageUrl: e.Image,
+			Year:     parseSearchStartYear(e.StartDate),
 		}
 	}
 
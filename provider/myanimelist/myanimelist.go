@@ -2,6 +2,9 @@ package myanimelist
 
 import (
 	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -37,12 +40,16 @@ type AnimeEntry struct {
 	EndDate   *string    `json:"endDate"`
 	Release   *time.Time `json:"release"`
 
-	Studios []string `json:"studios"`
-	Tags    []string `json:"tags"`
+	Studios     []string `json:"studios"`
+	StudioNames []string `json:"studioNames"`
+	Tags        []string `json:"tags"`
 
 	CoverImageUrl string `json:"coverImageUrl"`
 
 	EpisodeCount *int64 `json:"episodeCount"`
+
+	ThemeSongs     []ThemeSong    `json:"themeSongs"`
+	RelatedEntries []RelatedEntry `json:"relatedEntries"`
 }
 
 func parseDateTimeUTC(dateStr, schedule string) (time.Time, error) {
@@ -111,7 +118,7 @@ func parseDateTimeUTC(dateStr, schedule string) (time.Time, error) {
 	return localTime.UTC(), nil
 }
 
-func fetchAnimeData(malId string) (AnimeEntry, error) {
+func fetchAnimeData(dl *downloader.Downloader, malId string) (AnimeEntry, error) {
 	data, err := FetchAnimeData(dl, malId, false)
 	if err != nil {
 		return AnimeEntry{}, err
@@ -192,21 +199,24 @@ func fetchAnimeData(malId string) (AnimeEntry, error) {
 	}
 
 	res := AnimeEntry{
-		Type:          ConvertAnimeType(data.Type),
-		Title:         data.Title,
-		TitleEnglish:  data.TitleEnglish,
-		Description:   strings.TrimSpace(desc.String()),
-		Score:         data.Score,
-		Status:        ConvertAnimeStatus(data.Status),
-		Rating:        ConvertAnimeRating(data.Rating),
-		AiringSeason:  utils.Slug(data.Premiered),
-		StartDate:     startDate,
-		EndDate:       endDate,
-		Studios:       studios,
-		Tags:          tags,
-		CoverImageUrl: data.CoverImageUrl,
-		EpisodeCount:  data.EpisodeCount,
-		Release:       release,
+		Type:           ConvertAnimeType(data.Type),
+		Title:          data.Title,
+		TitleEnglish:   data.TitleEnglish,
+		Description:    strings.TrimSpace(desc.String()),
+		Score:          data.Score,
+		Status:         ConvertAnimeStatus(data.Status),
+		Rating:         ConvertAnimeRating(data.Rating),
+		AiringSeason:   utils.Slug(data.Premiered),
+		StartDate:      startDate,
+		EndDate:        endDate,
+		Studios:        studios,
+		StudioNames:    data.Studios,
+		Tags:           tags,
+		CoverImageUrl:  data.CoverImageUrl,
+		EpisodeCount:   data.EpisodeCount,
+		Release:        release,
+		ThemeSongs:     data.ThemeSongs,
+		RelatedEntries: data.RelatedEntries,
 	}
 
 	return res, nil
@@ -295,6 +305,34 @@ const AnimeProviderName string = "myanimelist-anime"
 var _ (provider.Provider) = (*MyAnimeListAnimeProvider)(nil)
 
 type MyAnimeListAnimeProvider struct {
+	dl *downloader.Downloader
+}
+
+func NewMyAnimeListAnimeProvider(config provider.Config) *MyAnimeListAnimeProvider {
+	d := dl
+
+	// NOTE(patrik): Only create a new downloader if the config overrides
+	// the defaults, the shared one is also used by the watchlist import
+	if config.RateLimit > 0 || config.UserAgent != "" {
+		limit := rate.Every(4 * time.Second)
+		if config.RateLimit > 0 {
+			limit = rate.Limit(config.RateLimit)
+		}
+
+		burst := 10
+		if config.RateBurst > 0 {
+			burst = config.RateBurst
+		}
+
+		d = downloader.NewDownloader(
+			rate.NewLimiter(limit, burst),
+			config.GetUserAgent(UserAgent),
+		)
+	}
+
+	return &MyAnimeListAnimeProvider{
+		dl: d,
+	}
 }
 
 func (m *MyAnimeListAnimeProvider) Info() provider.Info {
@@ -313,7 +351,7 @@ func (m *MyAnimeListAnimeProvider) GetCollection(c provider.Context, id string)
 }
 
 func (m *MyAnimeListAnimeProvider) GetMedia(c provider.Context, id string) (provider.Media, error) {
-	anime, err := fetchAnimeData(id)
+	anime, err := fetchAnimeData(m.dl, id)
 	if err != nil {
 		return provider.Media{}, err
 	}
@@ -361,7 +399,26 @@ func (m *MyAnimeListAnimeProvider) GetMedia(c provider.Context, id string) (prov
 
 	parts := []provider.MediaPart{}
 
-	episodes, _ := FetchAnimeEpisodes(dl, id)
+	episodes, _ := FetchAnimeEpisodes(m.dl, id)
+
+	// NOTE(patrik): The credits and videos is left as nil when the pages
+	// fails to fetch so the update keeps the existing ones instead of
+	// replacing them with a partial list
+	var credits []provider.Credit
+	staff, err := FetchAnimeStaff(m.dl, id)
+	if err != nil {
+		slog.Warn("failed to fetch anime staff", "id", id, "err", err)
+	} else {
+		credits = convertCredits(anime.StudioNames, staff)
+	}
+
+	var videos []provider.Video
+	animeVideos, err := FetchAnimeVideos(m.dl, id)
+	if err != nil {
+		slog.Warn("failed to fetch anime videos", "id", id, "err", err)
+	} else {
+		videos = convertVideos(animeVideos)
+	}
 
 	numEpisodesFound := len(episodes)
 	missingEpisodes := max(episodeCount-numEpisodesFound, 0)
@@ -413,20 +470,275 @@ func (m *MyAnimeListAnimeProvider) GetMedia(c provider.Context, id string) (prov
 		Creators:         anime.Studios,
 		Tags:             anime.Tags,
 		Parts:            parts,
+		ThemeSongs:       convertThemeSongs(anime.ThemeSongs),
+		Relations:        convertRelatedEntries(anime.RelatedEntries),
+		Credits:          credits,
+		Videos:           videos,
 		ExtraProviderIds: map[string]string{},
 	}, nil
 }
 
+func convertThemeSongs(themeSongs []ThemeSong) []provider.ThemeSong {
+	res := make([]provider.ThemeSong, 0, len(themeSongs))
+
+	counts := map[types.ThemeSongType]int{}
+	for _, t := range themeSongs {
+		typ := types.ThemeSongTypeOpening
+		if t.Type == ThemeSongEnding {
+			typ = types.ThemeSongTypeEnding
+		}
+
+		counts[typ]++
+
+		// NOTE(patrik): Entries with only one song doesn't have an index
+		// on the page
+		index := int(t.Index)
+		if index <= 0 {
+			index = counts[typ]
+		}
+
+		if t.Name == "" {
+			continue
+		}
+
+		res = append(res, provider.ThemeSong{
+			Type:   typ,
+			Index:  index,
+			Name:   t.Name,
+			Artist: t.Artist,
+		})
+	}
+
+	return res
+}
+
+// NOTE(patrik): The urls inside the table is relative ("/manga/2/Berserk")
+var relatedEntryUrlRegex = regexp.MustCompile(`(?:^|myanimelist\.net)/(anime|manga)/(\d+)`)
+
+func convertRelatedEntries(entries []RelatedEntry) []provider.MediaRelation {
+	res := make([]provider.MediaRelation, 0, len(entries))
+
+	for _, entry := range entries {
+		m := relatedEntryUrlRegex.FindStringSubmatch(entry.Url)
+		if m == nil {
+			continue
+		}
+
+		providerName := AnimeProviderName
+		if m[1] == "manga" {
+			providerName = provider.ProviderNameMyAnimeListManga
+		}
+
+		res = append(res, provider.MediaRelation{
+			Type:         ConvertRelationType(entry.Relation),
+			ProviderName: providerName,
+			ProviderId:   m[2],
+			Title:        entry.Title,
+		})
+	}
+
+	return res
+}
+
+// NOTE(patrik): The tiles has the type of the entry after the relation
+// ("Sequel (TV)")
+func ConvertRelationType(relation string) types.MediaRelationType {
+	relation, _, _ = strings.Cut(relation, "(")
+	relation = strings.TrimSpace(relation)
+
+	switch strings.ToLower(relation) {
+	case "sequel":
+		return types.MediaRelationTypeSequel
+	case "prequel":
+		return types.MediaRelationTypePrequel
+	case "side story":
+		return types.MediaRelationTypeSideStory
+	case "parent story":
+		return types.MediaRelationTypeParentStory
+	case "alternative setting", "alternative version":
+		return types.MediaRelationTypeAlternative
+	case "spin-off":
+		return types.MediaRelationTypeSpinOff
+	case "summary":
+		return types.MediaRelationTypeSummary
+	case "full story":
+		return types.MediaRelationTypeFullStory
+	case "adaptation":
+		return types.MediaRelationTypeAdaptation
+	case "character":
+		return types.MediaRelationTypeCharacter
+	}
+
+	return types.MediaRelationTypeOther
+}
+
+var personUrlRegex = regexp.MustCompile(`/people/(\d+)`)
+
+// NOTE(patrik): MyAnimeList uses "Last, First" for the names, flip them so
+// the names matches the other providers
+func convertPersonName(name string) string {
+	last, first, ok := strings.Cut(name, ", ")
+	if !ok || strings.Contains(first, ",") {
+		return name
+	}
+
+	return first + " " + last
+}
+
+func ConvertStaffPosition(position string) types.PersonRole {
+	switch position {
+	case "Director":
+		return types.PersonRoleDirector
+	case "Script", "Screenplay", "Series Composition":
+		return types.PersonRoleWriter
+	case "Original Creator":
+		return types.PersonRoleCreator
+	case "Music":
+		return types.PersonRoleComposer
+	}
+
+	return types.PersonRoleStaff
+}
+
+func convertCredits(studios []string, staff AnimeStaff) []provider.Credit {
+	var res []provider.Credit
+
+	for _, studio := range studios {
+		studio = strings.TrimSpace(studio)
+		if studio == "" {
+			continue
+		}
+
+		res = append(res, provider.Credit{
+			Name:       studio,
+			PersonType: types.PersonTypeOrganization,
+			Role:       types.PersonRoleStudio,
+		})
+	}
+
+	personId := func(url string) string {
+		m := personUrlRegex.FindStringSubmatch(url)
+		if m == nil {
+			return ""
+		}
+
+		return m[1]
+	}
+
+	for _, member := range staff.Staff {
+		id := personId(member.Url)
+
+		for _, position := range member.Positions {
+			res = append(res, provider.Credit{
+				Name:         convertPersonName(member.Name),
+				PersonType:   types.PersonTypePerson,
+				Role:         ConvertStaffPosition(position),
+				ProviderName: provider.ProviderNameMyAnimeListPerson,
+				ProviderId:   id,
+			})
+		}
+	}
+
+	for _, va := range staff.VoiceActors {
+		// NOTE(patrik): Only the original cast, the dubs would add a lot
+		// of people for every character
+		if va.Language != "Japanese" {
+			continue
+		}
+
+		res = append(res, provider.Credit{
+			Name:         convertPersonName(va.Name),
+			PersonType:   types.PersonTypePerson,
+			Role:         types.PersonRoleCast,
+			Character:    convertPersonName(va.Character),
+			ProviderName: provider.ProviderNameMyAnimeListPerson,
+			ProviderId:   personId(va.Url),
+		})
+	}
+
+	return res
+}
+
+// NOTE(patrik): The promotional videos is named like "PV 1", "CM 2" or
+// "Teaser PV"
+func convertVideoType(title string) types.VideoType {
+	t := strings.ToLower(title)
+
+	switch {
+	case strings.Contains(t, "teaser"):
+		return types.VideoTypeTeaser
+	case strings.HasPrefix(t, "cm"):
+		return types.VideoTypeClip
+	case strings.Contains(t, "opening"):
+		return types.VideoTypeOpening
+	case strings.Contains(t, "ending"):
+		return types.VideoTypeEnding
+	}
+
+	return types.VideoTypeTrailer
+}
+
+func convertVideos(videos []PromotionalVideo) []provider.Video {
+	res := make([]provider.Video, 0, len(videos))
+	for _, video := range videos {
+		res = append(res, provider.Video{
+			Site:     types.VideoSiteYoutube,
+			Key:      video.YoutubeId,
+			Type:     convertVideoType(video.Title),
+			Name:     video.Title,
+			Language: "ja",
+			Official: true,
+		})
+	}
+
+	return res
+}
+
 func (m *MyAnimeListAnimeProvider) SearchCollection(c provider.Context, query string) ([]provider.SearchResult, error) {
 	panic("unsupported")
 }
 
 func (m *MyAnimeListAnimeProvider) SearchMedia(c provider.Context, query string) ([]provider.SearchResult, error) {
-	items, err := FetchSearch(query)
+	items, err := FetchSearch(m.dl, SearchOptions{
+		Query: query,
+		Page:  1,
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	return convertSearchResults(items), nil
+}
+
+var _ provider.SearchParamsProvider = (*MyAnimeListAnimeProvider)(nil)
+
+func (m *MyAnimeListAnimeProvider) SearchMediaWithParams(c provider.Context, params provider.SearchParams) (provider.SearchPage, error) {
+	// NOTE(patrik): The movies can be filtered by MyAnimeList, the other
+	// types is multiple MyAnimeList types so they are filtered after
+	typ := SearchTypeAll
+	switch params.MediaType {
+	case types.MediaTypeAnimeMovie, types.MediaTypeMovie:
+		typ = SearchTypeMovie
+	}
+
+	items, err := FetchSearch(m.dl, SearchOptions{
+		Query:        params.Query,
+		Page:         params.GetPage(),
+		Type:         typ,
+		ExcludeAdult: !params.IncludeAdult,
+	})
+	if err != nil {
+		return provider.SearchPage{}, err
+	}
+
+	return provider.SearchPage{
+		Results:     provider.FilterSearchResults(convertSearchResults(items), params),
+		Page:        params.GetPage(),
+		HasNextPage: len(items) >= SearchPageSize,
+	}, nil
+}
+
+func convertSearchResults(items []SearchResult) []provider.SearchResult {
 	res := make([]provider.SearchResult, len(items))
 
 	for i, item := range items {
@@ -436,8 +748,82 @@ func (m *MyAnimeListAnimeProvider) SearchMedia(c provider.Context, query string)
 			Title:      item.Title,
 			MediaType:  item.Type,
 			ImageUrl:   item.ImageUrl,
+			Year:       item.Year,
 		}
 	}
 
+	return res
+}
+
+var _ (provider.SeasonalProvider) = (*MyAnimeListAnimeProvider)(nil)
+
+func (m *MyAnimeListAnimeProvider) GetSeasonal(c provider.Context, season string, year int) ([]provider.SearchResult, error) {
+	seasonal, err := FetchSeasonal(m.dl, season, year)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]provider.SearchResult, 0, len(seasonal.Animes))
+
+	for _, anime := range seasonal.Animes {
+		title := anime.Title
+		if anime.TitleEnglish != "" {
+			title = anime.TitleEnglish
+		}
+
+		res = append(res, provider.SearchResult{
+			SearchType: provider.SearchResultTypeMedia,
+			ProviderId: anime.Id,
+			Title:      title,
+			MediaType:  ConvertAnimeType(anime.Type),
+			ImageUrl:   anime.CoverImageUrl,
+			Year:       provider.ParseYear(anime.StartDate),
+		})
+	}
+
+	return res, nil
+}
+
+var _ (provider.ImageProvider) = (*MyAnimeListAnimeProvider)(nil)
+
+// NOTE(patrik): The picture page only has posters
+func (m *MyAnimeListAnimeProvider) GetMediaImages(c provider.Context, id string) ([]provider.Image, error) {
+	pictures, err := FetchAnimePictures(m.dl, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]provider.Image, 0, len(pictures))
+	for _, picture := range pictures {
+		res = append(res, provider.Image{
+			Type: types.ImageTypeCover,
+			Url:  picture,
+		})
+	}
+
 	return res, nil
 }
+
+var _ (provider.LinkResolver) = (*MyAnimeListAnimeProvider)(nil)
+
+// NOTE(patrik): Handles myanimelist.net/anime/52991/Sousou_no_Frieren
+func (m *MyAnimeListAnimeProvider) ResolveLink(u *url.URL) (provider.ResolvedLink, bool) {
+	if !provider.IsHost(u, "myanimelist.net") {
+		return provider.ResolvedLink{}, false
+	}
+
+	segments := provider.PathSegments(u)
+	if len(segments) < 2 || segments[0] != "anime" {
+		return provider.ResolvedLink{}, false
+	}
+
+	id, ok := provider.LeadingId(segments[1])
+	if !ok {
+		return provider.ResolvedLink{}, false
+	}
+
+	return provider.ResolvedLink{
+		ProviderId: id,
+		Type:       provider.SearchResultTypeMedia,
+	}, true
+}
@@ -0,0 +1,241 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/nanoteck137/watchbook/provider"
+)
+
+const (
+	DefaultTimeout = 30 * time.Second
+
+	// NOTE(patrik): Max size of the response a plugin can write to stdout
+	maxResponseSize = 16 * 1024 * 1024
+	// NOTE(patrik): How much of stderr to keep for the error messages
+	maxStderrSize = 4 * 1024
+)
+
+var (
+	ErrTimeout         = errors.New("plugin timed out")
+	ErrResponseTooBig  = errors.New("plugin response too big")
+	ErrInvalidResponse = errors.New("plugin returned an invalid response")
+)
+
+type Config struct {
+	Name    string
+	Command string
+	Args    []string
+	Env     []string
+	Dir     string
+	Timeout time.Duration
+}
+
+var _ provider.Provider = (*PluginProvider)(nil)
+
+type PluginProvider struct {
+	config Config
+	info   provider.Info
+}
+
+// NOTE(patrik): Runs the plugin once to get the info, if that fails the
+// provider is still returned but is marked as disabled
+func NewPluginProvider(ctx context.Context, config Config) *PluginProvider {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+
+	p := &PluginProvider{
+		config: config,
+		info: provider.Info{
+			Name:        config.Name,
+			DisplayName: config.Name,
+		},
+	}
+
+	info, err := call[Info](ctx, config, Request{Method: MethodInfo})
+	if err != nil {
+		p.info.Disabled = true
+		p.info.DisabledReason = err.Error()
+		return p
+	}
+
+	if info.DisplayName != "" {
+		p.info.DisplayName = info.DisplayName
+	}
+
+	p.info.SupportGetMedia = info.SupportGetMedia
+	p.info.SupportSearchMedia = info.SupportSearchMedia
+	p.info.SupportGetCollection = info.SupportGetCollection
+	p.info.SupportSearchCollection = info.SupportSearchCollection
+
+	return p
+}
+
+func (p *PluginProvider) Info() provider.Info {
+	return p.info
+}
+
+func (p *PluginProvider) GetMedia(c provider.Context, id string) (provider.Media, error) {
+	res, err := call[Media](c.Context(), p.config, Request{
+		Method: MethodGetMedia,
+		Id:     id,
+	})
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	return res.convert(id), nil
+}
+
+func (p *PluginProvider) SearchMedia(c provider.Context, query string) ([]provider.SearchResult, error) {
+	res, err := call[[]SearchResult](c.Context(), p.config, Request{
+		Method: MethodSearchMedia,
+		Query:  query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return convertSearchResults(res, provider.SearchResultTypeMedia), nil
+}
+
+func (p *PluginProvider) GetCollection(c provider.Context, id string) (provider.Collection, error) {
+	res, err := call[Collection](c.Context(), p.config, Request{
+		Method: MethodGetCollection,
+		Id:     id,
+	})
+	if err != nil {
+		return provider.Collection{}, err
+	}
+
+	return res.convert(id), nil
+}
+
+func (p *PluginProvider) SearchCollection(c provider.Context, query string) ([]provider.SearchResult, error) {
+	res, err := call[[]SearchResult](c.Context(), p.config, Request{
+		Method: MethodSearchCollection,
+		Query:  query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return convertSearchResults(res, provider.SearchResultTypeCollection), nil
+}
+
+// NOTE(patrik): Writer that keeps the first max bytes and drops the rest
+type limitedBuffer struct {
+	buf      bytes.Buffer
+	max      int
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	left := b.max - b.buf.Len()
+	if left <= 0 {
+		b.overflow = len(p) > 0 || b.overflow
+		return len(p), nil
+	}
+
+	if len(p) > left {
+		b.overflow = true
+		b.buf.Write(p[:left])
+		return len(p), nil
+	}
+
+	return b.buf.Write(p)
+}
+
+// NOTE(patrik): Starts the plugin, writes the request to stdin and reads
+// the response from stdout, the process is killed if it takes longer then
+// the timeout
+func call[T any](ctx context.Context, config Config, req Request) (T, error) {
+	var res T
+
+	req.Version = ProtocolVersion
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return res, fmt.Errorf("failed to marshal plugin request: %w", err)
+	}
+	data = append(data, '\n')
+
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	stdout := &limitedBuffer{max: maxResponseSize}
+	stderr := &limitedBuffer{max: maxStderrSize}
+
+	cmd := exec.CommandContext(ctx, config.Command, config.Args...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Dir = config.Dir
+	cmd.Env = append(os.Environ(), config.Env...)
+	// NOTE(patrik): Don't wait forever on children of the plugin that
+	// keeps stdout/stderr open after the plugin has been killed
+	cmd.WaitDelay = 2 * time.Second
+
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return res, fmt.Errorf("plugin %q (%s): %w", config.Name, req.Method, ErrTimeout)
+		}
+
+		return res, ctx.Err()
+	}
+
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return res, fmt.Errorf("plugin %q (%s) exited with code %d: %s", config.Name, req.Method, exitErr.ExitCode(), stderrMessage(stderr))
+		}
+
+		return res, fmt.Errorf("failed to run plugin %q: %w", config.Name, err)
+	}
+
+	if stdout.overflow {
+		return res, fmt.Errorf("plugin %q (%s): %w", config.Name, req.Method, ErrResponseTooBig)
+	}
+
+	var resp Response[T]
+	err = json.Unmarshal(stdout.buf.Bytes(), &resp)
+	if err != nil {
+		return res, fmt.Errorf("plugin %q (%s): %w: %w", config.Name, req.Method, ErrInvalidResponse, err)
+	}
+
+	if resp.Error != nil {
+		if resp.Error.Code == ErrorCodeNotFound {
+			return res, provider.NotFound
+		}
+
+		return res, fmt.Errorf("plugin %q (%s): %s (%s)", config.Name, req.Method, resp.Error.Message, resp.Error.Code)
+	}
+
+	if resp.Result == nil {
+		return res, fmt.Errorf("plugin %q (%s): %w: missing result", config.Name, req.Method, ErrInvalidResponse)
+	}
+
+	return *resp.Result, nil
+}
+
+func stderrMessage(b *limitedBuffer) string {
+	msg := strings.TrimSpace(b.buf.String())
+	if msg == "" {
+		return "no output on stderr"
+	}
+
+	if b.overflow {
+		msg += "..."
+	}
+
+	return msg
+}
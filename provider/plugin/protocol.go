@@ -0,0 +1,268 @@
+package plugin
+
+import (
+	"time"
+
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+// NOTE(patrik): The wire types of the plugin protocol, see README.md for
+// the full documentation
+
+type Method string
+
+const (
+	MethodInfo             Method = "info"
+	MethodGetMedia         Method = "getMedia"
+	MethodSearchMedia      Method = "searchMedia"
+	MethodGetCollection    Method = "getCollection"
+	MethodSearchCollection Method = "searchCollection"
+)
+
+const ProtocolVersion = 1
+
+type Request struct {
+	Version int    `json:"version"`
+	Method  Method `json:"method"`
+	Id      string `json:"id,omitempty"`
+	Query   string `json:"query,omitempty"`
+}
+
+const (
+	ErrorCodeNotFound = "not_found"
+)
+
+type ResponseError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type Response[T any] struct {
+	Result *T             `json:"result"`
+	Error  *ResponseError `json:"error"`
+}
+
+type Info struct {
+	DisplayName string `json:"displayName"`
+
+	SupportGetMedia         bool `json:"supportGetMedia"`
+	SupportSearchMedia      bool `json:"supportSearchMedia"`
+	SupportGetCollection    bool `json:"supportGetCollection"`
+	SupportSearchCollection bool `json:"supportSearchCollection"`
+}
+
+type MediaPart struct {
+	Name   string `json:"name"`
+	Number int    `json:"number"`
+	// NOTE(patrik): Format: 2006-01-02
+	ReleaseDate string `json:"releaseDate"`
+}
+
+type Media struct {
+	Type         types.MediaType   `json:"type"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	Score        *float64          `json:"score"`
+	Status       types.MediaStatus `json:"status"`
+	Rating       types.MediaRating `json:"rating"`
+	AiringSeason string            `json:"airingSeason"`
+
+	// NOTE(patrik): Format: 2006-01-02
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	// NOTE(patrik): Format: RFC3339
+	Release string `json:"release"`
+
+	CoverUrl  string `json:"coverUrl"`
+	LogoUrl   string `json:"logoUrl"`
+	BannerUrl string `json:"bannerUrl"`
+
+	Creators []string    `json:"creators"`
+	Tags     []string    `json:"tags"`
+	Parts    []MediaPart `json:"parts"`
+
+	ExtraProviderIds map[string]string `json:"extraProviderIds"`
+}
+
+type CollectionItem struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+type Collection struct {
+	Type      types.CollectionType `json:"type"`
+	Name      string               `json:"name"`
+	CoverUrl  string               `json:"coverUrl"`
+	LogoUrl   string               `json:"logoUrl"`
+	BannerUrl string               `json:"bannerUrl"`
+
+	Items []CollectionItem `json:"items"`
+
+	ExtraProviderIds map[string]string `json:"extraProviderIds"`
+}
+
+type SearchResult struct {
+	Id        string          `json:"id"`
+	Title     string          `json:"title"`
+	MediaType types.MediaType `json:"mediaType"`
+	ImageUrl  string          `json:"imageUrl"`
+	Year      int             `json:"year"`
+
+	ExtraProviderIds map[string]string `json:"extraProviderIds"`
+}
+
+func strPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+func parseDate(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+
+	d, err := time.Parse(types.MediaDateLayout, s)
+	if err != nil {
+		return nil
+	}
+
+	return &d
+}
+
+func nonNil[T any](a []T) []T {
+	if a == nil {
+		return []T{}
+	}
+
+	return a
+}
+
+func (m Media) convert(id string) provider.Media {
+	var release *time.Time
+	if m.Release != "" {
+		t, err := time.Parse(time.RFC3339, m.Release)
+		if err == nil {
+			release = &t
+		}
+	}
+
+	typ := m.Type
+	if !types.IsValidMediaType(typ) {
+		typ = types.MediaTypeUnknown
+	}
+
+	status := m.Status
+	if !types.IsValidMediaStatus(status) {
+		status = types.MediaStatusUnknown
+	}
+
+	rating := m.Rating
+	if !types.IsValidMediaRating(rating) {
+		rating = types.MediaRatingUnknown
+	}
+
+	parts := make([]provider.MediaPart, len(m.Parts))
+	for i, part := range m.Parts {
+		number := part.Number
+		if number == 0 {
+			number = i + 1
+		}
+
+		parts[i] = provider.MediaPart{
+			Name:        part.Name,
+			Number:      number,
+			ReleaseDate: parseDate(part.ReleaseDate),
+		}
+	}
+
+	extraProviderIds := m.ExtraProviderIds
+	if extraProviderIds == nil {
+		extraProviderIds = map[string]string{}
+	}
+
+	return provider.Media{
+		ProviderId:       id,
+		Type:             typ,
+		Title:            m.Title,
+		Description:      strPtr(m.Description),
+		Score:            m.Score,
+		Status:           status,
+		Rating:           rating,
+		AiringSeason:     strPtr(m.AiringSeason),
+		StartDate:        parseDate(m.StartDate),
+		EndDate:          parseDate(m.EndDate),
+		Release:          release,
+		CoverUrl:         strPtr(m.CoverUrl),
+		LogoUrl:          strPtr(m.LogoUrl),
+		BannerUrl:        strPtr(m.BannerUrl),
+		Creators:         nonNil(m.Creators),
+		Tags:             nonNil(m.Tags),
+		Parts:            parts,
+		ExtraProviderIds: extraProviderIds,
+	}
+}
+
+func (c Collection) convert(id string) provider.Collection {
+	typ := c.Type
+	if !types.IsValidCollectionType(typ) {
+		typ = types.CollectionTypeUnknown
+	}
+
+	items := make([]provider.CollectionItem, len(c.Items))
+	for i, item := range c.Items {
+		position := item.Position
+		if position == 0 {
+			position = i + 1
+		}
+
+		items[i] = provider.CollectionItem{
+			Id:       item.Id,
+			Name:     item.Name,
+			Position: position,
+		}
+	}
+
+	extraProviderIds := c.ExtraProviderIds
+	if extraProviderIds == nil {
+		extraProviderIds = map[string]string{}
+	}
+
+	return provider.Collection{
+		ProviderId:       id,
+		Type:             typ,
+		Name:             c.Name,
+		CoverUrl:         strPtr(c.CoverUrl),
+		LogoUrl:          strPtr(c.LogoUrl),
+		BannerUrl:        strPtr(c.BannerUrl),
+		Items:            items,
+		ExtraProviderIds: extraProviderIds,
+	}
+}
+
+func convertSearchResults(items []SearchResult, searchType provider.SearchResultType) []provider.SearchResult {
+	res := make([]provider.SearchResult, len(items))
+
+	for i, item := range items {
+		mediaType := item.MediaType
+		if !types.IsValidMediaType(mediaType) {
+			mediaType = types.MediaTypeUnknown
+		}
+
+		res[i] = provider.SearchResult{
+			SearchType:       searchType,
+			ProviderId:       item.Id,
+			Title:            item.Title,
+			MediaType:        mediaType,
+			ImageUrl:         item.ImageUrl,
+			Year:             item.Year,
+			ExtraProviderIds: item.ExtraProviderIds,
+		}
+	}
+
+	return res
+}
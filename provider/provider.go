@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/nanoteck137/watchbook/tools/cache"
@@ -13,10 +14,37 @@ import (
 const (
 	ProviderNameMyAnimeListAnime string = "myanimelist-anime"
 	ProviderNameAnilistAnime     string = "anilist-anime"
+	ProviderNameAnilistManga     string = "anilist-manga"
 	ProviderNameTheMovieDbMovie  string = "tmdb-movie"
 	ProviderNameTheMovieDbTv     string = "tmdb-tv"
+	ProviderNameRawgGame         string = "rawg-game"
+	ProviderNameComicVineComic   string = "comicvine-comic"
+
+	// NOTE(patrik): Not a provider, only used to store the id inside the
+	// providers list
+	ProviderNameImdb string = "imdb"
+	// NOTE(patrik): Not a provider, used by the relations from the anime
+	// pages on MyAnimeList
+	ProviderNameMyAnimeListManga string = "myanimelist-manga"
+
+	// NOTE(patrik): Not providers, only used to store the ids of the
+	// people
+	ProviderNameMyAnimeListPerson string = "myanimelist-person"
+	ProviderNameTheMovieDbPerson  string = "tmdb-person"
+	ProviderNameTheMovieDbCompany string = "tmdb-company"
 )
 
+// NOTE(patrik): Ids that can be inside the providers list but doesn't have
+// a provider, maps to the display name
+var ExternalIds = map[string]string{
+	ProviderNameImdb:             "IMDb",
+	ProviderNameMyAnimeListManga: "MyAnimeList Manga",
+
+	ProviderNameMyAnimeListPerson: "MyAnimeList",
+	ProviderNameTheMovieDbPerson:  "TheMovieDB",
+	ProviderNameTheMovieDbCompany: "TheMovieDB",
+}
+
 type SearchResultType string
 
 const (
@@ -31,6 +59,30 @@ type SearchResult struct {
 	// TODO(patrik): Should this be here?
 	MediaType types.MediaType `json:"mediaType"`
 	ImageUrl  string          `json:"imageUrl"`
+
+	// NOTE(patrik): Optional, used to find results from different
+	// providers that points to the same entry
+	Year             int               `json:"year,omitempty"`
+	ExtraProviderIds map[string]string `json:"extraProviderIds,omitempty"`
+
+	// NOTE(patrik): Set by the providers that knows if the entry is adult
+	// content, used by FilterSearchResults
+	Adult bool `json:"adult,omitempty"`
+}
+
+// NOTE(patrik): Returns the year from a date string (2006-01-02 or 2006),
+// returns 0 if the string doesn't start with a year
+func ParseYear(s string) int {
+	if len(s) < 4 {
+		return 0
+	}
+
+	year, err := strconv.Atoi(s[:4])
+	if err != nil {
+		return 0
+	}
+
+	return year
 }
 
 type MediaPart struct {
@@ -39,6 +91,47 @@ type MediaPart struct {
 	ReleaseDate *time.Time
 }
 
+type ThemeSong struct {
+	Type   types.ThemeSongType `json:"type"`
+	Index  int                 `json:"index"`
+	Name   string              `json:"name"`
+	Artist string              `json:"artist"`
+}
+
+// NOTE(patrik): Reference to a video on a video site (youtube), the key is
+// the id of the video on the site
+type Video struct {
+	Site     types.VideoSite `json:"site"`
+	Key      string          `json:"key"`
+	Type     types.VideoType `json:"type"`
+	Name     string          `json:"name"`
+	Language string          `json:"language"`
+	Official bool            `json:"official"`
+}
+
+// NOTE(patrik): Points to another entry on a provider, the entry doesn't
+// need to be inside the library
+type MediaRelation struct {
+	Type         types.MediaRelationType `json:"type"`
+	ProviderName string                  `json:"providerName"`
+	ProviderId   string                  `json:"providerId"`
+	Title        string                  `json:"title"`
+}
+
+// NOTE(patrik): Person or organization that worked on the media, the
+// provider name and id is optional and points to the person on the
+// provider (not a real provider, see ProviderNameTheMovieDbPerson)
+type Credit struct {
+	Name       string           `json:"name"`
+	PersonType types.PersonType `json:"personType"`
+	Role       types.PersonRole `json:"role"`
+	// NOTE(patrik): Only set for the cast
+	Character string `json:"character"`
+
+	ProviderName string `json:"providerName"`
+	ProviderId   string `json:"providerId"`
+}
+
 type Media struct {
 	ProviderId string          `json:"id"`
 	Type       types.MediaType `json:"type"`
@@ -64,6 +157,12 @@ type Media struct {
 
 	Parts []MediaPart `json:"parts"`
 
+	ThemeSongs []ThemeSong     `json:"themeSongs"`
+	Relations  []MediaRelation `json:"relations"`
+
+	Credits []Credit `json:"credits"`
+	Videos  []Video  `json:"videos"`
+
 	ExtraProviderIds map[string]string `json:"extraProviderIds"`
 }
 
@@ -97,6 +196,15 @@ type Info struct {
 
 	SupportGetCollection    bool
 	SupportSearchCollection bool
+
+	// NOTE(patrik): Skip the result cache, used by providers where the
+	// data is cheap to get and can change at any time (local files)
+	NoCache bool
+
+	// NOTE(patrik): Set when the provider is missing something it needs
+	// to work, like an api key
+	Disabled       bool
+	DisabledReason string
 }
 
 func (i Info) GetDisplayName() string {
@@ -135,7 +243,13 @@ const (
 	searchTTL = 1 * time.Hour
 )
 
-var ErrNoProvider = errors.New("no provider")
+var (
+	ErrNoProvider       = errors.New("no provider")
+	ErrProviderDisabled = errors.New("provider is disabled")
+
+	ErrCollectionsNotSupported = errors.New("provider doesn't support collections")
+	ErrMediaSearchNotSupported = errors.New("provider doesn't support media search")
+)
 
 type ProviderManager struct {
 	providers     map[string]Provider
@@ -170,6 +284,28 @@ func (p *ProviderManager) IsValidProvider(name string) bool {
 	return ok
 }
 
+func (p *ProviderManager) ClearCache(name string) error {
+	return p.cache.ClearByProviderName(name)
+}
+
+// NOTE(patrik): Returns the number of removed cache entries
+func (p *ProviderManager) ClearExpiredCache() (int64, error) {
+	return p.cache.ClearExpired()
+}
+
+func (p *ProviderManager) getProvider(name string) (Provider, error) {
+	provider, ok := p.providers[name]
+	if !ok {
+		return nil, ErrNoProvider
+	}
+
+	if p.providerInfos[name].Disabled {
+		return nil, ErrProviderDisabled
+	}
+
+	return provider, nil
+}
+
 func (p *ProviderManager) GetProviders() []Info {
 	res := make([]Info, 0, len(p.providers))
 
@@ -181,16 +317,16 @@ func (p *ProviderManager) GetProviders() []Info {
 }
 
 func (p *ProviderManager) GetMedia(ctx context.Context, providerName, id string) (Media, error) {
-	if !p.IsValidProvider(providerName) {
-		return Media{}, ErrNoProvider
+	provider, err := p.getProvider(providerName)
+	if err != nil {
+		return Media{}, err
 	}
-
-	provider := p.providers[providerName]
 	cacheKey := fmt.Sprintf("media:%s", id)
 
 	providerCache := p.cache.WithName(providerName)
+	noCache := p.providerInfos[providerName].NoCache
 
-	if data, ok := cache.GetJson[Media](providerCache, cacheKey); ok {
+	if data, ok := cache.GetJson[Media](providerCache, cacheKey); ok && !noCache {
 		return data, nil
 	}
 
@@ -204,25 +340,32 @@ func (p *ProviderManager) GetMedia(ctx context.Context, providerName, id string)
 		return Media{}, err
 	}
 
-	err = cache.SetJson(providerCache, cacheKey, m, mediaTTL)
-	if err != nil {
-		return Media{}, err
+	if !noCache {
+		err = cache.SetJson(providerCache, cacheKey, m, mediaTTL)
+		if err != nil {
+			return Media{}, err
+		}
 	}
 
 	return m, nil
 }
 
 func (p *ProviderManager) SearchMedia(ctx context.Context, providerName, query string) ([]SearchResult, error) {
-	if !p.IsValidProvider(providerName) {
-		return nil, ErrNoProvider
+	provider, err := p.getProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.providerInfos[providerName].SupportSearchMedia {
+		return nil, ErrMediaSearchNotSupported
 	}
 
-	provider := p.providers[providerName]
 	cacheKey := fmt.Sprintf("media-search:%s", query)
 
 	providerCache := p.cache.WithName(providerName)
+	noCache := p.providerInfos[providerName].NoCache
 
-	if data, ok := cache.GetJson[[]SearchResult](providerCache, cacheKey); ok {
+	if data, ok := cache.GetJson[[]SearchResult](providerCache, cacheKey); ok && !noCache {
 		return data, nil
 	}
 
@@ -236,25 +379,32 @@ func (p *ProviderManager) SearchMedia(ctx context.Context, providerName, query s
 		return nil, err
 	}
 
-	err = cache.SetJson(providerCache, cacheKey, items, searchTTL)
-	if err != nil {
-		return nil, err
+	if !noCache {
+		err = cache.SetJson(providerCache, cacheKey, items, searchTTL)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return items, nil
 }
 
 func (p *ProviderManager) GetCollection(ctx context.Context, providerName, id string) (Collection, error) {
-	if !p.IsValidProvider(providerName) {
-		return Collection{}, ErrNoProvider
+	provider, err := p.getProvider(providerName)
+	if err != nil {
+		return Collection{}, err
+	}
+
+	if !p.providerInfos[providerName].SupportGetCollection {
+		return Collection{}, ErrCollectionsNotSupported
 	}
 
-	provider := p.providers[providerName]
 	cacheKey := fmt.Sprintf("collections:%s", id)
 
 	providerCache := p.cache.WithName(providerName)
+	noCache := p.providerInfos[providerName].NoCache
 
-	if data, ok := cache.GetJson[Collection](providerCache, cacheKey); ok {
+	if data, ok := cache.GetJson[Collection](providerCache, cacheKey); ok && !noCache {
 		return data, nil
 	}
 
@@ -268,25 +418,32 @@ func (p *ProviderManager) GetCollection(ctx context.Context, providerName, id st
 		return Collection{}, err
 	}
 
-	err = cache.SetJson(providerCache, cacheKey, col, mediaTTL)
-	if err != nil {
-		return Collection{}, err
+	if !noCache {
+		err = cache.SetJson(providerCache, cacheKey, col, mediaTTL)
+		if err != nil {
+			return Collection{}, err
+		}
 	}
 
 	return col, nil
 }
 
 func (p *ProviderManager) SearchCollection(ctx context.Context, providerName, query string) ([]SearchResult, error) {
-	if !p.IsValidProvider(providerName) {
-		return nil, ErrNoProvider
+	provider, err := p.getProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.providerInfos[providerName].SupportSearchCollection {
+		return nil, ErrCollectionsNotSupported
 	}
 
-	provider := p.providers[providerName]
 	cacheKey := fmt.Sprintf("collections-search:%s", query)
 
 	providerCache := p.cache.WithName(providerName)
+	noCache := p.providerInfos[providerName].NoCache
 
-	if data, ok := cache.GetJson[[]SearchResult](providerCache, cacheKey); ok {
+	if data, ok := cache.GetJson[[]SearchResult](providerCache, cacheKey); ok && !noCache {
 		return data, nil
 	}
 
@@ -300,9 +457,11 @@ func (p *ProviderManager) SearchCollection(ctx context.Context, providerName, qu
 		return nil, err
 	}
 
-	err = cache.SetJson(providerCache, cacheKey, items, searchTTL)
-	if err != nil {
-		return nil, err
+	if !noCache {
+		err = cache.SetJson(providerCache, cacheKey, items, searchTTL)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return items, nil
@@ -0,0 +1,170 @@
+package rawg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/tools/cache"
+)
+
+const DefaultBaseUrl = "https://api.rawg.io"
+
+type NamedItem struct {
+	Id   int    `json:"id"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type PlatformItem struct {
+	Platform NamedItem `json:"platform"`
+}
+
+type GameDetails struct {
+	Id                        int            `json:"id"`
+	Slug                      string         `json:"slug"`
+	Name                      string         `json:"name"`
+	DescriptionRaw            string         `json:"description_raw"`
+	Released                  string         `json:"released"`
+	Tba                       bool           `json:"tba"`
+	BackgroundImage           string         `json:"background_image"`
+	BackgroundImageAdditional string         `json:"background_image_additional"`
+	Rating                    float64        `json:"rating"`
+	Metacritic                *int           `json:"metacritic"`
+	Developers                []NamedItem    `json:"developers"`
+	Publishers                []NamedItem    `json:"publishers"`
+	Genres                    []NamedItem    `json:"genres"`
+	Platforms                 []PlatformItem `json:"platforms"`
+	EsrbRating                *NamedItem     `json:"esrb_rating"`
+}
+
+type GameSearchResult struct {
+	Id              int    `json:"id"`
+	Slug            string `json:"slug"`
+	Name            string `json:"name"`
+	Released        string `json:"released"`
+	BackgroundImage string `json:"background_image"`
+}
+
+type SearchResponse[T any] struct {
+	Count    int     `json:"count"`
+	Next     *string `json:"next"`
+	Previous *string `json:"previous"`
+	Results  []T     `json:"results"`
+}
+
+const requestTTL = 1 * time.Hour
+
+type ApiClient struct {
+	client *provider.HTTPClient
+	cache  cache.Cache
+	apiKey string
+}
+
+func newHttpClient(config provider.Config) *provider.HTTPClient {
+	return config.NewHttpClient(DefaultBaseUrl, 2, 5, "")
+}
+
+func NewApiClient(client *provider.HTTPClient, cache cache.Cache, apiKey string) *ApiClient {
+	return &ApiClient{
+		client: client,
+		cache:  cache,
+		apiKey: apiKey,
+	}
+}
+
+type requestData struct {
+	client *provider.HTTPClient
+	cache  cache.Cache
+
+	cacheKey string
+
+	path  string
+	query url.Values
+}
+
+// NOTE(patrik): RAWG returns {"detail": "..."} with the error instead of
+// the requested object, like "Not found." for missing games
+type apiError struct {
+	Detail string `json:"detail"`
+}
+
+func apiRequest[T any](ctx context.Context, req requestData) (T, error) {
+	var res T
+
+	if data, ok := cache.GetJson[T](req.cache, req.cacheKey); ok {
+		return data, nil
+	}
+
+	d, err := req.client.Get(ctx, req.path, provider.RequestOptions{
+		Headers: http.Header{
+			"Accept": {"application/json"},
+		},
+		Query: req.query,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	var apiErr apiError
+	err = json.Unmarshal(d, &apiErr)
+	if err == nil && apiErr.Detail != "" {
+		if apiErr.Detail == "Not found." {
+			return res, provider.NotFound
+		}
+
+		return res, fmt.Errorf("rawg: %s", apiErr.Detail)
+	}
+
+	err = json.Unmarshal(d, &res)
+	if err != nil {
+		return res, err
+	}
+
+	// TODO(patrik): Log error?
+	cache.SetJson(req.cache, req.cacheKey, res, requestTTL)
+
+	return res, nil
+}
+
+func (c *ApiClient) GetGameDetails(ctx context.Context, id string) (GameDetails, error) {
+	res, err := apiRequest[GameDetails](ctx, requestData{
+		client:   c.client,
+		cache:    c.cache,
+		cacheKey: "api:game-details:" + id,
+		path:     fmt.Sprintf("/api/games/%s", url.PathEscape(id)),
+		query: url.Values{
+			"key": {c.apiKey},
+		},
+	})
+	if err != nil {
+		return GameDetails{}, err
+	}
+
+	// NOTE(patrik): Missing games is handled by apiRequest, this catches
+	// the responses without a game that isn't marked as an error
+	if res.Id == 0 {
+		return GameDetails{}, provider.NotFound
+	}
+
+	return res, nil
+}
+
+func (c *ApiClient) GameSearch(ctx context.Context, query string) (SearchResponse[GameSearchResult], error) {
+	return apiRequest[SearchResponse[GameSearchResult]](ctx, requestData{
+		client:   c.client,
+		cache:    c.cache,
+		cacheKey: "api:game-search:" + query,
+		path:     "/api/games",
+		query: url.Values{
+			"key":       {c.apiKey},
+			"search":    {query},
+			"page":      {"1"},
+			"page_size": {"20"},
+		},
+	})
+}
@@ -0,0 +1,204 @@
+package rawg
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/types"
+	"github.com/nanoteck137/watchbook/utils"
+)
+
+const GameProviderName = "rawg-game"
+
+var ErrMissingApiKey = errors.New("rawg: missing api key")
+
+var _ provider.Provider = (*RawgGameProvider)(nil)
+
+type RawgGameProvider struct {
+	config provider.Config
+	client *provider.HTTPClient
+}
+
+func NewRawgGameProvider(config provider.Config) *RawgGameProvider {
+	return &RawgGameProvider{
+		config: config,
+		client: newHttpClient(config),
+	}
+}
+
+func (r *RawgGameProvider) Info() provider.Info {
+	return r.config.RequireApiKey(provider.Info{
+		Name:                    GameProviderName,
+		DisplayName:             "RAWG Games",
+		SupportGetMedia:         true,
+		SupportSearchMedia:      true,
+		SupportGetCollection:    false,
+		SupportSearchCollection: false,
+	})
+}
+
+func (r *RawgGameProvider) apiClient(c provider.Context) (*ApiClient, error) {
+	if r.config.ApiKey == "" {
+		return nil, ErrMissingApiKey
+	}
+
+	return NewApiClient(r.client, c.Cache(), r.config.ApiKey), nil
+}
+
+func (r *RawgGameProvider) GetMedia(c provider.Context, id string) (provider.Media, error) {
+	apiClient, err := r.apiClient(c)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	details, err := apiClient.GetGameDetails(c.Context(), id)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	var releaseDate *time.Time
+	d, err := time.Parse(types.MediaDateLayout, details.Released)
+	if err == nil {
+		releaseDate = &d
+	}
+
+	status := types.MediaStatusUnknown
+	switch {
+	case details.Tba:
+		status = types.MediaStatusUpcoming
+	case releaseDate != nil && releaseDate.After(time.Now()):
+		status = types.MediaStatusUpcoming
+	case releaseDate != nil:
+		status = types.MediaStatusCompleted
+	}
+
+	var description *string
+	if details.DescriptionRaw != "" {
+		description = &details.DescriptionRaw
+	}
+
+	// NOTE(patrik): RAWG rating is between 0-5 so convert it to
+	// the same range as the other providers
+	var score *float64
+	if details.Rating > 0 {
+		s := utils.RoundFloat(details.Rating*2, 2)
+		score = &s
+	}
+
+	var airingSeason *string
+	if releaseDate != nil {
+		s := types.GetAiringSeason(details.Released)
+		airingSeason = &s
+	}
+
+	creators := make([]string, 0, len(details.Developers)+len(details.Publishers))
+	for _, developer := range details.Developers {
+		creators = append(creators, utils.Slug(developer.Name))
+	}
+
+	for _, publisher := range details.Publishers {
+		creators = append(creators, utils.Slug(publisher.Name))
+	}
+
+	tags := make([]string, 0, len(details.Genres)+len(details.Platforms))
+	for _, genre := range details.Genres {
+		tags = append(tags, utils.Slug(genre.Name))
+	}
+
+	for _, platform := range details.Platforms {
+		tags = append(tags, utils.Slug(platform.Platform.Name))
+	}
+
+	var coverUrl *string
+	if details.BackgroundImage != "" {
+		coverUrl = &details.BackgroundImage
+	}
+
+	bannerUrl := coverUrl
+	if details.BackgroundImageAdditional != "" {
+		bannerUrl = &details.BackgroundImageAdditional
+	}
+
+	rating := types.MediaRatingUnknown
+	if details.EsrbRating != nil {
+		rating = ConvertEsrbRating(details.EsrbRating.Slug)
+	}
+
+	return provider.Media{
+		ProviderId:       id,
+		Type:             types.MediaTypeGame,
+		Title:            details.Name,
+		Description:      description,
+		Score:            score,
+		Status:           status,
+		Rating:           rating,
+		AiringSeason:     airingSeason,
+		StartDate:        releaseDate,
+		EndDate:          releaseDate,
+		Release:          releaseDate,
+		CoverUrl:         coverUrl,
+		BannerUrl:        bannerUrl,
+		Creators:         creators,
+		Tags:             tags,
+		Parts:            []provider.MediaPart{},
+		ExtraProviderIds: map[string]string{},
+	}, nil
+}
+
+func (r *RawgGameProvider) SearchMedia(c provider.Context, query string) ([]provider.SearchResult, error) {
+	apiClient, err := r.apiClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := apiClient.GameSearch(c.Context(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]provider.SearchResult, len(search.Results))
+
+	for i, result := range search.Results {
+		res[i] = provider.SearchResult{
+			SearchType: provider.SearchResultTypeMedia,
+			ProviderId: strconv.Itoa(result.Id),
+			Title:      result.Name,
+			MediaType:  types.MediaTypeGame,
+			ImageUrl:   result.BackgroundImage,
+			Year:       provider.ParseYear(result.Released),
+		}
+	}
+
+	return res, nil
+}
+
+func (r *RawgGameProvider) GetCollection(c provider.Context, id string) (provider.Collection, error) {
+	return provider.Collection{}, provider.ErrCollectionsNotSupported
+}
+
+func (r *RawgGameProvider) SearchCollection(c provider.Context, query string) ([]provider.SearchResult, error) {
+	return nil, provider.ErrCollectionsNotSupported
+}
+
+func ConvertEsrbRating(slug string) types.MediaRating {
+	switch slug {
+	case "everyone":
+		return types.MediaRatingAllAges
+	case "everyone-10-plus":
+		return types.MediaRatingPG
+	case "teen":
+		return types.MediaRatingPG13
+	case "mature", "adults-only":
+		return types.MediaRatingR17
+	case "rating-pending", "":
+		return types.MediaRatingUnknown
+	default:
+		// TODO(patrik): Better logging
+		fmt.Printf("WARN: Unknown esrb rating \"%s\"\n", slug)
+	}
+
+	return types.MediaRatingUnknown
+}
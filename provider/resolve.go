@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var ErrUnknownLink = errors.New("link is not recognized by any provider")
+
+var (
+	imdbIdRegex    = regexp.MustCompile(`^tt\d+$`)
+	leadingIdRegex = regexp.MustCompile(`^\d+`)
+)
+
+// NOTE(patrik): Entry on a provider that a link points to, the type is
+// used to know if the entry should be imported as media or as a collection
+type ResolvedLink struct {
+	ProviderName string           `json:"providerName"`
+	ProviderId   string           `json:"providerId"`
+	Type         SearchResultType `json:"type"`
+}
+
+// NOTE(patrik): Optional interface for the providers that can recognize
+// links to their own site, the host of the url is lowercased and the "www."
+// prefix is removed before the url is passed to the provider
+type LinkResolver interface {
+	ResolveLink(u *url.URL) (ResolvedLink, bool)
+}
+
+// NOTE(patrik): Optional interface for the providers that can find their
+// entries from an id on another site, the source is one of the provider
+// names that only stores ids (ProviderNameImdb)
+type ExternalIdResolver interface {
+	ResolveExternalId(c Context, source, id string) ([]ResolvedLink, error)
+}
+
+// NOTE(patrik): Returns the imdb id from a link or from the id itself
+// (tt0944947 or https://www.imdb.com/title/tt0944947/)
+func ParseImdbId(link string) (string, bool) {
+	if imdbIdRegex.MatchString(link) {
+		return link, true
+	}
+
+	u, ok := parseLink(link)
+	if !ok || !IsHost(u, "imdb.com") {
+		return "", false
+	}
+
+	segments := PathSegments(u)
+	if len(segments) >= 2 && segments[0] == "title" && imdbIdRegex.MatchString(segments[1]) {
+		return segments[1], true
+	}
+
+	return "", false
+}
+
+// NOTE(patrik): Checks if the url points to the host or one of the
+// subdomains of the host (m.imdb.com)
+func IsHost(u *url.URL, host string) bool {
+	return u.Host == host || strings.HasSuffix(u.Host, "."+host)
+}
+
+// NOTE(patrik): Returns the non empty path segments of the url
+// ("/anime/52991/Sousou_no_Frieren" -> ["anime", "52991", "Sousou_no_Frieren"])
+func PathSegments(u *url.URL) []string {
+	return strings.FieldsFunc(u.Path, func(r rune) bool {
+		return r == '/'
+	})
+}
+
+// NOTE(patrik): Returns the number at the start of a path segment, some
+// sites adds the slug of the title after the id ("550-fight-club")
+func LeadingId(segment string) (string, bool) {
+	id := leadingIdRegex.FindString(segment)
+	return id, id != ""
+}
+
+func parseLink(link string) (*url.URL, bool) {
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+
+	u, err := url.Parse(link)
+	if err != nil || u.Hostname() == "" {
+		return nil, false
+	}
+
+	u.Host = strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+
+	return u, true
+}
+
+func (p *ProviderManager) enabledProviderNames() []string {
+	names := make([]string, 0, len(p.providers))
+	for name, info := range p.providerInfos {
+		if !info.Disabled {
+			names = append(names, name)
+		}
+	}
+
+	// NOTE(patrik): Keep the order of the results stable
+	sort.Strings(names)
+
+	return names
+}
+
+// NOTE(patrik): Resolves a link or an external id (imdb) to the entries on
+// the providers, returns ErrUnknownLink when no provider recognizes the
+// link
+func (p *ProviderManager) ResolveLink(ctx context.Context, link string) ([]ResolvedLink, error) {
+	link = strings.TrimSpace(link)
+
+	if imdbId, ok := ParseImdbId(link); ok {
+		return p.resolveExternalId(ctx, ProviderNameImdb, imdbId)
+	}
+
+	u, ok := parseLink(link)
+	if !ok {
+		return nil, ErrUnknownLink
+	}
+
+	var res []ResolvedLink
+
+	for _, name := range p.enabledProviderNames() {
+		resolver, ok := p.providers[name].(LinkResolver)
+		if !ok {
+			continue
+		}
+
+		resolved, ok := resolver.ResolveLink(u)
+		if ok {
+			resolved.ProviderName = name
+			res = append(res, resolved)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, ErrUnknownLink
+	}
+
+	return res, nil
+}
+
+func (p *ProviderManager) resolveExternalId(ctx context.Context, source, id string) ([]ResolvedLink, error) {
+	var res []ResolvedLink
+
+	for _, name := range p.enabledProviderNames() {
+		resolver, ok := p.providers[name].(ExternalIdResolver)
+		if !ok {
+			continue
+		}
+
+		c := Context{
+			ctx:   ctx,
+			cache: p.cache.WithName(name),
+		}
+
+		items, err := resolver.ResolveExternalId(c, source, id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			item.ProviderName = name
+			res = append(res, item)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil, ErrUnknownLink
+	}
+
+	return res, nil
+}
@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/nanoteck137/watchbook/types"
+)
+
+// NOTE(patrik): Min similarity (0-1) between two titles for them to be
+// treated as the same entry
+const titleSimilarityThreshold = 0.9
+
+type ProviderSearchResults struct {
+	ProviderName string
+	Results      []SearchResult
+	Err          error
+}
+
+// NOTE(patrik): Searches all the given providers at the same time, a
+// provider that fails (or panics) only sets the Err of its own result. Every
+// provider gets its own timeout so a slow provider doesn't use up the time
+// of the others.
+func (p *ProviderManager) SearchMediaMultiple(ctx context.Context, providerNames []string, query string, timeout time.Duration) []ProviderSearchResults {
+	res := make([]ProviderSearchResults, len(providerNames))
+
+	var wg sync.WaitGroup
+	for i, name := range providerNames {
+		res[i].ProviderName = name
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					res[i].Err = fmt.Errorf("provider panicked: %v", r)
+				}
+			}()
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			res[i].Results, res[i].Err = p.SearchMedia(ctx, name, query)
+		}()
+	}
+
+	wg.Wait()
+
+	return res
+}
+
+// NOTE(patrik): Returns the names of the providers that supports searching
+// for media and is not disabled
+func (p *ProviderManager) GetMediaSearchProviders() []string {
+	var res []string
+	for name, info := range p.providerInfos {
+		if info.SupportSearchMedia && !info.Disabled {
+			res = append(res, name)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+type SearchGroupItem struct {
+	ProviderName string
+	Result       SearchResult
+	// NOTE(patrik): Position of the result inside the provider results
+	Rank int
+}
+
+// NOTE(patrik): Search results from different providers that points to the
+// same entry, max one item per provider
+type SearchGroup struct {
+	Items []SearchGroupItem
+}
+
+// NOTE(patrik): Merges the results that points to the same entry, first by
+// the ids the providers reports and then by the title and year. The groups
+// are sorted by the best rank of the items inside them.
+func GroupSearchResults(results []ProviderSearchResults) []SearchGroup {
+	var items []SearchGroupItem
+	for _, r := range results {
+		for i, result := range r.Results {
+			items = append(items, SearchGroupItem{
+				ProviderName: r.ProviderName,
+				Result:       result,
+				Rank:         i,
+			})
+		}
+	}
+
+	parent := make([]int, len(items))
+	providers := make([]map[string]bool, len(items))
+	for i, item := range items {
+		parent[i] = i
+		providers[i] = map[string]bool{item.ProviderName: true}
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+
+	// NOTE(patrik): Only merge the groups if they doesn't share any
+	// providers, stops a loose title match from pulling in a second result
+	// from the same provider
+	union := func(a, b int) {
+		a, b = find(a), find(b)
+		if a == b {
+			return
+		}
+
+		for name := range providers[b] {
+			if providers[a][name] {
+				return
+			}
+		}
+
+		for name := range providers[b] {
+			providers[a][name] = true
+		}
+		parent[b] = a
+	}
+
+	// NOTE(patrik): Id matches first, they are more reliable than the
+	// title matches
+	for i := range items {
+		for j := i + 1; j < len(items); j++ {
+			if sameIds(items[i], items[j]) {
+				union(i, j)
+			}
+		}
+	}
+
+	for i := range items {
+		for j := i + 1; j < len(items); j++ {
+			if sameTitle(items[i].Result, items[j].Result) {
+				union(i, j)
+			}
+		}
+	}
+
+	groupIndex := map[int]int{}
+	var groups []SearchGroup
+	for i, item := range items {
+		root := find(i)
+
+		idx, ok := groupIndex[root]
+		if !ok {
+			idx = len(groups)
+			groupIndex[root] = idx
+			groups = append(groups, SearchGroup{})
+		}
+
+		groups[idx].Items = append(groups[idx].Items, item)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].bestRank() < groups[j].bestRank()
+	})
+
+	return groups
+}
+
+func (g SearchGroup) bestRank() int {
+	best := -1
+	for _, item := range g.Items {
+		if best == -1 || item.Rank < best {
+			best = item.Rank
+		}
+	}
+
+	return best
+}
+
+func sameIds(a, b SearchGroupItem) bool {
+	if a.ProviderName == b.ProviderName {
+		return false
+	}
+
+	if id, ok := a.Result.ExtraProviderIds[b.ProviderName]; ok && id == b.Result.ProviderId {
+		return true
+	}
+
+	if id, ok := b.Result.ExtraProviderIds[a.ProviderName]; ok && id == a.Result.ProviderId {
+		return true
+	}
+
+	for name, id := range a.Result.ExtraProviderIds {
+		if other, ok := b.Result.ExtraProviderIds[name]; ok && other == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sameTitle(a, b SearchResult) bool {
+	if a.Year != 0 && b.Year != 0 && a.Year != b.Year {
+		return false
+	}
+
+	if !compatibleMediaTypes(a.MediaType, b.MediaType) {
+		return false
+	}
+
+	ta := normalizeTitle(a.Title)
+	tb := normalizeTitle(b.Title)
+	if ta == "" || tb == "" {
+		return false
+	}
+
+	return titleSimilarity(ta, tb) >= titleSimilarityThreshold
+}
+
+func compatibleMediaTypes(a, b types.MediaType) bool {
+	if a == "" || b == "" || a == types.MediaTypeUnknown || b == types.MediaTypeUnknown {
+		return true
+	}
+
+	// NOTE(patrik): Anime is usually tv/movie on the non anime providers
+	group := func(t types.MediaType) string {
+		switch t {
+		case types.MediaTypeTV, types.MediaTypeAnimeSeason:
+			return "series"
+		case types.MediaTypeMovie, types.MediaTypeAnimeMovie:
+			return "movie"
+		}
+
+		return string(t)
+	}
+
+	return group(a) == group(b)
+}
+
+var titleYearRegex = regexp.MustCompile(`\s*\(\d{4}\)\s*$`)
+
+// NOTE(patrik): Lowercase and only keep letters and digits, "Re:Zero" and
+// "Re: Zero" should be the same. Some providers adds the year to the title
+// ("Title (2020)") so that is removed.
+func normalizeTitle(s string) string {
+	s = titleYearRegex.ReplaceAllString(s, "")
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// NOTE(patrik): 1 - (levenshtein distance / length of the longest string)
+func titleSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	ra := []rune(a)
+	rb := []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	longest := max(len(ra), len(rb))
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
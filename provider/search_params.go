@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/nanoteck137/watchbook/tools/cache"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+type SearchParams struct {
+	Query string
+	// NOTE(patrik): 1 based, 0 is treated as the first page
+	Page int
+
+	// NOTE(patrik): Optional filters, the zero value means no filter
+	Year      int
+	MediaType types.MediaType
+
+	// NOTE(patrik): The providers with an adult filter in the api uses it
+	// directly, the rest filters the results marked as adult with
+	// FilterSearchResults. Results from providers that doesn't mark the
+	// adult entries is always included.
+	IncludeAdult bool
+}
+
+func (p SearchParams) GetPage() int {
+	return max(p.Page, 1)
+}
+
+func (p SearchParams) cacheKey() string {
+	v := url.Values{}
+	v.Set("query", p.Query)
+	v.Set("page", strconv.Itoa(p.GetPage()))
+	v.Set("year", strconv.Itoa(p.Year))
+	v.Set("type", string(p.MediaType))
+	v.Set("adult", strconv.FormatBool(p.IncludeAdult))
+
+	return "media-search-params:" + v.Encode()
+}
+
+type SearchPage struct {
+	Results []SearchResult `json:"results"`
+
+	Page int `json:"page"`
+	// NOTE(patrik): 0 when the provider doesn't know the number of pages
+	TotalPages  int  `json:"totalPages"`
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// NOTE(patrik): Optional interface for the providers that supports
+// pagination and filters for the media search, the other providers only
+// gets the first page from SearchMedia
+type SearchParamsProvider interface {
+	SearchMediaWithParams(c Context, params SearchParams) (SearchPage, error)
+}
+
+// NOTE(patrik): Removes the results that doesn't match the year and the
+// media type of the params, results without a year or a type is kept. The
+// adult results is removed unless the params includes them.
+func FilterSearchResults(results []SearchResult, params SearchParams) []SearchResult {
+	res := make([]SearchResult, 0, len(results))
+	for _, result := range results {
+		if result.Adult && !params.IncludeAdult {
+			continue
+		}
+
+		if params.Year != 0 && result.Year != 0 && result.Year != params.Year {
+			continue
+		}
+
+		if params.MediaType != "" && !compatibleMediaTypes(params.MediaType, result.MediaType) {
+			continue
+		}
+
+		res = append(res, result)
+	}
+
+	return res
+}
+
+func (p *ProviderManager) SearchMediaWithParams(ctx context.Context, providerName string, params SearchParams) (SearchPage, error) {
+	provider, err := p.getProvider(providerName)
+	if err != nil {
+		return SearchPage{}, err
+	}
+
+	if !p.providerInfos[providerName].SupportSearchMedia {
+		return SearchPage{}, ErrMediaSearchNotSupported
+	}
+
+	paramsProvider, ok := provider.(SearchParamsProvider)
+	if !ok {
+		if params.GetPage() > 1 {
+			return SearchPage{
+				Results: []SearchResult{},
+				Page:    params.GetPage(),
+			}, nil
+		}
+
+		items, err := p.SearchMedia(ctx, providerName, params.Query)
+		if err != nil {
+			return SearchPage{}, err
+		}
+
+		return SearchPage{
+			Results:    FilterSearchResults(items, params),
+			Page:       1,
+			TotalPages: 1,
+		}, nil
+	}
+
+	cacheKey := params.cacheKey()
+
+	providerCache := p.cache.WithName(providerName)
+	noCache := p.providerInfos[providerName].NoCache
+
+	if data, ok := cache.GetJson[SearchPage](providerCache, cacheKey); ok && !noCache {
+		return data, nil
+	}
+
+	c := Context{
+		ctx:   ctx,
+		cache: providerCache,
+	}
+
+	page, err := paramsProvider.SearchMediaWithParams(c, params)
+	if err != nil {
+		return SearchPage{}, err
+	}
+
+	if !noCache {
+		err = cache.SetJson(providerCache, cacheKey, page, searchTTL)
+		if err != nil {
+			return SearchPage{}, err
+		}
+	}
+
+	return page, nil
+}
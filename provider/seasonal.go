@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nanoteck137/watchbook/tools/cache"
+)
+
+// NOTE(patrik): The seasonal charts changes when new entries is announced so
+// they are not cached for as long as the media
+const seasonalTTL = 6 * time.Hour
+
+var ErrSeasonalNotSupported = errors.New("provider doesn't support seasonal charts")
+
+const (
+	SeasonWinter = "winter"
+	SeasonSpring = "spring"
+	SeasonSummer = "summer"
+	SeasonFall   = "fall"
+)
+
+var Seasons = []string{
+	SeasonWinter,
+	SeasonSpring,
+	SeasonSummer,
+	SeasonFall,
+}
+
+func IsValidSeason(s string) bool {
+	switch s {
+	case SeasonWinter, SeasonSpring, SeasonSummer, SeasonFall:
+		return true
+	}
+
+	return false
+}
+
+// NOTE(patrik): Returns the anime season (winter, spring, summer, fall) and
+// the year for the time
+func GetSeason(t time.Time) (string, int) {
+	return Seasons[(int(t.Month())-1)/3], t.Year()
+}
+
+// NOTE(patrik): Optional interface for the providers that has seasonal
+// charts (MyAnimeList)
+type SeasonalProvider interface {
+	GetSeasonal(c Context, season string, year int) ([]SearchResult, error)
+}
+
+func (p *ProviderManager) GetSeasonal(ctx context.Context, providerName, season string, year int) ([]SearchResult, error) {
+	provider, err := p.getProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	seasonalProvider, ok := provider.(SeasonalProvider)
+	if !ok {
+		return nil, ErrSeasonalNotSupported
+	}
+
+	cacheKey := fmt.Sprintf("seasonal:%s-%d", season, year)
+
+	providerCache := p.cache.WithName(providerName)
+	noCache := p.providerInfos[providerName].NoCache
+
+	if data, ok := cache.GetJson[[]SearchResult](providerCache, cacheKey); ok && !noCache {
+		return data, nil
+	}
+
+	c := Context{
+		ctx:   ctx,
+		cache: providerCache,
+	}
+
+	items, err := seasonalProvider.GetSeasonal(c, season, year)
+	if err != nil {
+		return nil, err
+	}
+
+	if !noCache {
+		err = cache.SetJson(providerCache, cacheKey, items, seasonalTTL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
@@ -0,0 +1,42 @@
+package tmdb
+
+import (
+	"sort"
+
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/types"
+	"github.com/nanoteck137/watchbook/utils"
+)
+
+func convertWatchProviders(watchProviders WatchProviders) []provider.Availability {
+	regions := make([]string, 0, len(watchProviders.Results))
+	for region := range watchProviders.Results {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	var res []provider.Availability
+
+	add := func(region string, typ types.AvailabilityType, items []WatchProvider) {
+		for _, item := range items {
+			res = append(res, provider.Availability{
+				Region:      region,
+				Type:        typ,
+				Service:     utils.Slug(item.ProviderName),
+				ServiceName: item.ProviderName,
+			})
+		}
+	}
+
+	for _, region := range regions {
+		r := watchProviders.Results[region]
+
+		add(region, types.AvailabilityTypeSubscription, r.Flatrate)
+		add(region, types.AvailabilityTypeFree, r.Free)
+		add(region, types.AvailabilityTypeAds, r.Ads)
+		add(region, types.AvailabilityTypeRent, r.Rent)
+		add(region, types.AvailabilityTypeBuy, r.Buy)
+	}
+
+	return res
+}
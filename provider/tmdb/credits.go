@@ -0,0 +1,85 @@
+package tmdb
+
+import (
+	"strconv"
+
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+// NOTE(patrik): Max number of cast members, the full cast of a movie can
+// be hundreds of people
+const maxCastCredits = 20
+
+// NOTE(patrik): Only the jobs we care about, the crew lists on tmdb
+// contains everyone from the lighting to the catering
+func convertCrewJob(job string) (types.PersonRole, bool) {
+	switch job {
+	case "Director":
+		return types.PersonRoleDirector, true
+	case "Screenplay", "Writer", "Story", "Teleplay":
+		return types.PersonRoleWriter, true
+	case "Novel", "Author", "Comic Book", "Original Story", "Characters":
+		return types.PersonRoleCreator, true
+	case "Original Music Composer", "Music":
+		return types.PersonRoleComposer, true
+	}
+
+	return "", false
+}
+
+func convertCredits(credits Credits, companies []ProductionCompany, createdBy []TvCreatedBy) []provider.Credit {
+	var res []provider.Credit
+
+	for _, company := range companies {
+		res = append(res, provider.Credit{
+			Name:         company.Name,
+			PersonType:   types.PersonTypeOrganization,
+			Role:         types.PersonRoleStudio,
+			ProviderName: provider.ProviderNameTheMovieDbCompany,
+			ProviderId:   strconv.Itoa(company.Id),
+		})
+	}
+
+	for _, person := range createdBy {
+		res = append(res, provider.Credit{
+			Name:         person.Name,
+			PersonType:   types.PersonTypePerson,
+			Role:         types.PersonRoleCreator,
+			ProviderName: provider.ProviderNameTheMovieDbPerson,
+			ProviderId:   strconv.Itoa(person.Id),
+		})
+	}
+
+	for _, crew := range credits.Crew {
+		role, ok := convertCrewJob(crew.Job)
+		if !ok {
+			continue
+		}
+
+		res = append(res, provider.Credit{
+			Name:         crew.Name,
+			PersonType:   types.PersonTypePerson,
+			Role:         role,
+			ProviderName: provider.ProviderNameTheMovieDbPerson,
+			ProviderId:   strconv.Itoa(crew.Id),
+		})
+	}
+
+	for i, cast := range credits.Cast {
+		if i >= maxCastCredits {
+			break
+		}
+
+		res = append(res, provider.Credit{
+			Name:         cast.Name,
+			PersonType:   types.PersonTypePerson,
+			Role:         types.PersonRoleCast,
+			Character:    cast.Character,
+			ProviderName: provider.ProviderNameTheMovieDbPerson,
+			ProviderId:   strconv.Itoa(cast.Id),
+		})
+	}
+
+	return res
+}
@@ -1,6 +1,8 @@
 package tmdb
 
 import (
+	"slices"
+	"sort"
 	"strconv"
 	"time"
 
@@ -10,29 +12,94 @@ import (
 )
 
 var _ provider.Provider = (*TmdbMovieProvider)(nil)
+var _ provider.ImageProvider = (*TmdbMovieProvider)(nil)
 
 const MovieProviderName = "tmdb-movie"
 
 type TmdbMovieProvider struct {
+	config provider.Config
+	client *provider.HTTPClient
+}
+
+func NewTmdbMovieProvider(config provider.Config) *TmdbMovieProvider {
+	return &TmdbMovieProvider{
+		config: config,
+		client: newHttpClient(config),
+	}
 }
 
 func (t *TmdbMovieProvider) Info() provider.Info {
-	return provider.Info{
+	return t.config.RequireApiKey(provider.Info{
 		Name:                    MovieProviderName,
 		DisplayName:             "TheMovieDB Movie",
 		SupportGetMedia:         true,
 		SupportSearchMedia:      true,
-		SupportGetCollection:    false,
-		SupportSearchCollection: false,
-	}
+		SupportGetCollection:    true,
+		SupportSearchCollection: true,
+	})
 }
 
 func (t *TmdbMovieProvider) GetCollection(c provider.Context, id string) (provider.Collection, error) {
-	panic("unsupported")
+	apiClient := NewApiClient(t.client, c.Cache(), t.config)
+
+	details, err := apiClient.GetCollectionDetails(c.Context(), id)
+	if err != nil {
+		return provider.Collection{}, err
+	}
+
+	images, err := apiClient.GetCollectionImages(c.Context(), id)
+	if err != nil {
+		return provider.Collection{}, err
+	}
+
+	coverUrl := "http://image.tmdb.org/t/p/original" + details.PosterPath
+	bannerUrl := "http://image.tmdb.org/t/p/original" + details.BackdropPath
+	var logoUrl *string
+
+	if len(images.Logos) > 0 {
+		logo := images.Logos[0]
+		u := "http://image.tmdb.org/t/p/original" + logo.FilePath
+		logoUrl = &u
+	}
+
+	// NOTE(patrik): TMDB doesn't return the parts in any specific order,
+	// sort by release date and put the unreleased movies (no release date)
+	// at the end
+	parts := slices.Clone(details.Parts)
+	sort.SliceStable(parts, func(i, j int) bool {
+		a := parts[i].ReleaseDate
+		b := parts[j].ReleaseDate
+
+		if a == "" || b == "" {
+			return a != "" && b == ""
+		}
+
+		return a < b
+	})
+
+	res := provider.Collection{
+		ProviderId: strconv.Itoa(details.Id),
+		Type:       types.CollectionTypeFranchise,
+		Name:       details.Name,
+		CoverUrl:   &coverUrl,
+		LogoUrl:    logoUrl,
+		BannerUrl:  &bannerUrl,
+		Items:      make([]provider.CollectionItem, len(parts)),
+	}
+
+	for i, part := range parts {
+		res.Items[i] = provider.CollectionItem{
+			Id:       strconv.Itoa(part.Id),
+			Name:     part.Title,
+			Position: i + 1,
+		}
+	}
+
+	return res, nil
 }
 
 func (t *TmdbMovieProvider) GetMedia(c provider.Context, id string) (provider.Media, error) {
-	apiClient := NewApiClient(c.Cache())
+	apiClient := NewApiClient(t.client, c.Cache(), t.config)
 
 	details, err := apiClient.GetMovieDetails(c.Context(), id)
 	if err != nil {
@@ -44,6 +111,16 @@ func (t *TmdbMovieProvider) GetMedia(c provider.Context, id string) (provider.Me
 		return provider.Media{}, err
 	}
 
+	credits, err := apiClient.GetMovieCredits(c.Context(), id)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	videos, err := apiClient.GetMovieVideos(c.Context(), id)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
 	status := types.MediaStatusUpcoming
 	switch details.Status {
 	case "Released":
@@ -87,6 +164,11 @@ func (t *TmdbMovieProvider) GetMedia(c provider.Context, id string) (provider.Me
 		releaseDate = &d
 	}
 
+	extraProviderIds := map[string]string{}
+	if details.ImdbId != "" {
+		extraProviderIds[provider.ProviderNameImdb] = details.ImdbId
+	}
+
 	return provider.Media{
 		ProviderId:       id,
 		Type:             types.MediaTypeMovie,
@@ -104,33 +186,131 @@ func (t *TmdbMovieProvider) GetMedia(c provider.Context, id string) (provider.Me
 		Creators:         creators,
 		Tags:             tags,
 		Parts:            []provider.MediaPart{},
-		ExtraProviderIds: map[string]string{},
+		Credits:          convertCredits(credits, details.ProductionCompanies, nil),
+		Videos:           convertVideos(videos),
+		ExtraProviderIds: extraProviderIds,
 	}, nil
 }
 
 func (t *TmdbMovieProvider) SearchCollection(c provider.Context, query string) ([]provider.SearchResult, error) {
-	panic("unsupported")
+	apiClient := NewApiClient(t.client, c.Cache(), t.config)
+
+	search, err := apiClient.CollectionSearch(c.Context(), query)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]provider.SearchResult, len(search.Results))
+
+	for i, result := range search.Results {
+		res[i] = provider.SearchResult{
+			SearchType: provider.SearchResultTypeCollection,
+			ProviderId: strconv.Itoa(result.Id),
+			Title:      result.Name,
+			MediaType:  types.MediaTypeMovie,
+			ImageUrl:   "http://image.tmdb.org/t/p/original" + result.PosterPath,
+		}
+	}
+
+	return res, nil
 }
 
 func (t *TmdbMovieProvider) SearchMedia(c provider.Context, query string) ([]provider.SearchResult, error) {
-	apiClient := NewApiClient(c.Cache())
+	apiClient := NewApiClient(t.client, c.Cache(), t.config)
 
 	search, err := apiClient.MovieSearch(c.Context(), query)
 	if err != nil {
 		return nil, err
 	}
 
-	res := make([]provider.SearchResult, len(search.Results))
+	return convertMovieSearchResults(search.Results), nil
+}
 
-	for i, result := range search.Results {
+var _ provider.SearchParamsProvider = (*TmdbMovieProvider)(nil)
+
+func (t *TmdbMovieProvider) SearchMediaWithParams(c provider.Context, params provider.SearchParams) (provider.SearchPage, error) {
+	// NOTE(patrik): Only movies on this provider
+	if params.MediaType != "" && params.MediaType != types.MediaTypeMovie && params.MediaType != types.MediaTypeAnimeMovie {
+		return provider.SearchPage{
+			Results: []provider.SearchResult{},
+			Page:    params.GetPage(),
+		}, nil
+	}
+
+	apiClient := NewApiClient(t.client, c.Cache(), t.config)
+
+	search, err := apiClient.MovieSearchPage(c.Context(), params.Query, params.GetPage(), params.Year, params.IncludeAdult)
+	if err != nil {
+		return provider.SearchPage{}, err
+	}
+
+	return provider.SearchPage{
+		Results:     convertMovieSearchResults(search.Results),
+		Page:        search.Page,
+		TotalPages:  search.TotalPages,
+		HasNextPage: search.Page < search.TotalPages,
+	}, nil
+}
+
+func convertMovieSearchResults(results []MovieSearchResult) []provider.SearchResult {
+	res := make([]provider.SearchResult, len(results))
+
+	for i, result := range results {
 		res[i] = provider.SearchResult{
 			SearchType: provider.SearchResultTypeMedia,
 			ProviderId: strconv.Itoa(result.Id),
 			Title:      result.Title,
 			MediaType:  types.MediaTypeMovie,
 			ImageUrl:   "http://image.tmdb.org/t/p/original" + result.PosterPath,
+			Year:       provider.ParseYear(result.ReleaseDate),
 		}
 	}
 
+	return res
+}
+
+func (t *TmdbMovieProvider) GetMediaImages(c provider.Context, id string) ([]provider.Image, error) {
+	apiClient := NewApiClient(t.client, c.Cache(), t.config)
+
+	images, err := apiClient.GetMovieImages(c.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []provider.Image
+	res = appendImages(res, types.ImageTypeCover, images.Posters)
+	res = appendImages(res, types.ImageTypeBanner, images.Backdrops)
+	res = appendImages(res, types.ImageTypeLogo, images.Logos)
+
 	return res, nil
 }
+
+func (t *TmdbMovieProvider) GetAvailability(c provider.Context, id string) ([]provider.Availability, error) {
+	apiClient := NewApiClient(t.client, c.Cache(), t.config)
+
+	watchProviders, err := apiClient.GetMovieWatchProviders(c.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertWatchProviders(watchProviders), nil
+}
+
+// NOTE(patrik): Max number of images per type, tmdb can have hundreds of
+// posters for the popular entries
+const maxImagesPerType = 10
+
+func appendImages(res []provider.Image, typ types.ImageType, items []ImageItem) []provider.Image {
+	for i, item := range items {
+		if i >= maxImagesPerType {
+			break
+		}
+
+		res = append(res, provider.Image{
+			Type: typ,
+			Url:  "http://image.tmdb.org/t/p/original" + item.FilePath,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,139 @@
+package tmdb
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/nanoteck137/watchbook/provider"
+)
+
+var _ provider.LinkResolver = (*TmdbMovieProvider)(nil)
+var _ provider.LinkResolver = (*TmdbTvProvider)(nil)
+var _ provider.ExternalIdResolver = (*TmdbMovieProvider)(nil)
+var _ provider.ExternalIdResolver = (*TmdbTvProvider)(nil)
+
+const siteHost = "themoviedb.org"
+
+// NOTE(patrik): Maps the provider names of the external ids to the names
+// used by the find endpoint
+var findSources = map[string]string{
+	provider.ProviderNameImdb: "imdb_id",
+}
+
+func findExternalId(c provider.Context, client *provider.HTTPClient, config provider.Config, source, id string) (FindResults, bool, error) {
+	findSource, ok := findSources[source]
+	if !ok {
+		return FindResults{}, false, nil
+	}
+
+	apiClient := NewApiClient(client, c.Cache(), config)
+
+	res, err := apiClient.Find(c.Context(), id, findSource)
+	if err != nil {
+		return FindResults{}, false, err
+	}
+
+	return res, true, nil
+}
+
+// NOTE(patrik): Handles themoviedb.org/movie/550-fight-club and
+// themoviedb.org/collection/10-star-wars-collection
+func (t *TmdbMovieProvider) ResolveLink(u *url.URL) (provider.ResolvedLink, bool) {
+	if !provider.IsHost(u, siteHost) {
+		return provider.ResolvedLink{}, false
+	}
+
+	segments := provider.PathSegments(u)
+	if len(segments) < 2 {
+		return provider.ResolvedLink{}, false
+	}
+
+	id, ok := provider.LeadingId(segments[1])
+	if !ok {
+		return provider.ResolvedLink{}, false
+	}
+
+	switch segments[0] {
+	case "movie":
+		return provider.ResolvedLink{
+			ProviderId: id,
+			Type:       provider.SearchResultTypeMedia,
+		}, true
+	case "collection":
+		return provider.ResolvedLink{
+			ProviderId: id,
+			Type:       provider.SearchResultTypeCollection,
+		}, true
+	}
+
+	return provider.ResolvedLink{}, false
+}
+
+func (t *TmdbMovieProvider) ResolveExternalId(c provider.Context, source, id string) ([]provider.ResolvedLink, error) {
+	found, ok, err := findExternalId(c, t.client, t.config, source, id)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	res := make([]provider.ResolvedLink, 0, len(found.MovieResults))
+	for _, movie := range found.MovieResults {
+		res = append(res, provider.ResolvedLink{
+			ProviderId: strconv.Itoa(movie.Id),
+			Type:       provider.SearchResultTypeMedia,
+		})
+	}
+
+	return res, nil
+}
+
+// NOTE(patrik): Handles themoviedb.org/tv/1399-game-of-thrones (the serie
+// as a collection) and themoviedb.org/tv/1399/season/2 (a single season)
+func (t *TmdbTvProvider) ResolveLink(u *url.URL) (provider.ResolvedLink, bool) {
+	if !provider.IsHost(u, siteHost) {
+		return provider.ResolvedLink{}, false
+	}
+
+	segments := provider.PathSegments(u)
+	if len(segments) < 2 || segments[0] != "tv" {
+		return provider.ResolvedLink{}, false
+	}
+
+	id, ok := provider.LeadingId(segments[1])
+	if !ok {
+		return provider.ResolvedLink{}, false
+	}
+
+	if len(segments) >= 4 && segments[2] == "season" {
+		seasonNumber, ok := provider.LeadingId(segments[3])
+		if !ok {
+			return provider.ResolvedLink{}, false
+		}
+
+		return provider.ResolvedLink{
+			ProviderId: id + "@" + seasonNumber,
+			Type:       provider.SearchResultTypeMedia,
+		}, true
+	}
+
+	return provider.ResolvedLink{
+		ProviderId: id,
+		Type:       provider.SearchResultTypeCollection,
+	}, true
+}
+
+func (t *TmdbTvProvider) ResolveExternalId(c provider.Context, source, id string) ([]provider.ResolvedLink, error) {
+	found, ok, err := findExternalId(c, t.client, t.config, source, id)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	res := make([]provider.ResolvedLink, 0, len(found.TvResults))
+	for _, tv := range found.TvResults {
+		res = append(res, provider.ResolvedLink{
+			ProviderId: strconv.Itoa(tv.Id),
+			Type:       provider.SearchResultTypeCollection,
+		})
+	}
+
+	return res, nil
+}
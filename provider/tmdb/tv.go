@@ -13,25 +13,35 @@ import (
 )
 
 var _ provider.Provider = (*TmdbTvProvider)(nil)
+var _ provider.ImageProvider = (*TmdbTvProvider)(nil)
 
 const TvProviderName = "tmdb-tv"
 
 type TmdbTvProvider struct {
+	config provider.Config
+	client *provider.HTTPClient
+}
+
+func NewTmdbTvProvider(config provider.Config) *TmdbTvProvider {
+	return &TmdbTvProvider{
+		config: config,
+		client: newHttpClient(config),
+	}
 }
 
 func (t *TmdbTvProvider) Info() provider.Info {
-	return provider.Info{
+	return t.config.RequireApiKey(provider.Info{
 		Name:                    TvProviderName,
 		DisplayName:             "TheMovieDB TV",
 		SupportGetMedia:         true,
 		SupportSearchMedia:      false,
 		SupportGetCollection:    true,
 		SupportSearchCollection: true,
-	}
+	})
 }
 
 func (t *TmdbTvProvider) GetCollection(c provider.Context, id string) (provider.Collection, error) {
-	apiClient := NewApiClient(c.Cache())
+	apiClient := NewApiClient(t.client, c.Cache(), t.config)
 
 	details, err := apiClient.GetTvDetails(c.Context(), id)
 	if err != nil {
@@ -75,7 +85,7 @@ func (t *TmdbTvProvider) GetCollection(c provider.Context, id string) (provider.
 }
 
 func (t *TmdbTvProvider) GetMedia(c provider.Context, id string) (provider.Media, error) {
-	apiClient := NewApiClient(c.Cache())
+	apiClient := NewApiClient(t.client, c.Cache(), t.config)
 
 	splits := strings.Split(id, "@")
 	if len(splits) != 2 {
@@ -95,6 +105,25 @@ func (t *TmdbTvProvider) GetMedia(c provider.Context, id string) (provider.Media
 		return provider.Media{}, err
 	}
 
+	credits, err := apiClient.GetSeasonCredits(c.Context(), serieId, seasonNumber)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	videos, err := apiClient.GetSeasonVideos(c.Context(), serieId, seasonNumber)
+	if err != nil {
+		return provider.Media{}, err
+	}
+
+	// NOTE(patrik): Most of the seasons doesn't have any videos, use the
+	// videos from the serie instead
+	if len(videos.Results) == 0 {
+		videos, err = apiClient.GetTvVideos(c.Context(), serieId)
+		if err != nil {
+			return provider.Media{}, err
+		}
+	}
+
 	var description *string
 	if seasonDetails.Overview != "" {
 		description = &seasonDetails.Overview
@@ -173,6 +202,8 @@ func (t *TmdbTvProvider) GetMedia(c provider.Context, id string) (provider.Media
 		Creators:         creators,
 		Tags:             tags,
 		Parts:            make([]provider.MediaPart, len(seasonDetails.Episodes)),
+		Credits:          convertCredits(credits, details.ProductionCompanies, details.CreatedBy),
+		Videos:           convertVideos(videos),
 		ExtraProviderIds: map[string]string{},
 	}
 
@@ -195,7 +226,7 @@ func (t *TmdbTvProvider) GetMedia(c provider.Context, id string) (provider.Media
 }
 
 func (t *TmdbTvProvider) SearchCollection(c provider.Context, query string) ([]provider.SearchResult, error) {
-	apiClient := NewApiClient(c.Cache())
+	apiClient := NewApiClient(t.client, c.Cache(), t.config)
 
 	search, err := apiClient.TvSearch(c.Context(), query)
 	if err != nil {
@@ -217,6 +248,58 @@ func (t *TmdbTvProvider) SearchCollection(c provider.Context, query string) ([]p
 	return res, nil
 }
 
+// NOTE(patrik): The media on this provider is the seasons of a show, search
+// for the show with SearchCollection instead
 func (t *TmdbTvProvider) SearchMedia(c provider.Context, query string) ([]provider.SearchResult, error) {
-	panic("unsupported")
+	return nil, provider.ErrMediaSearchNotSupported
+}
+
+func (t *TmdbTvProvider) GetMediaImages(c provider.Context, id string) ([]provider.Image, error) {
+	apiClient := NewApiClient(t.client, c.Cache(), t.config)
+
+	splits := strings.Split(id, "@")
+	if len(splits) != 2 {
+		return nil, errors.New("not found")
+	}
+
+	serieId := splits[0]
+	seasonNumber := splits[1]
+
+	// NOTE(patrik): The seasons only has posters, the backdrops and logos
+	// comes from the serie
+	seasonImages, err := apiClient.GetSeasonImages(c.Context(), serieId, seasonNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := apiClient.GetTvImages(c.Context(), serieId)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []provider.Image
+	res = appendImages(res, types.ImageTypeCover, seasonImages.Posters)
+	res = appendImages(res, types.ImageTypeBanner, images.Backdrops)
+	res = appendImages(res, types.ImageTypeLogo, images.Logos)
+
+	return res, nil
+}
+
+func (t *TmdbTvProvider) GetAvailability(c provider.Context, id string) ([]provider.Availability, error) {
+	apiClient := NewApiClient(t.client, c.Cache(), t.config)
+
+	splits := strings.Split(id, "@")
+	if len(splits) != 2 {
+		return nil, errors.New("not found")
+	}
+
+	serieId := splits[0]
+	seasonNumber := splits[1]
+
+	watchProviders, err := apiClient.GetSeasonWatchProviders(c.Context(), serieId, seasonNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertWatchProviders(watchProviders), nil
 }
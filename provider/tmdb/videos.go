@@ -0,0 +1,56 @@
+package tmdb
+
+import (
+	"github.com/nanoteck137/watchbook/provider"
+	"github.com/nanoteck137/watchbook/types"
+)
+
+func convertVideoSite(site string) (types.VideoSite, bool) {
+	switch site {
+	case "YouTube":
+		return types.VideoSiteYoutube, true
+	case "Vimeo":
+		return types.VideoSiteVimeo, true
+	}
+
+	return "", false
+}
+
+func convertVideoType(typ string) types.VideoType {
+	switch typ {
+	case "Trailer":
+		return types.VideoTypeTrailer
+	case "Teaser":
+		return types.VideoTypeTeaser
+	case "Clip":
+		return types.VideoTypeClip
+	case "Featurette", "Behind the Scenes":
+		return types.VideoTypeFeaturette
+	case "Opening Credits":
+		return types.VideoTypeOpening
+	}
+
+	return types.VideoTypeOther
+}
+
+func convertVideos(videos Videos) []provider.Video {
+	var res []provider.Video
+
+	for _, video := range videos.Results {
+		site, ok := convertVideoSite(video.Site)
+		if !ok {
+			continue
+		}
+
+		res = append(res, provider.Video{
+			Site:     site,
+			Key:      video.Key,
+			Type:     convertVideoType(video.Type),
+			Name:     video.Name,
+			Language: video.Iso639_1,
+			Official: video.Official,
+		})
+	}
+
+	return res
+}
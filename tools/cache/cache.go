@@ -121,6 +121,21 @@ func (c *ProviderCache) ClearByProviderName(name string) error {
 	return err
 }
 
+// NOTE(patrik): Expired entries is otherwise only removed when they are read,
+// returns the number of removed entries
+func (c *ProviderCache) ClearExpired() (int64, error) {
+	query := dialect.Delete(table).
+		Where(
+			table.Col("expires_at").Lt(time.Now()),
+		)
+	res, err := c.db.Exec(context.Background(), query)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
 func (c *ProviderCache) Clear() error {
 	query := dialect.Delete(table)
 	_, err := c.db.Exec(context.Background(), query)
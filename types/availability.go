@@ -0,0 +1,65 @@
+package types
+
+import (
+	"errors"
+	"regexp"
+)
+
+type AvailabilityType string
+
+const (
+	AvailabilityTypeSubscription AvailabilityType = "subscription"
+	AvailabilityTypeFree         AvailabilityType = "free"
+	AvailabilityTypeAds          AvailabilityType = "ads"
+	AvailabilityTypeRent         AvailabilityType = "rent"
+	AvailabilityTypeBuy          AvailabilityType = "buy"
+)
+
+func IsValidAvailabilityType(t AvailabilityType) bool {
+	switch t {
+	case AvailabilityTypeSubscription,
+		AvailabilityTypeFree,
+		AvailabilityTypeAds,
+		AvailabilityTypeRent,
+		AvailabilityTypeBuy:
+		return true
+	}
+
+	return false
+}
+
+// NOTE(patrik): Regions is ISO 3166-1 country codes (US, SE, JP)
+var regionRegex = regexp.MustCompile(`^[A-Z]{2}$`)
+
+func IsValidRegion(region string) bool {
+	return regionRegex.MatchString(region)
+}
+
+func ValidateRegion(val any) error {
+	if s, ok := val.(string); ok {
+		if s == "" {
+			return nil
+		}
+
+		if !IsValidRegion(s) {
+			return errors.New("invalid region")
+		}
+	} else if p, ok := val.(*string); ok {
+		if p == nil {
+			return nil
+		}
+
+		s := *p
+		if s == "" {
+			return nil
+		}
+
+		if !IsValidRegion(s) {
+			return errors.New("invalid region")
+		}
+	} else {
+		return errors.New("expected string")
+	}
+
+	return nil
+}
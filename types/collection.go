@@ -8,6 +8,8 @@ const (
 	CollectionTypeUnknown CollectionType = "unknown"
 	CollectionTypeSeries  CollectionType = "series"
 	CollectionTypeAnime   CollectionType = "anime"
+	// NOTE(patrik): Movie series like a trilogy
+	CollectionTypeFranchise CollectionType = "franchise"
 )
 
 // func (t MediaType) IsMovie() bool {
@@ -18,7 +20,8 @@ func IsValidCollectionType(t CollectionType) bool {
 	switch t {
 	case CollectionTypeUnknown,
 		CollectionTypeSeries,
-		CollectionTypeAnime:
+		CollectionTypeAnime,
+		CollectionTypeFranchise:
 		return true
 	}
 
@@ -40,6 +40,10 @@ func (d WorkDir) CollectionDirById(id string) CollectionDir {
 	return CollectionDir(path.Join(d.CollectionsDir(), id))
 }
 
+func (d WorkDir) MetadataDir() string {
+	return path.Join(d.String(), "metadata")
+}
+
 func (d WorkDir) ShowsDir() string {
 	return path.Join(d.String(), "shows")
 }
@@ -9,6 +9,9 @@ const (
 	JobStatusRunning JobStatus = "running"
 	JobStatusSuccess JobStatus = "success"
 	JobStatusFailed  JobStatus = "failed"
+	// NOTE(patrik): Cancelled by a user, a queued job never runs and a running
+	// job gets the handler cancelled
+	JobStatusCancelled JobStatus = "cancelled"
 )
 
 func IsValidJobStatus(t JobStatus) bool {
@@ -16,7 +19,20 @@ func IsValidJobStatus(t JobStatus) bool {
 	case JobStatusQueued,
 		JobStatusRunning,
 		JobStatusSuccess,
-		JobStatusFailed:
+		JobStatusFailed,
+		JobStatusCancelled:
+		return true
+	}
+
+	return false
+}
+
+// NOTE(patrik): Returns true if the job will not run again
+func IsFinishedJobStatus(t JobStatus) bool {
+	switch t {
+	case JobStatusSuccess,
+		JobStatusFailed,
+		JobStatusCancelled:
 		return true
 	}
 
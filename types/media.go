@@ -352,3 +352,154 @@ func ValidateMediaPartReleaseType(val any) error {
 
 	return nil
 }
+
+type ThemeSongType string
+
+const (
+	ThemeSongTypeOpening ThemeSongType = "opening"
+	ThemeSongTypeEnding  ThemeSongType = "ending"
+)
+
+func IsValidThemeSongType(t ThemeSongType) bool {
+	switch t {
+	case ThemeSongTypeOpening,
+		ThemeSongTypeEnding:
+		return true
+	}
+
+	return false
+}
+
+type MediaRelationType string
+
+const (
+	MediaRelationTypeSequel      MediaRelationType = "sequel"
+	MediaRelationTypePrequel     MediaRelationType = "prequel"
+	MediaRelationTypeSideStory   MediaRelationType = "side-story"
+	MediaRelationTypeParentStory MediaRelationType = "parent-story"
+	MediaRelationTypeAlternative MediaRelationType = "alternative"
+	MediaRelationTypeSpinOff     MediaRelationType = "spin-off"
+	MediaRelationTypeSummary     MediaRelationType = "summary"
+	MediaRelationTypeFullStory   MediaRelationType = "full-story"
+	MediaRelationTypeAdaptation  MediaRelationType = "adaptation"
+	MediaRelationTypeSource      MediaRelationType = "source"
+	MediaRelationTypeCharacter   MediaRelationType = "character"
+	MediaRelationTypeOther       MediaRelationType = "other"
+)
+
+func IsValidMediaRelationType(t MediaRelationType) bool {
+	switch t {
+	case MediaRelationTypeSequel,
+		MediaRelationTypePrequel,
+		MediaRelationTypeSideStory,
+		MediaRelationTypeParentStory,
+		MediaRelationTypeAlternative,
+		MediaRelationTypeSpinOff,
+		MediaRelationTypeSummary,
+		MediaRelationTypeFullStory,
+		MediaRelationTypeAdaptation,
+		MediaRelationTypeSource,
+		MediaRelationTypeCharacter,
+		MediaRelationTypeOther:
+		return true
+	}
+
+	return false
+}
+
+type ImageType string
+
+const (
+	ImageTypeCover  ImageType = "cover"
+	ImageTypeBanner ImageType = "banner"
+	ImageTypeLogo   ImageType = "logo"
+)
+
+func IsValidImageType(t ImageType) bool {
+	switch t {
+	case ImageTypeCover,
+		ImageTypeBanner,
+		ImageTypeLogo:
+		return true
+	}
+
+	return false
+}
+
+func ValidateImageType(val any) error {
+	if s, ok := val.(string); ok {
+		if s == "" {
+			return nil
+		}
+
+		t := ImageType(s)
+		if !IsValidImageType(t) {
+			return errors.New("invalid type")
+		}
+	} else if p, ok := val.(*string); ok {
+		if p == nil {
+			return nil
+		}
+
+		s := *p
+		if s == "" {
+			return nil
+		}
+
+		t := ImageType(s)
+		if !IsValidImageType(t) {
+			return errors.New("invalid type")
+		}
+	} else {
+		return errors.New("expected string")
+	}
+
+	return nil
+}
+
+type VideoType string
+
+const (
+	VideoTypeTrailer    VideoType = "trailer"
+	VideoTypeTeaser     VideoType = "teaser"
+	VideoTypeClip       VideoType = "clip"
+	VideoTypeFeaturette VideoType = "featurette"
+	VideoTypeOpening    VideoType = "opening"
+	VideoTypeEnding     VideoType = "ending"
+	VideoTypeOther      VideoType = "other"
+)
+
+func IsValidVideoType(t VideoType) bool {
+	switch t {
+	case VideoTypeTrailer,
+		VideoTypeTeaser,
+		VideoTypeClip,
+		VideoTypeFeaturette,
+		VideoTypeOpening,
+		VideoTypeEnding,
+		VideoTypeOther:
+		return true
+	}
+
+	return false
+}
+
+type VideoSite string
+
+const (
+	VideoSiteYoutube VideoSite = "youtube"
+	VideoSiteVimeo   VideoSite = "vimeo"
+)
+
+// NOTE(patrik): Returns the page url and the embed url of the video, empty
+// strings for the unknown sites
+func GetVideoUrls(site VideoSite, key string) (string, string) {
+	switch site {
+	case VideoSiteYoutube:
+		return "https://www.youtube.com/watch?v=" + key, "https://www.youtube.com/embed/" + key
+	case VideoSiteVimeo:
+		return "https://vimeo.com/" + key, "https://player.vimeo.com/video/" + key
+	}
+
+	return "", ""
+}
@@ -0,0 +1,51 @@
+package types
+
+type PersonType string
+
+const (
+	PersonTypePerson PersonType = "person"
+	// NOTE(patrik): Studios and production companies
+	PersonTypeOrganization PersonType = "organization"
+)
+
+func IsValidPersonType(t PersonType) bool {
+	switch t {
+	case PersonTypePerson,
+		PersonTypeOrganization:
+		return true
+	}
+
+	return false
+}
+
+type PersonRole string
+
+const (
+	PersonRoleStudio   PersonRole = "studio"
+	PersonRoleDirector PersonRole = "director"
+	PersonRoleWriter   PersonRole = "writer"
+	// NOTE(patrik): Creator of the original work (manga, novel) or the
+	// creator of the serie
+	PersonRoleCreator  PersonRole = "creator"
+	PersonRoleComposer PersonRole = "composer"
+	// NOTE(patrik): Actors and voice actors, the character is stored with
+	// the role
+	PersonRoleCast PersonRole = "cast"
+	// NOTE(patrik): Everyone else from the staff lists
+	PersonRoleStaff PersonRole = "staff"
+)
+
+func IsValidPersonRole(r PersonRole) bool {
+	switch r {
+	case PersonRoleStudio,
+		PersonRoleDirector,
+		PersonRoleWriter,
+		PersonRoleCreator,
+		PersonRoleComposer,
+		PersonRoleCast,
+		PersonRoleStaff:
+		return true
+	}
+
+	return false
+}
@@ -36,9 +36,12 @@ var CreateMediaId = createIdGenerator(12)
 var CreateCollectionId = createIdGenerator(8)
 var CreateShowId = createIdGenerator(8)
 var CreateShowSeasonId = createIdGenerator(8)
+var CreatePersonId = createIdGenerator(8)
 
 var CreateJobId = createIdGenerator(6)
 
+var CreateJobScheduleId = createIdGenerator(8)
+
 var CreateFolderId = createIdGenerator(8)
 
 var CreateApiTokenId = createIdGenerator(32)
@@ -421,6 +424,22 @@ func DownloadImageHashed(url, outDir string) (string, error) {
 	return path.Join(outDir, filename), nil
 }
 
+func CopyImageHashed(src, outDir string) (string, error) {
+	ext := strings.ToLower(path.Ext(src))
+
+	_, err := ImageExtToContentType(ext)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return "", fmt.Errorf("failed to read image: %w", err)
+	}
+
+	return WriteHashedFile(data, outDir, ext)
+}
+
 func NextAiringDate(start time.Time, delayDays, intervalDays int) time.Time {
 	effectiveStart := start.Add(time.Duration(delayDays) * 24 * time.Hour)
 	now := time.Now().UTC()
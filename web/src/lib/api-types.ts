@@ -66,6 +66,7 @@ export const collectionTypes = [
   { label: "Unknown", value: "unknown" },
   { label: "Series", value: "series" },
   { label: "Anime", value: "anime" },
+  { label: "Franchise", value: "franchise" },
 ] as const;
 export type CollectionType = (typeof collectionTypes)[number]["value"];
 export const CollectionTypeEnum = z.enum(
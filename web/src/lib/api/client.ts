@@ -31,6 +31,18 @@ export class ApiClient extends BaseApiClient {
     return this.request(`/api/v1/shows/${id}/seasons/${seasonNum}/items`, "POST", z.undefined(), z.any(), body, options)
   }
   
+  backfillMediaProviders(body: api.ResolveMediaProvidersBody, options?: ExtraOptions) {
+    return this.request("/api/v1/providers/backfill", "POST", api.BackfillMediaProviders, z.any(), body, options)
+  }
+  
+  buildShowFromChain(id: string, body: api.BuildShowFromChainBody, options?: ExtraOptions) {
+    return this.request(`/api/v1/media/${id}/build-show`, "POST", api.BuildShowFromChain, z.any(), body, options)
+  }
+  
+  cancelJob(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/jobs/${id}/cancel`, "POST", z.undefined(), z.any(), undefined, options)
+  }
+  
   changeCollectionImages(id: string, body: FormData, options?: ExtraOptions) {
     return this.requestForm(`/api/v1/collections/${id}/images`, "PATCH", z.undefined(), z.any(), body, options)
   }
@@ -55,6 +67,10 @@ export class ApiClient extends BaseApiClient {
     return this.request("/api/v1/folders", "POST", api.CreateFolder, z.any(), body, options)
   }
   
+  createJobSchedule(body: api.CreateJobScheduleBody, options?: ExtraOptions) {
+    return this.request("/api/v1/job-schedules", "POST", api.CreateJobSchedule, z.any(), body, options)
+  }
+  
   createMedia(body: api.CreateMediaBody, options?: ExtraOptions) {
     return this.request("/api/v1/media", "POST", api.CreateMedia, z.any(), body, options)
   }
@@ -75,6 +91,14 @@ export class ApiClient extends BaseApiClient {
     return this.request(`/api/v1/folders/${id}`, "DELETE", z.undefined(), z.any(), undefined, options)
   }
   
+  deleteJob(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/jobs/${id}`, "DELETE", z.undefined(), z.any(), undefined, options)
+  }
+  
+  deleteJobSchedule(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/job-schedules/${id}`, "DELETE", z.undefined(), z.any(), undefined, options)
+  }
+  
   deleteMedia(id: string, options?: ExtraOptions) {
     return this.request(`/api/v1/media/${id}`, "DELETE", z.undefined(), z.any(), undefined, options)
   }
@@ -103,6 +127,10 @@ export class ApiClient extends BaseApiClient {
     return this.request(`/api/v1/folders/${id}`, "PATCH", z.undefined(), z.any(), body, options)
   }
   
+  editJobSchedule(id: string, body: api.EditJobScheduleBody, options?: ExtraOptions) {
+    return this.request(`/api/v1/job-schedules/${id}`, "PATCH", z.undefined(), z.any(), body, options)
+  }
+  
   editMedia(id: string, body: api.EditMediaBody, options?: ExtraOptions) {
     return this.request(`/api/v1/media/${id}`, "PATCH", z.undefined(), z.any(), body, options)
   }
@@ -152,6 +180,22 @@ export class ApiClient extends BaseApiClient {
     return this.request("/api/v1/folders", "GET", api.GetFolders, z.any(), undefined, options)
   }
   
+  getJobById(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/jobs/${id}`, "GET", api.GetJobById, z.any(), undefined, options)
+  }
+  
+  getJobScheduleById(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/job-schedules/${id}`, "GET", api.GetJobScheduleById, z.any(), undefined, options)
+  }
+  
+  getJobSchedules(options?: ExtraOptions) {
+    return this.request("/api/v1/job-schedules", "GET", api.GetJobSchedules, z.any(), undefined, options)
+  }
+  
+  getJobs(options?: ExtraOptions) {
+    return this.request("/api/v1/jobs", "GET", api.GetJobs, z.any(), undefined, options)
+  }
+  
   getMe(options?: ExtraOptions) {
     return this.request("/api/v1/auth/me", "GET", api.GetMe, z.any(), undefined, options)
   }
@@ -160,15 +204,43 @@ export class ApiClient extends BaseApiClient {
     return this.request("/api/v1/media", "GET", api.GetMedia, z.any(), undefined, options)
   }
   
+  getMediaAvailability(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/media/${id}/availability`, "GET", api.GetMediaAvailability, z.any(), undefined, options)
+  }
+  
   getMediaById(id: string, options?: ExtraOptions) {
     return this.request(`/api/v1/media/${id}`, "GET", api.GetMediaById, z.any(), undefined, options)
   }
   
   
+  getMediaImages(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/media/${id}/images`, "GET", api.GetMediaImages, z.any(), undefined, options)
+  }
+  
   getMediaParts(id: string, options?: ExtraOptions) {
     return this.request(`/api/v1/media/${id}/parts`, "GET", api.GetMediaParts, z.any(), undefined, options)
   }
   
+  getMediaPeople(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/media/${id}/people`, "GET", api.GetMediaPeople, z.any(), undefined, options)
+  }
+  
+  getMediaRelations(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/media/${id}/relations`, "GET", api.GetMediaRelations, z.any(), undefined, options)
+  }
+  
+  getMissingMediaRelations(options?: ExtraOptions) {
+    return this.request("/api/v1/media/relations/missing", "GET", api.GetMissingMediaRelations, z.any(), undefined, options)
+  }
+  
+  getMyJobs(options?: ExtraOptions) {
+    return this.request("/api/v1/user/jobs", "GET", api.GetJobs, z.any(), undefined, options)
+  }
+  
+  getPersonById(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/people/${id}`, "GET", api.GetPersonById, z.any(), undefined, options)
+  }
+  
   getProviders(options?: ExtraOptions) {
     return this.request("/api/v1/providers", "GET", api.GetProviders, z.any(), undefined, options)
   }
@@ -207,13 +279,21 @@ export class ApiClient extends BaseApiClient {
   }
   
   importMalAnimeList(username: string, options?: ExtraOptions) {
-    return this.request(`/api/v1/users/import/mal/${username}/anime`, "POST", z.undefined(), z.any(), undefined, options)
+    return this.request(`/api/v1/users/import/mal/${username}/anime`, "POST", api.ImportMalAnimeList, z.any(), undefined, options)
   }
   
   moveFolderItem(id: string, mediaId: string, pos: string, options?: ExtraOptions) {
     return this.request(`/api/v1/folders/${id}/items/${mediaId}/move/${pos}`, "POST", z.undefined(), z.any(), undefined, options)
   }
   
+  promoteMediaImage(id: string, body: api.PromoteMediaImageBody, options?: ExtraOptions) {
+    return this.request(`/api/v1/media/${id}/images/promote`, "POST", z.undefined(), z.any(), body, options)
+  }
+  
+  providerGetSeasonal(providerName: string, season: string, year: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/providers/${providerName}/seasonal/${season}/${year}`, "GET", api.GetProviderSeasonal, z.any(), undefined, options)
+  }
+  
   providerImportCollections(providerName: string, body: api.PostProviderImportCollectionsBody, options?: ExtraOptions) {
     return this.request(`/api/v1/providers/${providerName}/collections/import`, "POST", z.undefined(), z.any(), body, options)
   }
@@ -222,16 +302,28 @@ export class ApiClient extends BaseApiClient {
     return this.request(`/api/v1/providers/${providerName}/media/import`, "POST", z.undefined(), z.any(), body, options)
   }
   
+  providerImportSeasonal(providerName: string, body: api.PostProviderImportSeasonalBody, options?: ExtraOptions) {
+    return this.request(`/api/v1/providers/${providerName}/seasonal/import`, "POST", api.PostProviderImportSeasonal, z.any(), body, options)
+  }
+  
   providerImportShows(providerName: string, body: api.PostProviderImportCollectionsBody, options?: ExtraOptions) {
     return this.request(`/api/v1/providers/${providerName}/shows/import`, "POST", z.undefined(), z.any(), body, options)
   }
   
+  providerMultiSearchMedia(options?: ExtraOptions) {
+    return this.request("/api/v1/providers/search/media", "GET", api.GetProviderMultiSearch, z.any(), undefined, options)
+  }
+  
+  providerResolveLinks(body: api.PostProviderResolveLinksBody, options?: ExtraOptions) {
+    return this.request("/api/v1/providers/links", "POST", api.PostProviderResolveLinks, z.any(), body, options)
+  }
+  
   providerSearchCollections(providerName: string, options?: ExtraOptions) {
     return this.request(`/api/v1/providers/${providerName}/collections`, "GET", api.GetProviderSearch, z.any(), undefined, options)
   }
   
   providerSearchMedia(providerName: string, options?: ExtraOptions) {
-    return this.request(`/api/v1/providers/${providerName}/media`, "GET", api.GetProviderSearch, z.any(), undefined, options)
+    return this.request(`/api/v1/providers/${providerName}/media`, "GET", api.GetProviderSearchMedia, z.any(), undefined, options)
   }
   
   providerSearchShows(providerName: string, options?: ExtraOptions) {
@@ -246,10 +338,18 @@ export class ApiClient extends BaseApiClient {
     return this.request(`/api/v1/providers/${providerName}/media/${mediaId}`, "PATCH", z.undefined(), z.any(), body, options)
   }
   
+  providerUpdateShow(providerName: string, showId: string, body: api.ProviderCollectionUpdateBody, options?: ExtraOptions) {
+    return this.request(`/api/v1/providers/${providerName}/collections/${showId}`, "PATCH", z.undefined(), z.any(), body, options)
+  }
+  
   providerUpdateUnknownMedia(options?: ExtraOptions) {
     return this.request("/api/v1/providers/updateUnknownMedia", "POST", z.undefined(), z.any(), undefined, options)
   }
   
+  refreshMediaImages(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/media/${id}/images/refresh`, "POST", api.RefreshMediaImages, z.any(), undefined, options)
+  }
+  
   removeCollectionItem(id: string, mediaId: string, options?: ExtraOptions) {
     return this.request(`/api/v1/collections/${id}/items/${mediaId}`, "DELETE", z.undefined(), z.any(), undefined, options)
   }
@@ -258,6 +358,10 @@ export class ApiClient extends BaseApiClient {
     return this.request(`/api/v1/folders/${id}/items/${mediaId}`, "DELETE", z.undefined(), z.any(), undefined, options)
   }
   
+  removeMediaImage(id: string, file: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/media/${id}/images/${file}`, "DELETE", z.undefined(), z.any(), undefined, options)
+  }
+  
   removePart(id: string, index: string, options?: ExtraOptions) {
     return this.request(`/api/v1/media/${id}/parts/${index}`, "DELETE", z.undefined(), z.any(), undefined, options)
   }
@@ -270,6 +374,18 @@ export class ApiClient extends BaseApiClient {
     return this.request(`/api/v1/shows/${id}/seasons/${seasonNum}/items/${mediaId}`, "DELETE", z.undefined(), z.any(), undefined, options)
   }
   
+  resolveMediaProviders(id: string, body: api.ResolveMediaProvidersBody, options?: ExtraOptions) {
+    return this.request(`/api/v1/media/${id}/providers/resolve`, "POST", api.ResolveMediaProviders, z.any(), body, options)
+  }
+  
+  retryJob(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/jobs/${id}/retry`, "POST", z.undefined(), z.any(), undefined, options)
+  }
+  
+  runJobSchedule(id: string, options?: ExtraOptions) {
+    return this.request(`/api/v1/job-schedules/${id}/run`, "POST", api.RunJobSchedule, z.any(), undefined, options)
+  }
+  
   setMediaRelease(id: string, body: api.SetMediaReleaseBody, options?: ExtraOptions) {
     return this.request(`/api/v1/media/${id}/release`, "POST", z.undefined(), z.any(), body, options)
   }
@@ -322,6 +438,18 @@ export class ClientUrls {
     return createUrl(this.baseUrl, `/api/v1/shows/${id}/seasons/${seasonNum}/items`)
   }
   
+  backfillMediaProviders() {
+    return createUrl(this.baseUrl, "/api/v1/providers/backfill")
+  }
+  
+  buildShowFromChain(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/media/${id}/build-show`)
+  }
+  
+  cancelJob(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/jobs/${id}/cancel`)
+  }
+  
   changeCollectionImages(id: string) {
     return createUrl(this.baseUrl, `/api/v1/collections/${id}/images`)
   }
@@ -346,6 +474,10 @@ export class ClientUrls {
     return createUrl(this.baseUrl, "/api/v1/folders")
   }
   
+  createJobSchedule() {
+    return createUrl(this.baseUrl, "/api/v1/job-schedules")
+  }
+  
   createMedia() {
     return createUrl(this.baseUrl, "/api/v1/media")
   }
@@ -366,6 +498,14 @@ export class ClientUrls {
     return createUrl(this.baseUrl, `/api/v1/folders/${id}`)
   }
   
+  deleteJob(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/jobs/${id}`)
+  }
+  
+  deleteJobSchedule(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/job-schedules/${id}`)
+  }
+  
   deleteMedia(id: string) {
     return createUrl(this.baseUrl, `/api/v1/media/${id}`)
   }
@@ -394,6 +534,10 @@ export class ClientUrls {
     return createUrl(this.baseUrl, `/api/v1/folders/${id}`)
   }
   
+  editJobSchedule(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/job-schedules/${id}`)
+  }
+  
   editMedia(id: string) {
     return createUrl(this.baseUrl, `/api/v1/media/${id}`)
   }
@@ -446,6 +590,22 @@ export class ClientUrls {
     return createUrl(this.baseUrl, "/api/v1/folders")
   }
   
+  getJobById(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/jobs/${id}`)
+  }
+  
+  getJobScheduleById(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/job-schedules/${id}`)
+  }
+  
+  getJobSchedules() {
+    return createUrl(this.baseUrl, "/api/v1/job-schedules")
+  }
+  
+  getJobs() {
+    return createUrl(this.baseUrl, "/api/v1/jobs")
+  }
+  
   getMe() {
     return createUrl(this.baseUrl, "/api/v1/auth/me")
   }
@@ -454,6 +614,10 @@ export class ClientUrls {
     return createUrl(this.baseUrl, "/api/v1/media")
   }
   
+  getMediaAvailability(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/media/${id}/availability`)
+  }
+  
   getMediaById(id: string) {
     return createUrl(this.baseUrl, `/api/v1/media/${id}`)
   }
@@ -462,10 +626,34 @@ export class ClientUrls {
     return createUrl(this.baseUrl, `/files/media/${id}/images/${file}`)
   }
   
+  getMediaImages(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/media/${id}/images`)
+  }
+  
   getMediaParts(id: string) {
     return createUrl(this.baseUrl, `/api/v1/media/${id}/parts`)
   }
   
+  getMediaPeople(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/media/${id}/people`)
+  }
+  
+  getMediaRelations(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/media/${id}/relations`)
+  }
+  
+  getMissingMediaRelations() {
+    return createUrl(this.baseUrl, "/api/v1/media/relations/missing")
+  }
+  
+  getMyJobs() {
+    return createUrl(this.baseUrl, "/api/v1/user/jobs")
+  }
+  
+  getPersonById(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/people/${id}`)
+  }
+  
   getProviders() {
     return createUrl(this.baseUrl, "/api/v1/providers")
   }
@@ -514,6 +702,14 @@ export class ClientUrls {
     return createUrl(this.baseUrl, `/api/v1/folders/${id}/items/${mediaId}/move/${pos}`)
   }
   
+  promoteMediaImage(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/media/${id}/images/promote`)
+  }
+  
+  providerGetSeasonal(providerName: string, season: string, year: string) {
+    return createUrl(this.baseUrl, `/api/v1/providers/${providerName}/seasonal/${season}/${year}`)
+  }
+  
   providerImportCollections(providerName: string) {
     return createUrl(this.baseUrl, `/api/v1/providers/${providerName}/collections/import`)
   }
@@ -522,10 +718,22 @@ export class ClientUrls {
     return createUrl(this.baseUrl, `/api/v1/providers/${providerName}/media/import`)
   }
   
+  providerImportSeasonal(providerName: string) {
+    return createUrl(this.baseUrl, `/api/v1/providers/${providerName}/seasonal/import`)
+  }
+  
   providerImportShows(providerName: string) {
     return createUrl(this.baseUrl, `/api/v1/providers/${providerName}/shows/import`)
   }
   
+  providerMultiSearchMedia() {
+    return createUrl(this.baseUrl, "/api/v1/providers/search/media")
+  }
+  
+  providerResolveLinks() {
+    return createUrl(this.baseUrl, "/api/v1/providers/links")
+  }
+  
   providerSearchCollections(providerName: string) {
     return createUrl(this.baseUrl, `/api/v1/providers/${providerName}/collections`)
   }
@@ -546,10 +754,18 @@ export class ClientUrls {
     return createUrl(this.baseUrl, `/api/v1/providers/${providerName}/media/${mediaId}`)
   }
   
+  providerUpdateShow(providerName: string, showId: string) {
+    return createUrl(this.baseUrl, `/api/v1/providers/${providerName}/collections/${showId}`)
+  }
+  
   providerUpdateUnknownMedia() {
     return createUrl(this.baseUrl, "/api/v1/providers/updateUnknownMedia")
   }
   
+  refreshMediaImages(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/media/${id}/images/refresh`)
+  }
+  
   removeCollectionItem(id: string, mediaId: string) {
     return createUrl(this.baseUrl, `/api/v1/collections/${id}/items/${mediaId}`)
   }
@@ -558,6 +774,10 @@ export class ClientUrls {
     return createUrl(this.baseUrl, `/api/v1/folders/${id}/items/${mediaId}`)
   }
   
+  removeMediaImage(id: string, file: string) {
+    return createUrl(this.baseUrl, `/api/v1/media/${id}/images/${file}`)
+  }
+  
   removePart(id: string, index: string) {
     return createUrl(this.baseUrl, `/api/v1/media/${id}/parts/${index}`)
   }
@@ -570,6 +790,18 @@ export class ClientUrls {
     return createUrl(this.baseUrl, `/api/v1/shows/${id}/seasons/${seasonNum}/items/${mediaId}`)
   }
   
+  resolveMediaProviders(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/media/${id}/providers/resolve`)
+  }
+  
+  retryJob(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/jobs/${id}/retry`)
+  }
+  
+  runJobSchedule(id: string) {
+    return createUrl(this.baseUrl, `/api/v1/job-schedules/${id}/run`)
+  }
+  
   setMediaRelease(id: string) {
     return createUrl(this.baseUrl, `/api/v1/media/${id}/release`)
   }
@@ -61,6 +61,29 @@ export const ApiToken = z.object({
 });
 export type ApiToken = z.infer<typeof ApiToken>;
 
+// Name: BackfillMediaProviders
+export const BackfillMediaProviders = z.object({
+  // Name: BackfillMediaProviders.jobId
+  "jobId": z.string(),
+});
+export type BackfillMediaProviders = z.infer<typeof BackfillMediaProviders>;
+
+// Name: BuildShowFromChain
+export const BuildShowFromChain = z.object({
+  // Name: BuildShowFromChain.jobId
+  "jobId": z.string(),
+});
+export type BuildShowFromChain = z.infer<typeof BuildShowFromChain>;
+
+// Name: BuildShowFromChainBody
+export const BuildShowFromChainBody = z.object({
+  // Name: BuildShowFromChainBody.providerName
+  "providerName": z.string().optional(),
+  // Name: BuildShowFromChainBody.name
+  "name": z.string().optional(),
+});
+export type BuildShowFromChainBody = z.infer<typeof BuildShowFromChainBody>;
+
 // Name: ChangePasswordBody
 export const ChangePasswordBody = z.object({
   // Name: ChangePasswordBody.currentPassword
@@ -220,6 +243,41 @@ export const CreateFolderBody = z.object({
 });
 export type CreateFolderBody = z.infer<typeof CreateFolderBody>;
 
+// Name: CreateJobSchedule
+export const CreateJobSchedule = z.object({
+  // Name: CreateJobSchedule.id
+  "id": z.string(),
+});
+export type CreateJobSchedule = z.infer<typeof CreateJobSchedule>;
+
+// Name: JobPayloadValue
+export const JobPayloadValue = z.object({
+  // Name: JobPayloadValue.key
+  "key": z.string(),
+  // Name: JobPayloadValue.value
+  "value": z.string(),
+});
+export type JobPayloadValue = z.infer<typeof JobPayloadValue>;
+
+// Name: CreateJobScheduleBody
+export const CreateJobScheduleBody = z.object({
+  // Name: CreateJobScheduleBody.name
+  "name": z.string(),
+  // Name: CreateJobScheduleBody.type
+  "type": z.string(),
+  // Name: CreateJobScheduleBody.cron
+  "cron": z.string().optional(),
+  // Name: CreateJobScheduleBody.interval
+  "interval": z.string().optional(),
+  // Name: CreateJobScheduleBody.payload
+  "payload": z.array(JobPayloadValue).optional(),
+  // Name: CreateJobScheduleBody.maxAttempts
+  "maxAttempts": z.number().optional(),
+  // Name: CreateJobScheduleBody.enabled
+  "enabled": z.boolean(),
+});
+export type CreateJobScheduleBody = z.infer<typeof CreateJobScheduleBody>;
+
 // Name: CreateMedia
 export const CreateMedia = z.object({
   // Name: CreateMedia.id
@@ -319,6 +377,25 @@ export const EditFolderBody = z.object({
 });
 export type EditFolderBody = z.infer<typeof EditFolderBody>;
 
+// Name: EditJobScheduleBody
+export const EditJobScheduleBody = z.object({
+  // Name: EditJobScheduleBody.name
+  "name": z.string().nullable().optional(),
+  // Name: EditJobScheduleBody.type
+  "type": z.string().nullable().optional(),
+  // Name: EditJobScheduleBody.cron
+  "cron": z.string().nullable().optional(),
+  // Name: EditJobScheduleBody.interval
+  "interval": z.string().nullable().optional(),
+  // Name: EditJobScheduleBody.payload
+  "payload": z.array(JobPayloadValue).nullable().optional(),
+  // Name: EditJobScheduleBody.maxAttempts
+  "maxAttempts": z.number().nullable().optional(),
+  // Name: EditJobScheduleBody.enabled
+  "enabled": z.boolean().nullable().optional(),
+});
+export type EditJobScheduleBody = z.infer<typeof EditJobScheduleBody>;
+
 // Name: EditMediaBody
 export const EditMediaBody = z.object({
   // Name: EditMediaBody.type
@@ -538,6 +615,158 @@ export const GetFolders = z.object({
 });
 export type GetFolders = z.infer<typeof GetFolders>;
 
+// Name: GetJobById
+export const GetJobById = z.object({
+  // Name: GetJobById.id
+  "id": z.string(),
+  // Name: GetJobById.type
+  "type": z.string(),
+  // Name: GetJobById.status
+  "status": z.string(),
+  // Name: GetJobById.priority
+  "priority": z.number(),
+  // Name: GetJobById.runAt
+  "runAt": z.number(),
+  // Name: GetJobById.attempts
+  "attempts": z.number(),
+  // Name: GetJobById.maxAttempts
+  "maxAttempts": z.number(),
+  // Name: GetJobById.payload
+  "payload": z.array(JobPayloadValue),
+  // Name: GetJobById.error
+  "error": z.string().nullable(),
+  // Name: GetJobById.userId
+  "userId": z.string().nullable(),
+  // Name: GetJobById.workerId
+  "workerId": z.string().nullable(),
+  // Name: GetJobById.heartbeat
+  "heartbeat": z.number().nullable(),
+  // Name: GetJobById.leaseExpires
+  "leaseExpires": z.number().nullable(),
+  // Name: GetJobById.created
+  "created": z.number(),
+  // Name: GetJobById.updated
+  "updated": z.number(),
+});
+export type GetJobById = z.infer<typeof GetJobById>;
+
+// Name: GetJobScheduleById
+export const GetJobScheduleById = z.object({
+  // Name: GetJobScheduleById.id
+  "id": z.string(),
+  // Name: GetJobScheduleById.name
+  "name": z.string(),
+  // Name: GetJobScheduleById.type
+  "type": z.string(),
+  // Name: GetJobScheduleById.payload
+  "payload": z.array(JobPayloadValue),
+  // Name: GetJobScheduleById.maxAttempts
+  "maxAttempts": z.number(),
+  // Name: GetJobScheduleById.cron
+  "cron": z.string().nullable(),
+  // Name: GetJobScheduleById.interval
+  "interval": z.string().nullable(),
+  // Name: GetJobScheduleById.enabled
+  "enabled": z.boolean(),
+  // Name: GetJobScheduleById.fromConfig
+  "fromConfig": z.boolean(),
+  // Name: GetJobScheduleById.lastRun
+  "lastRun": z.number().nullable(),
+  // Name: GetJobScheduleById.nextRun
+  "nextRun": z.number().nullable(),
+  // Name: GetJobScheduleById.lastJobId
+  "lastJobId": z.string().nullable(),
+  // Name: GetJobScheduleById.created
+  "created": z.number(),
+  // Name: GetJobScheduleById.updated
+  "updated": z.number(),
+});
+export type GetJobScheduleById = z.infer<typeof GetJobScheduleById>;
+
+// Name: JobSchedule
+export const JobSchedule = z.object({
+  // Name: JobSchedule.id
+  "id": z.string(),
+  // Name: JobSchedule.name
+  "name": z.string(),
+  // Name: JobSchedule.type
+  "type": z.string(),
+  // Name: JobSchedule.payload
+  "payload": z.array(JobPayloadValue),
+  // Name: JobSchedule.maxAttempts
+  "maxAttempts": z.number(),
+  // Name: JobSchedule.cron
+  "cron": z.string().nullable(),
+  // Name: JobSchedule.interval
+  "interval": z.string().nullable(),
+  // Name: JobSchedule.enabled
+  "enabled": z.boolean(),
+  // Name: JobSchedule.fromConfig
+  "fromConfig": z.boolean(),
+  // Name: JobSchedule.lastRun
+  "lastRun": z.number().nullable(),
+  // Name: JobSchedule.nextRun
+  "nextRun": z.number().nullable(),
+  // Name: JobSchedule.lastJobId
+  "lastJobId": z.string().nullable(),
+  // Name: JobSchedule.created
+  "created": z.number(),
+  // Name: JobSchedule.updated
+  "updated": z.number(),
+});
+export type JobSchedule = z.infer<typeof JobSchedule>;
+
+// Name: GetJobSchedules
+export const GetJobSchedules = z.object({
+  // Name: GetJobSchedules.schedules
+  "schedules": z.array(JobSchedule),
+});
+export type GetJobSchedules = z.infer<typeof GetJobSchedules>;
+
+// Name: Job
+export const Job = z.object({
+  // Name: Job.id
+  "id": z.string(),
+  // Name: Job.type
+  "type": z.string(),
+  // Name: Job.status
+  "status": z.string(),
+  // Name: Job.priority
+  "priority": z.number(),
+  // Name: Job.runAt
+  "runAt": z.number(),
+  // Name: Job.attempts
+  "attempts": z.number(),
+  // Name: Job.maxAttempts
+  "maxAttempts": z.number(),
+  // Name: Job.payload
+  "payload": z.array(JobPayloadValue),
+  // Name: Job.error
+  "error": z.string().nullable(),
+  // Name: Job.userId
+  "userId": z.string().nullable(),
+  // Name: Job.workerId
+  "workerId": z.string().nullable(),
+  // Name: Job.heartbeat
+  "heartbeat": z.number().nullable(),
+  // Name: Job.leaseExpires
+  "leaseExpires": z.number().nullable(),
+  // Name: Job.created
+  "created": z.number(),
+  // Name: Job.updated
+  "updated": z.number(),
+});
+export type Job = z.infer<typeof Job>;
+
+// Name: GetJobs
+export const GetJobs = z.object({
+  // Name: GetJobs.page
+  "page": Page,
+  // Name: GetJobs.jobs
+  "jobs": z.array(Job),
+});
+export type GetJobs = z.infer<typeof GetJobs>;
+
 // Name: GetMe
 export const GetMe = z.object({
   // Name: GetMe.id
@@ -548,9 +777,22 @@ export const GetMe = z.object({
   "role": z.string(),
   // Name: GetMe.displayName
   "displayName": z.string(),
+  // Name: GetMe.region
+  "region": z.string().nullable(),
 });
 export type GetMe = z.infer<typeof GetMe>;
 
+// Name: MediaFieldSource
+export const MediaFieldSource = z.object({
+  // Name: MediaFieldSource.field
+  "field": z.string(),
+  // Name: MediaFieldSource.providerName
+  "providerName": z.string(),
+  // Name: MediaFieldSource.providerDisplayName
+  "providerDisplayName": z.string(),
+});
+export type MediaFieldSource = z.infer<typeof MediaFieldSource>;
+
 // Name: MediaRelease
 export const MediaRelease = z.object({
   // Name: MediaRelease.releaseType
@@ -612,6 +854,8 @@ export const Media = z.object({
   "defaultProvider": z.string().nullable(),
   // Name: Media.providers
   "providers": z.array(ProviderValue),
+  // Name: Media.fieldSources
+  "fieldSources": z.array(MediaFieldSource),
   // Name: Media.user
   "user": MediaUser.nullable().optional(),
   // Name: Media.release
@@ -628,6 +872,64 @@ export const GetMedia = z.object({
 });
 export type GetMedia = z.infer<typeof GetMedia>;
 
+// Name: MediaAvailability
+export const MediaAvailability = z.object({
+  // Name: MediaAvailability.region
+  "region": z.string(),
+  // Name: MediaAvailability.type
+  "type": z.string(),
+  // Name: MediaAvailability.service
+  "service": z.string(),
+  // Name: MediaAvailability.serviceName
+  "serviceName": z.string(),
+  // Name: MediaAvailability.providerName
+  "providerName": z.string(),
+});
+export type MediaAvailability = z.infer<typeof MediaAvailability>;
+
+// Name: GetMediaAvailability
+export const GetMediaAvailability = z.object({
+  // Name: GetMediaAvailability.region
+  "region": z.string(),
+  // Name: GetMediaAvailability.availability
+  "availability": z.array(MediaAvailability),
+});
+export type GetMediaAvailability = z.infer<typeof GetMediaAvailability>;
+
+// Name: MediaThemeSong
+export const MediaThemeSong = z.object({
+  // Name: MediaThemeSong.type
+  "type": z.string(),
+  // Name: MediaThemeSong.index
+  "index": z.number(),
+  // Name: MediaThemeSong.name
+  "name": z.string(),
+  // Name: MediaThemeSong.artist
+  "artist": z.string(),
+});
+export type MediaThemeSong = z.infer<typeof MediaThemeSong>;
+
+// Name: MediaVideo
+export const MediaVideo = z.object({
+  // Name: MediaVideo.site
+  "site": z.string(),
+  // Name: MediaVideo.key
+  "key": z.string(),
+  // Name: MediaVideo.type
+  "type": z.string(),
+  // Name: MediaVideo.name
+  "name": z.string(),
+  // Name: MediaVideo.language
+  "language": z.string(),
+  // Name: MediaVideo.official
+  "official": z.boolean(),
+  // Name: MediaVideo.url
+  "url": z.string(),
+  // Name: MediaVideo.embedUrl
+  "embedUrl": z.string(),
+});
+export type MediaVideo = z.infer<typeof MediaVideo>;
+
 // Name: GetMediaById
 export const GetMediaById = z.object({
   // Name: GetMediaById.id
@@ -666,13 +968,47 @@ export const GetMediaById = z.object({
   "defaultProvider": z.string().nullable(),
   // Name: GetMediaById.providers
   "providers": z.array(ProviderValue),
+  // Name: GetMediaById.fieldSources
+  "fieldSources": z.array(MediaFieldSource),
   // Name: GetMediaById.user
   "user": MediaUser.nullable().optional(),
   // Name: GetMediaById.release
   "release": MediaRelease.nullable(),
+  // Name: GetMediaById.themeSongs
+  "themeSongs": z.array(MediaThemeSong),
+  // Name: GetMediaById.videos
+  "videos": z.array(MediaVideo),
 });
 export type GetMediaById = z.infer<typeof GetMediaById>;
 
+// Name: MediaImage
+export const MediaImage = z.object({
+  // Name: MediaImage.file
+  "file": z.string(),
+  // Name: MediaImage.type
+  "type": z.string(),
+  // Name: MediaImage.providerName
+  "providerName": z.string(),
+  // Name: MediaImage.providerDisplayName
+  "providerDisplayName": z.string(),
+  // Name: MediaImage.url
+  "url": z.string(),
+  // Name: MediaImage.isCover
+  "isCover": z.boolean(),
+  // Name: MediaImage.isBanner
+  "isBanner": z.boolean(),
+  // Name: MediaImage.isLogo
+  "isLogo": z.boolean(),
+});
+export type MediaImage = z.infer<typeof MediaImage>;
+
+// Name: GetMediaImages
+export const GetMediaImages = z.object({
+  // Name: GetMediaImages.images
+  "images": z.array(MediaImage),
+});
+export type GetMediaImages = z.infer<typeof GetMediaImages>;
+
 // Name: MediaPart
 export const MediaPart = z.object({
   // Name: MediaPart.index
@@ -693,6 +1029,176 @@ export const GetMediaParts = z.object({
 });
 export type GetMediaParts = z.infer<typeof GetMediaParts>;
 
+// Name: MediaPerson
+export const MediaPerson = z.object({
+  // Name: MediaPerson.personId
+  "personId": z.string(),
+  // Name: MediaPerson.personType
+  "personType": z.string(),
+  // Name: MediaPerson.name
+  "name": z.string(),
+  // Name: MediaPerson.role
+  "role": z.string(),
+  // Name: MediaPerson.character
+  "character": z.string(),
+});
+export type MediaPerson = z.infer<typeof MediaPerson>;
+
+// Name: GetMediaPeople
+export const GetMediaPeople = z.object({
+  // Name: GetMediaPeople.people
+  "people": z.array(MediaPerson),
+});
+export type GetMediaPeople = z.infer<typeof GetMediaPeople>;
+
+// Name: MediaRelation
+export const MediaRelation = z.object({
+  // Name: MediaRelation.type
+  "type": z.string(),
+  // Name: MediaRelation.providerName
+  "providerName": z.string(),
+  // Name: MediaRelation.providerDisplayName
+  "providerDisplayName": z.string(),
+  // Name: MediaRelation.providerId
+  "providerId": z.string(),
+  // Name: MediaRelation.title
+  "title": z.string(),
+  // Name: MediaRelation.media
+  "media": Media.nullable(),
+});
+export type MediaRelation = z.infer<typeof MediaRelation>;
+
+// Name: GetMediaRelations
+export const GetMediaRelations = z.object({
+  // Name: GetMediaRelations.relations
+  "relations": z.array(MediaRelation),
+});
+export type GetMediaRelations = z.infer<typeof GetMediaRelations>;
+
+// Name: MissingMediaRelationSource
+export const MissingMediaRelationSource = z.object({
+  // Name: MissingMediaRelationSource.type
+  "type": z.string(),
+  // Name: MissingMediaRelationSource.mediaId
+  "mediaId": z.string(),
+  // Name: MissingMediaRelationSource.mediaTitle
+  "mediaTitle": z.string(),
+});
+export type MissingMediaRelationSource = z.infer<typeof MissingMediaRelationSource>;
+
+// Name: MissingMediaRelation
+export const MissingMediaRelation = z.object({
+  // Name: MissingMediaRelation.providerName
+  "providerName": z.string(),
+  // Name: MissingMediaRelation.providerDisplayName
+  "providerDisplayName": z.string(),
+  // Name: MissingMediaRelation.providerId
+  "providerId": z.string(),
+  // Name: MissingMediaRelation.title
+  "title": z.string(),
+  // Name: MissingMediaRelation.canImport
+  "canImport": z.boolean(),
+  // Name: MissingMediaRelation.sources
+  "sources": z.array(MissingMediaRelationSource),
+});
+export type MissingMediaRelation = z.infer<typeof MissingMediaRelation>;
+
+// Name: GetMissingMediaRelations
+export const GetMissingMediaRelations = z.object({
+  // Name: GetMissingMediaRelations.relations
+  "relations": z.array(MissingMediaRelation),
+});
+export type GetMissingMediaRelations = z.infer<typeof GetMissingMediaRelations>;
+
+// Name: PersonCredit
+export const PersonCredit = z.object({
+  // Name: PersonCredit.role
+  "role": z.string(),
+  // Name: PersonCredit.character
+  "character": z.string(),
+});
+export type PersonCredit = z.infer<typeof PersonCredit>;
+
+// Name: PersonMedia
+export const PersonMedia = z.object({
+  // Name: PersonMedia.media
+  "media": Media,
+  // Name: PersonMedia.credits
+  "credits": z.array(PersonCredit),
+});
+export type PersonMedia = z.infer<typeof PersonMedia>;
+
+// Name: GetPersonById
+export const GetPersonById = z.object({
+  // Name: GetPersonById.id
+  "id": z.string(),
+  // Name: GetPersonById.type
+  "type": z.string(),
+  // Name: GetPersonById.name
+  "name": z.string(),
+  // Name: GetPersonById.providers
+  "providers": z.array(ProviderValue),
+  // Name: GetPersonById.media
+  "media": z.array(PersonMedia),
+});
+export type GetPersonById = z.infer<typeof GetPersonById>;
+
+// Name: ProviderMultiSearchItem
+export const ProviderMultiSearchItem = z.object({
+  // Name: ProviderMultiSearchItem.providerName
+  "providerName": z.string(),
+  // Name: ProviderMultiSearchItem.providerDisplayName
+  "providerDisplayName": z.string(),
+  // Name: ProviderMultiSearchItem.providerId
+  "providerId": z.string(),
+  // Name: ProviderMultiSearchItem.title
+  "title": z.string(),
+  // Name: ProviderMultiSearchItem.imageUrl
+  "imageUrl": z.string(),
+  // Name: ProviderMultiSearchItem.mediaType
+  "mediaType": z.string(),
+  // Name: ProviderMultiSearchItem.year
+  "year": z.number().nullable(),
+  // Name: ProviderMultiSearchItem.mediaId
+  "mediaId": z.string().nullable(),
+});
+export type ProviderMultiSearchItem = z.infer<typeof ProviderMultiSearchItem>;
+
+// Name: ProviderMultiSearchResult
+export const ProviderMultiSearchResult = z.object({
+  // Name: ProviderMultiSearchResult.title
+  "title": z.string(),
+  // Name: ProviderMultiSearchResult.imageUrl
+  "imageUrl": z.string(),
+  // Name: ProviderMultiSearchResult.mediaType
+  "mediaType": z.string(),
+  // Name: ProviderMultiSearchResult.year
+  "year": z.number().nullable(),
+  // Name: ProviderMultiSearchResult.mediaId
+  "mediaId": z.string().nullable(),
+  // Name: ProviderMultiSearchResult.items
+  "items": z.array(ProviderMultiSearchItem),
+});
+export type ProviderMultiSearchResult = z.infer<typeof ProviderMultiSearchResult>;
+
+// Name: ProviderSearchError
+export const ProviderSearchError = z.object({
+  // Name: ProviderSearchError.providerName
+  "providerName": z.string(),
+  // Name: ProviderSearchError.message
+  "message": z.string(),
+});
+export type ProviderSearchError = z.infer<typeof ProviderSearchError>;
+
+// Name: GetProviderMultiSearch
+export const GetProviderMultiSearch = z.object({
+  // Name: GetProviderMultiSearch.results
+  "results": z.array(ProviderMultiSearchResult),
+  // Name: GetProviderMultiSearch.errors
+  "errors": z.array(ProviderSearchError),
+});
+export type GetProviderMultiSearch = z.infer<typeof GetProviderMultiSearch>;
+
 // Name: ProviderSearchResult
 export const ProviderSearchResult = z.object({
   // Name: ProviderSearchResult.providerName
@@ -703,6 +1209,10 @@ export const ProviderSearchResult = z.object({
   "title": z.string(),
   // Name: ProviderSearchResult.imageUrl
   "imageUrl": z.string(),
+  // Name: ProviderSearchResult.mediaType
+  "mediaType": z.string(),
+  // Name: ProviderSearchResult.year
+  "year": z.number(),
 });
 export type ProviderSearchResult = z.infer<typeof ProviderSearchResult>;
 
@@ -713,6 +1223,54 @@ export const GetProviderSearch = z.object({
 });
 export type GetProviderSearch = z.infer<typeof GetProviderSearch>;
 
+// Name: ProviderSearchPage
+export const ProviderSearchPage = z.object({
+  // Name: ProviderSearchPage.page
+  "page": z.number(),
+  // Name: ProviderSearchPage.totalPages
+  "totalPages": z.number(),
+  // Name: ProviderSearchPage.hasNextPage
+  "hasNextPage": z.boolean(),
+});
+export type ProviderSearchPage = z.infer<typeof ProviderSearchPage>;
+
+// Name: GetProviderSearchMedia
+export const GetProviderSearchMedia = z.object({
+  // Name: GetProviderSearchMedia.page
+  "page": ProviderSearchPage,
+  // Name: GetProviderSearchMedia.searchResults
+  "searchResults": z.array(ProviderSearchResult),
+});
+export type GetProviderSearchMedia = z.infer<typeof GetProviderSearchMedia>;
+
+// Name: ProviderSeasonalEntry
+export const ProviderSeasonalEntry = z.object({
+  // Name: ProviderSeasonalEntry.providerId
+  "providerId": z.string(),
+  // Name: ProviderSeasonalEntry.title
+  "title": z.string(),
+  // Name: ProviderSeasonalEntry.imageUrl
+  "imageUrl": z.string(),
+  // Name: ProviderSeasonalEntry.mediaType
+  "mediaType": z.string(),
+  // Name: ProviderSeasonalEntry.year
+  "year": z.number().nullable(),
+  // Name: ProviderSeasonalEntry.mediaId
+  "mediaId": z.string().nullable(),
+});
+export type ProviderSeasonalEntry = z.infer<typeof ProviderSeasonalEntry>;
+
+// Name: GetProviderSeasonal
+export const GetProviderSeasonal = z.object({
+  // Name: GetProviderSeasonal.season
+  "season": z.string(),
+  // Name: GetProviderSeasonal.year
+  "year": z.number(),
+  // Name: GetProviderSeasonal.entries
+  "entries": z.array(ProviderSeasonalEntry),
+});
+export type GetProviderSeasonal = z.infer<typeof GetProviderSeasonal>;
+
 // Name: ProviderSupports
 export const ProviderSupports = z.object({
   // Name: ProviderSupports.getMedia
@@ -734,6 +1292,10 @@ export const Provider = z.object({
   "displayName": z.string(),
   // Name: Provider.supports
   "supports": ProviderSupports,
+  // Name: Provider.disabled
+  "disabled": z.boolean(),
+  // Name: Provider.disabledReason
+  "disabledReason": z.string().nullable(),
 });
 export type Provider = z.infer<typeof Provider>;
 
@@ -943,6 +1505,22 @@ export const GetUserStats = z.object({
 });
 export type GetUserStats = z.infer<typeof GetUserStats>;
 
+// Name: ImportMalAnimeList
+export const ImportMalAnimeList = z.object({
+  // Name: ImportMalAnimeList.jobId
+  "jobId": z.string(),
+});
+export type ImportMalAnimeList = z.infer<typeof ImportMalAnimeList>;
+
+// Name: MediaImageProviderError
+export const MediaImageProviderError = z.object({
+  // Name: MediaImageProviderError.providerName
+  "providerName": z.string(),
+  // Name: MediaImageProviderError.message
+  "message": z.string(),
+});
+export type MediaImageProviderError = z.infer<typeof MediaImageProviderError>;
+
 // Name: PartBody
 export const PartBody = z.object({
   // Name: PartBody.name
@@ -952,6 +1530,19 @@ export const PartBody = z.object({
 });
 export type PartBody = z.infer<typeof PartBody>;
 
+// Name: Person
+export const Person = z.object({
+  // Name: Person.id
+  "id": z.string(),
+  // Name: Person.type
+  "type": z.string(),
+  // Name: Person.name
+  "name": z.string(),
+  // Name: Person.providers
+  "providers": z.array(ProviderValue),
+});
+export type Person = z.infer<typeof Person>;
+
 // Name: PostProviderImportCollectionsBody
 export const PostProviderImportCollectionsBody = z.object({
   // Name: PostProviderImportCollectionsBody.ids
@@ -966,6 +1557,75 @@ export const PostProviderImportMediaBody = z.object({
 });
 export type PostProviderImportMediaBody = z.infer<typeof PostProviderImportMediaBody>;
 
+// Name: PostProviderImportSeasonal
+export const PostProviderImportSeasonal = z.object({
+  // Name: PostProviderImportSeasonal.jobId
+  "jobId": z.string(),
+});
+export type PostProviderImportSeasonal = z.infer<typeof PostProviderImportSeasonal>;
+
+// Name: PostProviderImportSeasonalBody
+export const PostProviderImportSeasonalBody = z.object({
+  // Name: PostProviderImportSeasonalBody.ids
+  "ids": z.array(z.string()),
+});
+export type PostProviderImportSeasonalBody = z.infer<typeof PostProviderImportSeasonalBody>;
+
+// Name: ProviderResolvedLink
+export const ProviderResolvedLink = z.object({
+  // Name: ProviderResolvedLink.link
+  "link": z.string(),
+  // Name: ProviderResolvedLink.providerName
+  "providerName": z.string(),
+  // Name: ProviderResolvedLink.providerDisplayName
+  "providerDisplayName": z.string(),
+  // Name: ProviderResolvedLink.providerId
+  "providerId": z.string(),
+  // Name: ProviderResolvedLink.type
+  "type": z.string(),
+  // Name: ProviderResolvedLink.mediaId
+  "mediaId": z.string().nullable(),
+  // Name: ProviderResolvedLink.collectionId
+  "collectionId": z.string().nullable(),
+});
+export type ProviderResolvedLink = z.infer<typeof ProviderResolvedLink>;
+
+// Name: ProviderLinkError
+export const ProviderLinkError = z.object({
+  // Name: ProviderLinkError.link
+  "link": z.string(),
+  // Name: ProviderLinkError.message
+  "message": z.string(),
+});
+export type ProviderLinkError = z.infer<typeof ProviderLinkError>;
+
+// Name: PostProviderResolveLinks
+export const PostProviderResolveLinks = z.object({
+  // Name: PostProviderResolveLinks.results
+  "results": z.array(ProviderResolvedLink),
+  // Name: PostProviderResolveLinks.errors
+  "errors": z.array(ProviderLinkError),
+});
+export type PostProviderResolveLinks = z.infer<typeof PostProviderResolveLinks>;
+
+// Name: PostProviderResolveLinksBody
+export const PostProviderResolveLinksBody = z.object({
+  // Name: PostProviderResolveLinksBody.links
+  "links": z.array(z.string()),
+  // Name: PostProviderResolveLinksBody.import
+  "import": z.boolean(),
+});
+export type PostProviderResolveLinksBody = z.infer<typeof PostProviderResolveLinksBody>;
+
+// Name: PromoteMediaImageBody
+export const PromoteMediaImageBody = z.object({
+  // Name: PromoteMediaImageBody.file
+  "file": z.string(),
+  // Name: PromoteMediaImageBody.type
+  "type": z.string(),
+});
+export type PromoteMediaImageBody = z.infer<typeof PromoteMediaImageBody>;
+
 // Name: ProviderCollectionUpdateBody
 export const ProviderCollectionUpdateBody = z.object({
   // Name: ProviderCollectionUpdateBody.replaceImages
@@ -981,9 +1641,43 @@ export const ProviderMediaUpdateBody = z.object({
   "overrideParts": z.boolean().optional(),
   // Name: ProviderMediaUpdateBody.setRelease
   "setRelease": z.boolean().optional(),
+  // Name: ProviderMediaUpdateBody.mergeProviders
+  "mergeProviders": z.boolean().optional(),
 });
 export type ProviderMediaUpdateBody = z.infer<typeof ProviderMediaUpdateBody>;
 
+// Name: RefreshMediaImages
+export const RefreshMediaImages = z.object({
+  // Name: RefreshMediaImages.added
+  "added": z.number(),
+  // Name: RefreshMediaImages.errors
+  "errors": z.array(MediaImageProviderError),
+});
+export type RefreshMediaImages = z.infer<typeof RefreshMediaImages>;
+
+// Name: ResolveMediaProviders
+export const ResolveMediaProviders = z.object({
+  // Name: ResolveMediaProviders.added
+  "added": z.array(z.string()),
+  // Name: ResolveMediaProviders.providers
+  "providers": z.array(ProviderValue),
+});
+export type ResolveMediaProviders = z.infer<typeof ResolveMediaProviders>;
+
+// Name: ResolveMediaProvidersBody
+export const ResolveMediaProvidersBody = z.object({
+  // Name: ResolveMediaProvidersBody.fetchProviders
+  "fetchProviders": z.boolean().optional(),
+});
+export type ResolveMediaProvidersBody = z.infer<typeof ResolveMediaProvidersBody>;
+
+// Name: RunJobSchedule
+export const RunJobSchedule = z.object({
+  // Name: RunJobSchedule.jobId
+  "jobId": z.string(),
+});
+export type RunJobSchedule = z.infer<typeof RunJobSchedule>;
+
 // Name: SetMediaReleaseBody
 export const SetMediaReleaseBody = z.object({
   // Name: SetMediaReleaseBody.releaseType
@@ -1063,6 +1757,8 @@ export type SignupBody = z.infer<typeof SignupBody>;
 export const UpdateUserSettingsBody = z.object({
   // Name: UpdateUserSettingsBody.displayName
   "displayName": z.string().nullable().optional(),
+  // Name: UpdateUserSettingsBody.region
+  "region": z.string().nullable().optional(),
 });
 export type UpdateUserSettingsBody = z.infer<typeof UpdateUserSettingsBody>;
 
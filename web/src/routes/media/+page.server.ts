@@ -38,6 +38,15 @@ function constructFilterSort(
     filters.push(`hasTag(${s})`);
   }
 
+  // NOTE(patrik): availableOn only takes one service so the services is
+  // combined with or
+  if (filter.filters.availableOn.length > 0) {
+    const s = filter.filters.availableOn
+      .map((i) => `availableOn("${i}")`)
+      .join(" || ");
+    filters.push(`(${s})`);
+  }
+
   if (filter.excludes.type.length > 0) {
     const s = filter.excludes.type.map((i) => `"${i}"`).join(",");
     filters.push(`!hasType(${s})`);
@@ -95,6 +104,8 @@ export const load: PageServerLoad = async ({ locals, url }) => {
       rating: url.searchParams.get("filterRating")?.split(",") ?? [],
       creators: url.searchParams.get("filterCreators")?.split(",") ?? [],
       tags: url.searchParams.get("filterTags")?.split(",") ?? [],
+      availableOn:
+        url.searchParams.get("filterAvailableOn")?.split(",") ?? [],
     },
     excludes: {
       type: url.searchParams.get("excludeType")?.split(",") ?? [],
@@ -0,0 +1,21 @@
+import { error } from "@sveltejs/kit";
+import type { PageServerLoad } from "./$types";
+
+export const load: PageServerLoad = async ({ locals, params }) => {
+  const res = await locals.apiClient.getMediaPeople(params.id);
+  if (!res.success) {
+    throw error(res.error.code, { message: res.error.message });
+  }
+
+  const availability = await locals.apiClient.getMediaAvailability(params.id);
+  if (!availability.success) {
+    throw error(availability.error.code, {
+      message: availability.error.message,
+    });
+  }
+
+  return {
+    people: res.data.people,
+    availability: availability.data,
+  };
+};
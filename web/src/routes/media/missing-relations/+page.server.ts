@@ -0,0 +1,20 @@
+import { error } from "@sveltejs/kit";
+import type { PageServerLoad } from "./$types";
+
+export const load: PageServerLoad = async ({ locals, url }) => {
+  const query: Record<string, string> = {};
+
+  const types = url.searchParams.get("types");
+  if (types) {
+    query["types"] = types;
+  }
+
+  const res = await locals.apiClient.getMissingMediaRelations({ query });
+  if (!res.success) {
+    throw error(res.error.code, { message: res.error.message });
+  }
+
+  return {
+    relations: res.data.relations,
+  };
+};
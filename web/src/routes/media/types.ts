@@ -30,6 +30,7 @@ export const FullFilter = z.object({
     rating: z.array(MediaRatingEnum),
     creators: z.array(z.string()),
     tags: z.array(z.string()),
+    availableOn: z.array(z.string()),
   }),
   excludes: z.object({
     type: z.array(MediaTypeEnum),
@@ -0,0 +1,13 @@
+import { error } from "@sveltejs/kit";
+import type { PageServerLoad } from "./$types";
+
+export const load: PageServerLoad = async ({ locals, params }) => {
+  const res = await locals.apiClient.getPersonById(params.id);
+  if (!res.success) {
+    throw error(res.error.code, { message: res.error.message });
+  }
+
+  return {
+    person: res.data,
+  };
+};
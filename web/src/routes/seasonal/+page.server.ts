@@ -0,0 +1,26 @@
+import { error } from "@sveltejs/kit";
+import type { PageServerLoad } from "./$types";
+
+const seasons = ["winter", "spring", "summer", "fall"];
+
+export const load: PageServerLoad = async ({ locals, url }) => {
+  const now = new Date();
+
+  const season =
+    url.searchParams.get("season") ?? seasons[Math.floor(now.getMonth() / 3)];
+  const year = url.searchParams.get("year") ?? now.getFullYear().toString();
+
+  const res = await locals.apiClient.providerGetSeasonal(
+    "myanimelist-anime",
+    season,
+    year,
+  );
+  if (!res.success) {
+    throw error(res.error.code, { message: res.error.message });
+  }
+
+  return {
+    seasons,
+    seasonal: res.data,
+  };
+};
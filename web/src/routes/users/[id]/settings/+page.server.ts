@@ -7,7 +7,15 @@ export const load: PageServerLoad = async ({ locals }) => {
     throw error(res.error.code, { message: res.error.message });
   }
 
+  const jobs = await locals.apiClient.getMyJobs({
+    query: { perPage: "10" },
+  });
+  if (!jobs.success) {
+    throw error(jobs.error.code, { message: jobs.error.message });
+  }
+
   return {
     tokens: res.data.tokens,
+    jobs: jobs.data.jobs,
   };
 };